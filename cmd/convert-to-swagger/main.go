@@ -4,22 +4,61 @@ package main
 import (
 	"encoding/json"
 	"flag"
-	"fmt"
 	"log"
 	"os"
+	"strings"
 
+	"github.com/YogeLiu/api-tool/pkg/console"
 	"github.com/YogeLiu/api-tool/pkg/exporter"
 	"github.com/YogeLiu/api-tool/pkg/models"
 )
 
 func main() {
 	inputFile := flag.String("input", "api_output.json", "输入的API JSON文件路径")
-	outputDir := flag.String("output", "./swagger_exports", "输出目录")
+	outputDir := flag.String("output", "", "输出目录")
+	outputRoot := flag.String("output-dir", "", "统一输出目录，Swagger文档写入其中的 swagger 子目录，-output 优先")
 	projectName := flag.String("project", "API Documentation", "项目名称")
 	version := flag.String("version", "1.0.0", "API版本号")
 	baseURL := flag.String("baseurl", "http://localhost:8080", "基础URL")
 	successOnly := flag.Bool("success-only", true, "仅提取成功响应（忽略错误响应）")
+	envelopeField := flag.String("envelope-field", "data", "响应封装中业务数据的字段名（如 data、result），为空时不解包")
+	keepEnvelope := flag.Bool("keep-envelope", true, "解包时是否保留 code/message 等封装字段")
+	fieldCase := flag.String("field-case", exporter.FieldCaseNone, "输出属性键的命名风格 (none, camel, snake, pascal)，none 表示原样使用JSON标签")
+	modelPackages := flag.String("model-packages", "", "模型包路径，逗号分隔，其中的类型名原样作为schema名称，以 /... 结尾时匹配所有子包")
+	locale := flag.String("response-locale", exporter.LocaleEnglish, "响应描述的语言 (en, zh)")
+	strictSchemas := flag.Bool("strict-schemas", false, "为字段确定的对象schema设置 additionalProperties: false，任意结构的map和any对象除外")
+	splitBy := flag.String("split-by", "", "按标签、包或服务拆分为多个文件 (tag, package, server)，tag/package 生成 index.json 入口文档及跨文件 $ref，server 为每个根路由器输出一份完整文档，为空时输出单个文件")
+	compact := flag.Bool("compact", false, "输出紧凑的JSON（无缩进、无换行）")
+	redact := flag.String("redact", "", "需脱敏的字段名模式，逗号分隔，不区分大小写，支持 * 通配 (例如 password,*token*,secret)")
+	redactMode := flag.String("redact-mode", exporter.RedactModeMask, "脱敏字段的处理方式 (mask, omit)")
+	prettyNames := flag.Bool("pretty-names", false, "为schema生成便于阅读的 title、为标签生成 x-displayName (例如 UserInfo -> User Info)")
+	dedupSchemas := flag.Bool("dedup-schemas", false, "结构相同的匿名结构体复用同一个组件，减少重复的schema定义")
+	tags := flag.String("tag", "", "只导出指定标签的路由，逗号分隔，不区分大小写 (例如 Order,Member)")
+	groupBy := flag.String("group-by", "", "路由标签的分组方式 (package, path-prefix)，为空时按路径前缀")
+	sharedParams := flag.Int("shared-params", 0, "同名且结构相同的参数在至少 N 个操作中出现时提取到 components.parameters 并以 $ref 引用，0 表示不提取")
+	trimPrefix := flag.String("trim-package-prefix", "", "按包拆分时从包路径中去掉的前缀 (例如 github.com/org/service/internal)")
+	noEmoji := flag.Bool("no-emoji", false, "输出纯文本状态信息，不使用 emoji")
 	flag.Parse()
+	if *noEmoji {
+		console.SetNoEmoji(true)
+	}
+	log.SetOutput(console.Writer(os.Stderr))
+
+	if !exporter.IsValidFieldCase(*fieldCase) {
+		log.Fatalf("不支持的字段命名风格: %s (可选: none, camel, snake, pascal)", *fieldCase)
+	}
+	if !exporter.IsValidLocale(*locale) {
+		log.Fatalf("不支持的响应描述语言: %s (可选: en, zh)", *locale)
+	}
+	if !exporter.IsValidSplitBy(*splitBy) {
+		log.Fatalf("不支持的拆分方式: %s (可选: tag, package, server)", *splitBy)
+	}
+	if !exporter.IsValidRedactMode(*redactMode) {
+		log.Fatalf("不支持的脱敏方式: %s (可选: mask, omit)", *redactMode)
+	}
+	if !exporter.IsValidGroupBy(*groupBy) {
+		log.Fatalf("不支持的分组方式: %s (可选: package, path-prefix)", *groupBy)
+	}
 
 	log.Printf("正在读取文件: %s", *inputFile)
 
@@ -36,51 +75,85 @@ func main() {
 	}
 
 	// 转换为APIInfo格式
-	apiInfo := convertToAPIInfo(rawAPIInfo, *successOnly)
+	apiInfo := convertToAPIInfo(rawAPIInfo)
 
 	log.Printf("找到 %d 个API接口", len(apiInfo.Routes))
 
 	// 创建Swagger导出器
-	swaggerExporter := exporter.NewSwaggerExporter(*projectName, *version, *baseURL, *outputDir, *successOnly)
+	swaggerExporter := exporter.NewSwaggerExporter(*projectName, *version, *baseURL, exporter.ResolveOutputDir(*outputDir, *outputRoot, exporter.OutputFormatSwagger), *successOnly)
+	swaggerExporter.SetOptions(exporter.Options{
+		EnvelopeField: *envelopeField,
+		KeepEnvelope:  *keepEnvelope,
+		FieldCase:     *fieldCase,
+		ModelPackages: splitList(*modelPackages),
+		StrictSchemas: *strictSchemas,
+		Locale:        *locale,
+		SplitBy:       *splitBy,
+		Compact:       *compact,
+		RedactFields:  splitList(*redact),
+		RedactMode:    *redactMode,
+		PrettyNames:   *prettyNames,
+		DedupSchemas:  *dedupSchemas,
+		Tags:          splitList(*tags),
+
+		TrimPackagePrefix: *trimPrefix,
+		SharedParameters:  *sharedParams,
+		GroupBy:           *groupBy,
+	})
 
 	// 导出Swagger格式
 	if err := swaggerExporter.Export(apiInfo); err != nil {
 		log.Fatalf("Swagger导出失败: %v", err)
 	}
 
-	fmt.Println("✅ 转换完成！")
+	console.Println("✅ 转换完成！")
 	if *successOnly {
-		fmt.Println("📝 注意: 仅提取了成功响应字段，已过滤错误响应")
+		console.Printf("📝 注意: 仅提取了成功响应的 %s 字段，已过滤错误响应\n", *envelopeField)
 	}
-	fmt.Println("💡 您可以将生成的JSON文件导入到Swagger Editor或其他OpenAPI工具中")
+	console.Println("💡 您可以将生成的JSON文件导入到Swagger Editor或其他OpenAPI工具中")
 }
 
 // convertToAPIInfo 将原始JSON转换为APIInfo格式
-func convertToAPIInfo(rawData map[string]interface{}, successOnly bool) *models.APIInfo {
+func convertToAPIInfo(rawData map[string]interface{}) *models.APIInfo {
 	routes := []models.RouteInfo{}
 
 	if routesData, ok := rawData["routes"].([]interface{}); ok {
 		for _, routeData := range routesData {
 			if routeMap, ok := routeData.(map[string]interface{}); ok {
-				route := convertRoute(routeMap, successOnly)
+				route := convertRoute(routeMap)
 				routes = append(routes, route)
 			}
 		}
 	}
 
 	return &models.APIInfo{
-		Routes: routes,
+		Routes:   routes,
+		Warnings: getStringSlice(rawData, "warnings"),
 	}
 }
 
 // convertRoute 转换单个路由
-func convertRoute(routeMap map[string]interface{}, successOnly bool) models.RouteInfo {
+func convertRoute(routeMap map[string]interface{}) models.RouteInfo {
 	route := models.RouteInfo{
 		PackageName: getString(routeMap, "package_name"),
 		PackagePath: getString(routeMap, "package_path"),
 		Method:      getString(routeMap, "method"),
 		Path:        getString(routeMap, "path"),
 		Handler:     getString(routeMap, "handler"),
+		HandlerFile: getString(routeMap, "handler_file"),
+		Deprecated:  getBool(routeMap, "deprecated"),
+		Kind:        getString(routeMap, "kind"),
+		Server:      getString(routeMap, "server"),
+		Since:       getString(routeMap, "since"),
+		Summary:     getString(routeMap, "summary"),
+		Description: getString(routeMap, "description"),
+
+		HandlerUnresolved: getBool(routeMap, "handler_unresolved"),
+
+		SuccessStatus:       getInt(routeMap, "success_status"),
+		ResponseDescription: getString(routeMap, "response_description"),
+		Middlewares:         getStringSlice(routeMap, "middlewares"),
+		MiddlewareRendered:  getBool(routeMap, "middleware_rendered"),
 	}
 
 	// 转换请求参数
@@ -90,7 +163,17 @@ func convertRoute(routeMap map[string]interface{}, successOnly bool) models.Rout
 
 	// 转换响应结构
 	if responseSchema, ok := routeMap["response_schema"].(map[string]interface{}); ok {
-		route.ResponseSchema = convertResponseSchema(responseSchema, successOnly)
+		route.ResponseSchema = convertAPISchema(responseSchema)
+	}
+
+	// 转换按状态码分组的响应结构
+	if responses, ok := routeMap["responses"].(map[string]interface{}); ok {
+		route.Responses = make(map[string]*models.APISchema, len(responses))
+		for status, responseData := range responses {
+			if responseMap, ok := responseData.(map[string]interface{}); ok {
+				route.Responses[status] = convertAPISchema(responseMap)
+			}
+		}
 	}
 
 	return route
@@ -107,6 +190,7 @@ func convertRequestParams(paramsData []interface{}) []models.RequestParamInfo {
 				ParamName:   getString(paramMap, "param_name"),
 				IsRequired:  getBool(paramMap, "is_required"),
 				Source:      getString(paramMap, "source"),
+				ContentType: getString(paramMap, "content_type"),
 				ParamSchema: convertAPISchema(getMap(paramMap, "param_schema")),
 			}
 			params = append(params, param)
@@ -116,20 +200,6 @@ func convertRequestParams(paramsData []interface{}) []models.RequestParamInfo {
 	return params
 }
 
-// convertResponseSchema 转换响应结构
-func convertResponseSchema(schemaMap map[string]interface{}, successOnly bool) *models.APISchema {
-	if successOnly {
-		// 只提取data字段
-		if properties, ok := schemaMap["properties"].(map[string]interface{}); ok {
-			if dataField, ok := properties["data"].(map[string]interface{}); ok {
-				return convertAPISchema(dataField)
-			}
-		}
-	}
-
-	return convertAPISchema(schemaMap)
-}
-
 // convertAPISchema 转换API Schema
 func convertAPISchema(schemaMap map[string]interface{}) *models.APISchema {
 	if schemaMap == nil {
@@ -140,6 +210,17 @@ func convertAPISchema(schemaMap map[string]interface{}) *models.APISchema {
 		Type:        getString(schemaMap, "type"),
 		Description: getString(schemaMap, "description"),
 		JSONTag:     getString(schemaMap, "json_tag"),
+		FormTag:     getString(schemaMap, "form_tag"),
+		Package:     getString(schemaMap, "package"),
+		Format:      getString(schemaMap, "format"),
+		ContentType: getString(schemaMap, "content_type"),
+		Nullable:    getBool(schemaMap, "nullable"),
+		Required:    getBool(schemaMap, "required"),
+		ReadOnly:    getBool(schemaMap, "read_only"),
+		WriteOnly:   getBool(schemaMap, "write_only"),
+		Default:     schemaMap["default"],
+		Example:     schemaMap["example"],
+		Map:         getBool(schemaMap, "map"),
 	}
 
 	// 转换properties
@@ -157,10 +238,29 @@ func convertAPISchema(schemaMap map[string]interface{}) *models.APISchema {
 		schema.Items = convertAPISchema(items)
 	}
 
+	// 转换one_of
+	if oneOf, ok := schemaMap["one_of"].([]interface{}); ok {
+		for _, candidate := range oneOf {
+			if candidateMap, ok := candidate.(map[string]interface{}); ok {
+				schema.OneOf = append(schema.OneOf, convertAPISchema(candidateMap))
+			}
+		}
+	}
+
 	return schema
 }
 
 // 辅助函数
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getString(m map[string]interface{}, key string) string {
 	if val, ok := m[key].(string); ok {
 		return val
@@ -175,6 +275,25 @@ func getBool(m map[string]interface{}, key string) bool {
 	return false
 }
 
+func getInt(m map[string]interface{}, key string) int {
+	if val, ok := m[key].(float64); ok {
+		return int(val)
+	}
+	return 0
+}
+
+func getStringSlice(m map[string]interface{}, key string) []string {
+	var values []string
+	if items, ok := m[key].([]interface{}); ok {
+		for _, item := range items {
+			if val, ok := item.(string); ok {
+				values = append(values, val)
+			}
+		}
+	}
+	return values
+}
+
 func getMap(m map[string]interface{}, key string) map[string]interface{} {
 	if val, ok := m[key].(map[string]interface{}); ok {
 		return val
@@ -0,0 +1,602 @@
+// 文件位置: cmd/my-tool/analyze.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/YogeLiu/api-tool/helper"
+	"github.com/YogeLiu/api-tool/pkg/analyzer"
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/exporter"
+	"github.com/YogeLiu/api-tool/pkg/extractor"
+	"github.com/YogeLiu/api-tool/pkg/models"
+	"github.com/YogeLiu/api-tool/pkg/parser"
+)
+
+// analysisFlags analyze/export/serve 共用的分析参数
+type analysisFlags struct {
+	projectPath   string
+	framework     string
+	projectName   string
+	pathFilter    string
+	basePath      string
+	exportedOnly  bool
+	failOnUnknown bool
+	includeTests  bool
+	timing        bool
+	noEmoji       bool
+	typeMap       string
+	overridesFile string
+	queryTags     string
+	maxRoutes     int
+	responders    string
+	annotations   bool
+	sourceLoc     bool
+	gitSince      bool
+	buildTags     string
+	goos          string
+	goarch        string
+
+	typeMappings map[string]helper.TypeMapping // 由 -type-map 解析的自定义类型映射
+	overrides    map[string]*helper.APISchema  // 由 -schema-overrides 加载的手写类型schema
+	responderSet []helper.Responder            // 由 -responder 解析的上下文响应器
+	timings      []models.PhaseTiming          // 各阶段耗时，-timing 时输出
+}
+
+// register 在子命令的 FlagSet 上注册分析参数
+func (f *analysisFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.projectPath, "path", ".", "要分析的 Go 项目的根路径。")
+	fs.StringVar(&f.framework, "framework", extractor.FrameworkAuto, "目标框架 (auto, gin, iris 或 beego)，auto 根据项目的导入自动检测。")
+	fs.StringVar(&f.projectName, "project", "", "项目名称 (可选)。")
+	fs.StringVar(&f.pathFilter, "filter", "", "路径过滤器，只显示包含指定路径的路由 (可选)。")
+	fs.StringVar(&f.basePath, "base-path", "", "路由前缀，如 /service-a (可选)：JSON 和 Apifox 输出添加到所有路由路径之前，Swagger 设置为 servers 的地址后缀，YAPI 设置为 basepath。")
+	fs.BoolVar(&f.exportedOnly, "exported-only", false, "仅包含导出的处理函数，跳过未导出函数和匿名函数。")
+	fs.BoolVar(&f.failOnUnknown, "fail-on-unknown", false, "存在处理函数、响应或请求体未能解析（unknown/any 或未检测到响应）的路由，或有导致响应无法解析的警告时以非零状态码退出（用于CI）。")
+	fs.BoolVar(&f.includeTests, "include-tests", false, "同时分析 _test.go 文件，包含在测试中注册的路由。")
+	fs.BoolVar(&f.noEmoji, "no-emoji", false, "输出纯文本状态信息，不使用 emoji（设置 NO_COLOR 环境变量时默认开启）。")
+	fs.StringVar(&f.typeMap, "type-map", "", "自定义类型的schema映射，逗号分隔的 包路径.类型名=类型[/格式] (例如 example.com/app/types.Email=string/email)。")
+	fs.StringVar(&f.overridesFile, "schema-overrides", "", "手写类型schema的JSON文件，内容为 {\"包路径.类型名\": schema}，用于自定义 MarshalJSON 等无法推断结构的类型。")
+	fs.StringVar(&f.queryTags, "query-tags", helper.DefaultQueryTag, "查询参数结构体字段命名使用的标签，逗号分隔，按优先级依次查找 (例如 query,form 优先使用第三方绑定库的 query 标签)。")
+	fs.StringVar(&f.responders, "responder", "", "存放在请求上下文中的响应器，分号分隔的 包路径.函数名:方法=数据参数索引[/状态码],... (例如 example.com/app/resp.FromCtx:OK=0,Fail=1/400)，handler 中 FromCtx(c).OK(data) 按响应封装函数处理。")
+	fs.IntVar(&f.maxRoutes, "max-routes", 0, "路由数量上限，超过时停止分析并报错，防止异常输入导致解析失控，0 表示不限制。")
+	fs.BoolVar(&f.annotations, "annotations", false, "解析处理函数注释中的 swaggo 风格注解 (@Summary、@Description、@Param、@Success)，与推断结果合并，冲突时以注解为准。")
+	fs.BoolVar(&f.sourceLoc, "include-source-location", false, "在路由信息中输出处理函数所在的源文件 (handler_file，相对项目根目录)，与起止行号一起用于IDE跳转。")
+	fs.BoolVar(&f.gitSince, "git-since", false, "通过 git log -L 查询处理函数首次提交的日期，标注为路由的引入日期 (since)，项目不在git仓库中时跳过。")
+	fs.StringVar(&f.buildTags, "tags", "", "构建标签，逗号分隔 (例如 prod,integration)，用于加载带 //go:build 约束的路由文件。")
+	fs.StringVar(&f.goos, "goos", "", "加载包时使用的目标操作系统 (GOOS)，默认为当前环境。")
+	fs.StringVar(&f.goarch, "goarch", "", "加载包时使用的目标架构 (GOARCH)，默认为当前环境。")
+	fs.BoolVar(&f.timing, "timing", false, "输出各分析阶段（解析、预处理、索引、递归解析、导出）的耗时及处理数量。")
+}
+
+// recordTiming 记录从 start 开始的阶段耗时
+func (f *analysisFlags) recordTiming(phase string, start time.Time, count int) {
+	f.timings = append(f.timings, models.PhaseTiming{Phase: phase, Duration: time.Since(start), Count: count})
+}
+
+// reportTimings 指定 -timing 时向标准错误输出各阶段耗时，并清空记录
+func (f *analysisFlags) reportTimings() {
+	if f.timing {
+		var total time.Duration
+		console.Fprintln(os.Stderr, "⏱  阶段耗时:")
+		for _, t := range f.timings {
+			fmt.Fprintf(os.Stderr, "  %-16s %10s  %d\n", t.Phase, t.Duration.Round(time.Microsecond), t.Count)
+			total += t.Duration
+		}
+		fmt.Fprintf(os.Stderr, "  %-16s %10s\n", "total", total.Round(time.Microsecond))
+	}
+	f.timings = nil
+}
+
+// parseArgs 解析参数，位置参数作为项目路径
+func (f *analysisFlags) parseArgs(fs *flag.FlagSet, args []string) {
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		f.projectPath = fs.Arg(0)
+	}
+	if f.noEmoji {
+		console.SetNoEmoji(true)
+	}
+	if f.projectName == "" {
+		f.projectName = filepath.Base(f.projectPath)
+	}
+
+	mappings, err := helper.ParseTypeMappings(splitList(f.typeMap))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	f.typeMappings = mappings
+
+	responders, err := helper.ParseResponders(splitListBy(f.responders, ";"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	f.responderSet = responders
+
+	if f.overridesFile != "" {
+		overrides, err := helper.LoadSchemaOverrides(f.overridesFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		f.overrides = overrides
+	}
+}
+
+// envelopeFlags 响应封装解包、字段命名及schema命名参数
+type envelopeFlags struct {
+	envelopeField string
+	keepEnvelope  bool
+	fieldCase     string
+	modelPackages string
+	strictSchemas bool
+	locale        string
+	splitBy       string
+	compact       bool
+	redact        string
+	redactMode    string
+	prettyNames   bool
+	dedupSchemas  bool
+	tags          string
+	trimPrefix    string
+	sharedParams  int
+	groupBy       string
+}
+
+func (f *envelopeFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.envelopeField, "envelope-field", "data", "响应封装中业务数据的字段名，Swagger仅成功响应模式下按此字段解包，为空时不解包。")
+	fs.BoolVar(&f.keepEnvelope, "keep-envelope", true, "解包时是否保留 code/message 等封装字段。")
+	fs.StringVar(&f.fieldCase, "field-case", exporter.FieldCaseNone, "输出属性键的命名风格 (none, camel, snake, pascal)，none 表示原样使用JSON标签。")
+	fs.StringVar(&f.modelPackages, "model-packages", "", "模型包路径，逗号分隔，其中的类型名原样作为schema名称，以 /... 结尾时匹配所有子包 (例如 example.com/app/dto/...)。")
+	fs.BoolVar(&f.strictSchemas, "strict-schemas", false, "为字段确定的对象schema设置 additionalProperties: false，任意结构的map和any对象除外。")
+	fs.StringVar(&f.locale, "response-locale", exporter.LocaleEnglish, "响应描述的语言 (en, zh)，en 使用标准HTTP原因短语 (如 201 Created)。")
+	fs.StringVar(&f.splitBy, "split-by", "", "Swagger按标签、包或服务拆分为多个文件 (tag, package, server)，tag/package 生成 index.json 入口文档及跨文件 $ref，server 为每个根路由器输出一份完整文档，为空时输出单个文件。")
+	fs.BoolVar(&f.compact, "compact", false, "输出紧凑的JSON（无缩进、无换行），便于机器处理。")
+	fs.StringVar(&f.redact, "redact", "", "需脱敏的字段名模式，逗号分隔，不区分大小写，支持 * 通配 (例如 password,*token*,secret)。")
+	fs.StringVar(&f.redactMode, "redact-mode", exporter.RedactModeMask, "脱敏字段的处理方式 (mask, omit)，mask 保留字段并将说明替换为 [redacted]，omit 删除字段。")
+	fs.BoolVar(&f.prettyNames, "pretty-names", false, "为Swagger的schema生成便于阅读的 title、为标签生成 x-displayName (例如 UserInfo -> User Info)。")
+	fs.BoolVar(&f.dedupSchemas, "dedup-schemas", false, "Swagger中结构相同的匿名结构体复用同一个组件，减少重复的schema定义。")
+	fs.StringVar(&f.groupBy, "group-by", "", "路由的分组方式 (package, path-prefix)，统一用于Swagger标签、YAPI分类和Apifox目录，为空时使用各格式的默认分组 (Swagger按路径前缀，YAPI/Apifox按包)。")
+	fs.IntVar(&f.sharedParams, "shared-params", 0, "Swagger中同名且结构相同的参数在至少 N 个操作中出现时提取到 components.parameters 并以 $ref 引用 (例如 2)，0 表示不提取。")
+	fs.StringVar(&f.tags, "tag", "", "Swagger只导出指定标签的路由，逗号分隔，不区分大小写 (例如 Order,Member)，标签按路径计算。")
+	fs.StringVar(&f.trimPrefix, "trim-package-prefix", "", "推导YAPI分类、Apifox目录及按包拆分的分组名称前去掉的包路径前缀，auto 表示使用项目 go.mod 中的模块路径 (例如 github.com/org/service/internal)。")
+}
+
+// validate 检查参数取值，不合法时退出
+func (f *envelopeFlags) validate() {
+	if !exporter.IsValidFieldCase(f.fieldCase) {
+		fmt.Fprintf(os.Stderr, "不支持的字段命名风格: %s (可选: none, camel, snake, pascal)\n", f.fieldCase)
+		os.Exit(2)
+	}
+	if !exporter.IsValidLocale(f.locale) {
+		fmt.Fprintf(os.Stderr, "不支持的响应描述语言: %s (可选: en, zh)\n", f.locale)
+		os.Exit(2)
+	}
+	if !exporter.IsValidSplitBy(f.splitBy) {
+		fmt.Fprintf(os.Stderr, "不支持的拆分方式: %s (可选: tag, package, server)\n", f.splitBy)
+		os.Exit(2)
+	}
+	if !exporter.IsValidRedactMode(f.redactMode) {
+		fmt.Fprintf(os.Stderr, "不支持的脱敏方式: %s (可选: mask, omit)\n", f.redactMode)
+		os.Exit(2)
+	}
+	if !exporter.IsValidGroupBy(f.groupBy) {
+		fmt.Fprintf(os.Stderr, "不支持的分组方式: %s (可选: package, path-prefix)\n", f.groupBy)
+		os.Exit(2)
+	}
+}
+
+// resolveTrimPrefix -trim-package-prefix 为 auto 时替换为项目的模块路径，读取失败时退出
+func (f *envelopeFlags) resolveTrimPrefix(projectPath string) {
+	if !strings.EqualFold(f.trimPrefix, "auto") {
+		return
+	}
+	modulePath, err := parser.ModulePath(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "无法检测模块路径: %v\n", err)
+		os.Exit(2)
+	}
+	log.Println("包路径前缀使用模块路径:", modulePath)
+	f.trimPrefix = modulePath
+}
+
+func (f *envelopeFlags) options() exporter.Options {
+	return exporter.Options{
+		EnvelopeField: f.envelopeField,
+		KeepEnvelope:  f.keepEnvelope,
+		FieldCase:     f.fieldCase,
+		ModelPackages: splitList(f.modelPackages),
+		StrictSchemas: f.strictSchemas,
+		Locale:        f.locale,
+		SplitBy:       f.splitBy,
+		Compact:       f.compact,
+		RedactFields:  splitList(f.redact),
+		RedactMode:    f.redactMode,
+		PrettyNames:   f.prettyNames,
+		DedupSchemas:  f.dedupSchemas,
+		Tags:          splitList(f.tags),
+
+		TrimPackagePrefix: f.trimPrefix,
+		SharedParameters:  f.sharedParams,
+		GroupBy:           f.groupBy,
+	}
+}
+
+// runAnalyze analyze 子命令：分析项目并输出JSON或Swagger
+func runAnalyze(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	var af analysisFlags
+	var ef envelopeFlags
+	af.register(fs)
+	ef.register(fs)
+	outputFormat := fs.String("format", "json", "输出格式 (json, swagger)。")
+	outputFile := fs.String("output", "", "输出文件路径 (可选)。")
+	outputRoot := fs.String("output-dir", "", "统一输出目录，各格式写入其中的子目录 (如 <dir>/swagger、<dir>/json)，-output 优先。")
+	pageSize := fs.Int("page-size", 0, "JSON输出每个文件的路由数量，路由较多时拆分为 <文件名>_1.json、<文件名>_2.json……，需配合 -output 或 -output-dir，0 表示不拆分。")
+	af.parseArgs(fs, args)
+	ef.validate()
+	ef.resolveTrimPrefix(af.projectPath)
+
+	apiInfo, err := analyzeProject(&af)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	log.Printf("4. 生成 %s 格式输出...", *outputFormat)
+
+	switch *outputFormat {
+	case "swagger":
+		// Swagger格式导出
+		outputDir := ""
+		if *outputFile != "" {
+			outputDir = filepath.Dir(*outputFile)
+		}
+		outputDir = exporter.ResolveOutputDir(outputDir, *outputRoot, exporter.OutputFormatSwagger)
+		start := time.Now()
+		if err := exportToSwagger(apiInfo, af.projectName, outputDir, af.basePath, ef.options()); err != nil {
+			log.Fatalf("Swagger导出失败: %v", err)
+		}
+		af.recordTiming("export:swagger", start, len(apiInfo.Routes))
+	default:
+		// 默认JSON格式输出
+		apiInfo = af.applyBasePath(apiInfo)
+		output, err := exporter.MarshalOutput(apiInfo, ef.compact)
+		if err != nil {
+			log.Fatalf("JSON序列化失败: %v", err)
+		}
+
+		// 指定了统一输出目录时保存到其中的 json 子目录
+		if *outputFile == "" && *outputRoot != "" {
+			jsonDir := exporter.ResolveOutputDir("", *outputRoot, exporter.OutputFormatJSON)
+			if err := os.MkdirAll(jsonDir, 0755); err != nil {
+				log.Fatalf("创建输出目录失败: %v", err)
+			}
+			*outputFile = filepath.Join(jsonDir, af.projectName+".json")
+		}
+
+		if *pageSize > 0 && len(apiInfo.Routes) > *pageSize {
+			// 按页拆分为多个文件
+			if *outputFile == "" {
+				log.Fatalf("-page-size 需配合 -output 或 -output-dir 使用")
+			}
+			pages := paginateRoutes(apiInfo, *pageSize)
+			for _, page := range pages {
+				pageOutput, err := exporter.MarshalOutput(page, ef.compact)
+				if err != nil {
+					log.Fatalf("JSON序列化失败: %v", err)
+				}
+				pageFile := pageFilename(*outputFile, page.Page.Page)
+				if err := os.WriteFile(pageFile, pageOutput, 0644); err != nil {
+					log.Fatalf("保存文件失败: %v", err)
+				}
+				log.Printf("✅ JSON输出第 %d/%d 页已保存到: %s", page.Page.Page, page.Page.TotalPages, pageFile)
+			}
+		} else if *outputFile != "" {
+			// 保存到文件
+			if err := os.WriteFile(*outputFile, output, 0644); err != nil {
+				log.Fatalf("保存文件失败: %v", err)
+			}
+			log.Printf("✅ JSON输出已保存到: %s", *outputFile)
+		} else {
+			// 输出到控制台
+			printRoutesToTerminal(apiInfo, ef.compact)
+		}
+	}
+
+	log.Println("\n分析完成。")
+	af.reportTimings()
+
+	if af.failOnUnknown {
+		failOnUnresolved(apiInfo)
+	}
+}
+
+// runExport export 子命令：分析项目并导出为文档文件
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var af analysisFlags
+	var ef envelopeFlags
+	af.register(fs)
+	ef.register(fs)
+	format := fs.String("format", "swagger", "导出格式 (swagger, yapi, apifox)。")
+	outputDir := fs.String("output", "", "输出目录 (可选)。")
+	outputRoot := fs.String("output-dir", "", "统一输出目录，各格式写入其中的子目录 (如 <dir>/swagger、<dir>/yapi、<dir>/apifox)，-output 优先。")
+	yapiProject := exporter.DefaultYAPIProjectOptions()
+	fs.IntVar(&yapiProject.ProjectID, "yapi-project-id", yapiProject.ProjectID, "YAPI导出的目标项目ID。")
+	fs.IntVar(&yapiProject.CategoryID, "yapi-cat-id", yapiProject.CategoryID, "YAPI导出的起始分类ID，各分类按顺序递增。")
+	fs.IntVar(&yapiProject.UID, "yapi-uid", yapiProject.UID, "YAPI导出的创建者用户ID。")
+	fs.StringVar(&yapiProject.Merge, "yapi-merge", "", "YAPI导入时的数据同步方式 (normal, good, merge)，normal 不导入已存在的接口，good 智能合并，merge 完全覆盖。")
+	af.parseArgs(fs, args)
+	ef.validate()
+	ef.resolveTrimPrefix(af.projectPath)
+	if !exporter.IsValidYAPIMerge(yapiProject.Merge) {
+		fmt.Fprintf(os.Stderr, "不支持的YAPI数据同步方式: %s (可选: normal, good, merge)\n", yapiProject.Merge)
+		os.Exit(2)
+	}
+
+	apiInfo, err := analyzeProject(&af)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	switch *format {
+	case "swagger":
+		*outputDir = exporter.ResolveOutputDir(*outputDir, *outputRoot, exporter.OutputFormatSwagger)
+		start := time.Now()
+		if err := exportToSwagger(apiInfo, af.projectName, *outputDir, af.basePath, ef.options()); err != nil {
+			log.Fatalf("Swagger导出失败: %v", err)
+		}
+		af.recordTiming("export:swagger", start, len(apiInfo.Routes))
+	case "yapi":
+		if ef.splitBy != "" || ef.tags != "" {
+			fmt.Fprintln(os.Stderr, "-split-by 和 -tag 仅支持 swagger 格式")
+			os.Exit(2)
+		}
+		*outputDir = exporter.ResolveOutputDir(*outputDir, *outputRoot, exporter.OutputFormatYAPI)
+		// YAPI 的 basepath 为项目级前缀，路径本身不再添加前缀
+		yapiExporter := exporter.NewYAPIExporter(af.projectName, joinBasePathOrEmpty(af.basePath), *outputDir, yapiProject)
+		yapiExporter.SetOptions(ef.options())
+		start := time.Now()
+		if err := yapiExporter.Export(apiInfo); err != nil {
+			log.Fatalf("YAPI导出失败: %v", err)
+		}
+		af.recordTiming("export:yapi", start, len(apiInfo.Routes))
+	case "apifox":
+		if ef.splitBy != "" || ef.tags != "" {
+			fmt.Fprintln(os.Stderr, "-split-by 和 -tag 仅支持 swagger 格式")
+			os.Exit(2)
+		}
+		*outputDir = exporter.ResolveOutputDir(*outputDir, *outputRoot, exporter.OutputFormatApifox)
+		apifoxExporter := exporter.NewApifoxExporter(af.projectName, "http://localhost:8080", *outputDir)
+		apifoxExporter.SetOptions(ef.options())
+		start := time.Now()
+		if err := apifoxExporter.Export(af.applyBasePath(apiInfo)); err != nil {
+			log.Fatalf("Apifox导出失败: %v", err)
+		}
+		af.recordTiming("export:apifox", start, len(apiInfo.Routes))
+	default:
+		fmt.Fprintf(os.Stderr, "不支持的导出格式: %s\n", *format)
+		os.Exit(2)
+	}
+	af.reportTimings()
+
+	if af.failOnUnknown {
+		failOnUnresolved(apiInfo)
+	}
+}
+
+// parserOptions 项目加载配置：构建标签和目标平台
+func (f *analysisFlags) parserOptions() parser.Options {
+	options := parser.Options{IncludeTests: f.includeTests}
+	if tags := splitList(f.buildTags); len(tags) > 0 {
+		options.BuildFlags = append(options.BuildFlags, "-tags="+strings.Join(tags, ","))
+	}
+	if f.goos != "" {
+		options.Env = append(options.Env, "GOOS="+f.goos)
+	}
+	if f.goarch != "" {
+		options.Env = append(options.Env, "GOARCH="+f.goarch)
+	}
+	return options
+}
+
+// analyzeProject 解析并分析项目，应用路径过滤器
+func analyzeProject(af *analysisFlags) (*models.APIInfo, error) {
+	log.Printf("项目路径: %s", af.projectPath)
+
+	log.Println("1. 解析项目代码...")
+	start := time.Now()
+	proj, err := parser.ParseProjectWithOptions(af.projectPath, af.parserOptions())
+	if err != nil {
+		return nil, fmt.Errorf("项目解析失败: %v", err)
+	}
+	af.recordTiming("parse", start, len(proj.Packages))
+
+	ext, err := selectExtractor(af.framework, proj)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("3. 运行核心分析器...")
+	coreAnalyzer := analyzer.NewAnalyzer(af.projectPath, proj, ext)
+	coreAnalyzer.SetOptions(analyzer.Options{
+		ExportedOnly: af.exportedOnly,
+		TypeMappings: af.typeMappings,
+		Annotations:  af.annotations,
+
+		SchemaOverrides: af.overrides,
+		SourceLocation:  af.sourceLoc,
+		QueryTags:       splitList(af.queryTags),
+		MaxRoutes:       af.maxRoutes,
+		Responders:      af.responderSet,
+		GitSince:        af.gitSince,
+	})
+	apiInfo, err := coreAnalyzer.Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("核心分析失败: %v", err)
+	}
+	af.timings = append(af.timings, coreAnalyzer.Timings()...)
+	if len(apiInfo.Warnings) > 0 {
+		console.Fprintf(os.Stderr, "⚠️  分析过程中有 %d 个警告:\n", len(apiInfo.Warnings))
+		for _, warning := range apiInfo.Warnings {
+			fmt.Fprintf(os.Stderr, "  %s\n", warning)
+		}
+	}
+
+	// 如果指定了路径过滤器，过滤路由
+	if af.pathFilter != "" {
+		apiInfo = filterRoutesByPath(apiInfo, af.pathFilter)
+		log.Printf("路径过滤器 '%s' 应用后，剩余路由数: %d", af.pathFilter, len(apiInfo.Routes))
+	}
+
+	return apiInfo, nil
+}
+
+// selectExtractor 创建框架提取器，framework 为空或 auto 时根据项目的导入自动检测
+func selectExtractor(framework string, proj *parser.Project) (extractor.Extractor, error) {
+	if framework == "" || strings.EqualFold(framework, extractor.FrameworkAuto) {
+		detected, err := extractor.DetectFramework(proj)
+		if err != nil {
+			return nil, fmt.Errorf("框架自动检测失败: %v", err)
+		}
+		log.Println("自动检测到框架:", detected)
+		framework = detected
+	}
+	log.Println("2. 选择框架提取器:", framework)
+	return extractor.CreateExtractor(framework, proj)
+}
+
+// applyBasePath 如果指定了路由前缀，添加到所有路由路径之前
+func (f *analysisFlags) applyBasePath(apiInfo *models.APIInfo) *models.APIInfo {
+	if f.basePath == "" {
+		return apiInfo
+	}
+	log.Printf("已为所有路由添加前缀: %s", f.basePath)
+	return applyBasePath(apiInfo, f.basePath)
+}
+
+// exportToSwagger 导出为Swagger格式，路由前缀设置在 servers 的地址上，路径本身不加前缀
+func exportToSwagger(apiInfo *models.APIInfo, projectName, outputDir, basePath string, options exporter.Options) error {
+	// 创建Swagger导出器
+	swaggerExporter := exporter.NewSwaggerExporter(projectName, "1.0.0", swaggerServerURL("http://localhost:8080", basePath), outputDir, true)
+	swaggerExporter.SetOptions(options)
+
+	// 执行导出
+	return swaggerExporter.Export(apiInfo)
+}
+
+// filterRoutesByPath 根据路径过滤器过滤路由
+func filterRoutesByPath(apiInfo *models.APIInfo, pathFilter string) *models.APIInfo {
+	var filteredRoutes []models.RouteInfo
+
+	for _, route := range apiInfo.Routes {
+		if strings.Contains(route.Path, pathFilter) {
+			filteredRoutes = append(filteredRoutes, route)
+		}
+	}
+
+	return &models.APIInfo{
+		Routes:   filteredRoutes,
+		Warnings: apiInfo.Warnings,
+	}
+}
+
+// applyBasePath 为所有路由路径添加前缀
+func applyBasePath(apiInfo *models.APIInfo, basePath string) *models.APIInfo {
+	routes := make([]models.RouteInfo, 0, len(apiInfo.Routes))
+	for _, route := range apiInfo.Routes {
+		route.Path = joinBasePath(basePath, route.Path)
+		routes = append(routes, route)
+	}
+
+	return &models.APIInfo{
+		Routes:   routes,
+		Warnings: apiInfo.Warnings,
+	}
+}
+
+// joinBasePath 拼接前缀与路径，合并重复的斜杠，保留路径参数（如 :id）
+func joinBasePath(basePath, path string) string {
+	joined := "/" + strings.Trim(basePath, "/") + "/" + strings.TrimPrefix(path, "/")
+	for strings.Contains(joined, "//") {
+		joined = strings.ReplaceAll(joined, "//", "/")
+	}
+	if joined != "/" && !strings.HasSuffix(path, "/") {
+		joined = strings.TrimSuffix(joined, "/")
+	}
+	return joined
+}
+
+// swaggerServerURL Swagger servers 中的服务地址：主机地址加上规范化的路由前缀（与YAPI的 basepath 一致）
+func swaggerServerURL(host, basePath string) string {
+	return strings.TrimSuffix(host, "/") + joinBasePathOrEmpty(basePath)
+}
+
+// joinBasePathOrEmpty 规范化路由前缀，未指定时返回空字符串
+func joinBasePathOrEmpty(basePath string) string {
+	if basePath == "" {
+		return ""
+	}
+	return joinBasePath(basePath, "")
+}
+
+// splitList 拆分逗号分隔的参数值，忽略空项
+func splitList(value string) []string {
+	return splitListBy(value, ",")
+}
+
+// splitListBy 按分隔符拆分参数值，去掉空白和空项
+func splitListBy(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// paginateRoutes 按每页 pageSize 个路由拆分分析结果，警告只保留在第一页
+func paginateRoutes(apiInfo *models.APIInfo, pageSize int) []*models.APIInfo {
+	total := len(apiInfo.Routes)
+	totalPages := (total + pageSize - 1) / pageSize
+	pages := make([]*models.APIInfo, 0, totalPages)
+	for i := 0; i < totalPages; i++ {
+		end := (i + 1) * pageSize
+		if end > total {
+			end = total
+		}
+		page := &models.APIInfo{
+			Routes: apiInfo.Routes[i*pageSize : end],
+			Page:   &models.PageInfo{Page: i + 1, TotalPages: totalPages, TotalRoutes: total},
+		}
+		if i == 0 {
+			page.Warnings = apiInfo.Warnings
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// pageFilename 分页文件名：在扩展名前追加页码，如 api.json -> api_2.json
+func pageFilename(path string, page int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(path, ext), page, ext)
+}
+
+// printRoutesToTerminal 以JSON格式打印路由到终端
+func printRoutesToTerminal(apiInfo *models.APIInfo, compact bool) {
+	output, err := exporter.MarshalOutput(apiInfo, compact)
+	if err != nil {
+		log.Fatalf("JSON序列化失败: %v", err)
+	}
+
+	os.Stdout.Write(output)
+}
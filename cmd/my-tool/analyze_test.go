@@ -0,0 +1,169 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/analyzer"
+	"github.com/YogeLiu/api-tool/pkg/exporter"
+	"github.com/YogeLiu/api-tool/pkg/extractor"
+	"github.com/YogeLiu/api-tool/pkg/models"
+	"github.com/YogeLiu/api-tool/pkg/parser"
+)
+
+func TestApplyBasePath(t *testing.T) {
+	apiInfo := &models.APIInfo{Routes: []models.RouteInfo{{Method: "GET", Path: "/users/:id"}}}
+
+	for _, basePath := range []string{"/v1", "v1", "/v1/", "//v1"} {
+		got := applyBasePath(apiInfo, basePath).Routes[0].Path
+		if got != "/v1/users/:id" {
+			t.Errorf("前缀 %q 应得到 /v1/users/:id，实际为 %s", basePath, got)
+		}
+	}
+	if apiInfo.Routes[0].Path != "/users/:id" {
+		t.Errorf("不应修改原始路由，实际为 %s", apiInfo.Routes[0].Path)
+	}
+}
+
+func TestSwaggerBasePathOnServers(t *testing.T) {
+	apiInfo := &models.APIInfo{Routes: []models.RouteInfo{{Method: "GET", Path: "/users/:id", Handler: "GetUser"}}}
+
+	swaggerExporter := exporter.NewSwaggerExporter("demo", "1.0.0", swaggerServerURL("http://localhost:8080", "/v1/"), "", true)
+	doc := swaggerExporter.Generate(apiInfo)
+
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "http://localhost:8080/v1" {
+		t.Fatalf("路由前缀应设置在 servers 地址上，实际为 %+v", doc.Servers)
+	}
+	if _, ok := doc.Paths["/users/:id"]; !ok {
+		t.Errorf("路径不应再添加前缀，实际路径: %v", doc.Paths)
+	}
+	if got := swaggerServerURL("http://localhost:8080", ""); got != "http://localhost:8080" {
+		t.Errorf("未指定前缀时应为主机地址，实际为 %s", got)
+	}
+}
+
+// loadProject 加载测试项目（使用 go.mod 而非 vendor 目录解析依赖）
+func loadProject(t *testing.T, path string) *parser.Project {
+	t.Helper()
+	proj, err := parser.ParseProjectWithOptions(path, parser.Options{Env: []string{"GOFLAGS=-mod=mod"}})
+	if err != nil {
+		t.Fatalf("加载项目 %s 失败: %v", path, err)
+	}
+	return proj
+}
+
+func TestAutoDetectFramework(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	example := loadProject(t, "../../example")
+	for _, framework := range []string{"", extractor.FrameworkAuto, "AUTO"} {
+		ext, err := selectExtractor(framework, example)
+		if err != nil {
+			t.Fatalf("framework=%q 时应自动检测到 gin: %v", framework, err)
+		}
+		if name := ext.GetFrameworkName(); name != "gin" {
+			t.Errorf("framework=%q 时应选择 gin 提取器，实际为 %s", framework, name)
+		}
+	}
+
+	_, err := selectExtractor(extractor.FrameworkAuto, loadProject(t, "../../pkg/models"))
+	if err == nil || !strings.Contains(err.Error(), "未检测到支持的Web框架") {
+		t.Errorf("没有导入任何框架时应返回明确的错误，实际为 %v", err)
+	}
+}
+
+func TestResolveTrimPrefixAuto(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	// 从项目目录向上查找最近的 go.mod
+	for projectPath, want := range map[string]string{
+		"../../pkg/analyzer/testdata/forkginapp": "example.com/forkginapp",
+		"../../pkg/analyzer/testdata/ginapp":     "github.com/YogeLiu/api-tool",
+	} {
+		f := &envelopeFlags{trimPrefix: "auto"}
+		f.resolveTrimPrefix(projectPath)
+		if f.trimPrefix != want {
+			t.Errorf("%s 的 auto 前缀应替换为模块路径 %s，实际为 %q", projectPath, want, f.trimPrefix)
+		}
+	}
+
+	f := &envelopeFlags{trimPrefix: "example.com/app"}
+	f.resolveTrimPrefix("../..")
+	if f.trimPrefix != "example.com/app" {
+		t.Errorf("显式指定的前缀不应被替换，实际为 %q", f.trimPrefix)
+	}
+}
+
+func TestPaginateRoutes(t *testing.T) {
+	apiInfo := &models.APIInfo{Warnings: []string{"warn"}}
+	for _, path := range []string{"/a", "/b", "/c", "/d", "/e"} {
+		apiInfo.Routes = append(apiInfo.Routes, models.RouteInfo{Method: "GET", Path: path})
+	}
+
+	pages := paginateRoutes(apiInfo, 2)
+	if len(pages) != 3 {
+		t.Fatalf("5 个路由每页 2 个应拆分为 3 页，实际为 %d 页", len(pages))
+	}
+	for i, want := range []int{2, 2, 1} {
+		page := pages[i]
+		if len(page.Routes) != want {
+			t.Errorf("第 %d 页应有 %d 个路由，实际为 %d", i+1, want, len(page.Routes))
+		}
+		if page.Page == nil || page.Page.Page != i+1 || page.Page.TotalPages != 3 || page.Page.TotalRoutes != 5 {
+			t.Errorf("第 %d 页的分页信息不正确: %+v", i+1, page.Page)
+		}
+		if hasWarnings := len(page.Warnings) > 0; hasWarnings != (i == 0) {
+			t.Errorf("警告应只保留在第一页，第 %d 页为 %v", i+1, page.Warnings)
+		}
+	}
+	if pages[2].Routes[0].Path != "/e" {
+		t.Errorf("最后一页应为 /e，实际为 %s", pages[2].Routes[0].Path)
+	}
+
+	if got := pageFilename("out/api.json", 2); got != "out/api_2.json" {
+		t.Errorf("分页文件名应为 out/api_2.json，实际为 %s", got)
+	}
+}
+
+func TestPaginateRoutesStable(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	example := loadProject(t, "../../example")
+	pageRoutes := func() [][]string {
+		ext, err := selectExtractor("gin", example)
+		if err != nil {
+			t.Fatal(err)
+		}
+		apiInfo, err := analyzer.NewAnalyzer("../../example", example, ext).Analyze()
+		if err != nil {
+			t.Fatalf("分析示例项目失败: %v", err)
+		}
+		var pages [][]string
+		for _, page := range paginateRoutes(apiInfo, 3) {
+			var routes []string
+			for _, route := range page.Routes {
+				routes = append(routes, route.Method+" "+route.Path)
+			}
+			pages = append(pages, routes)
+		}
+		return pages
+	}
+
+	first := pageRoutes()
+	if len(first) < 2 {
+		t.Fatalf("示例项目应拆分为多页，实际为 %v", first)
+	}
+	// 路由数量不多时 map 的遍历顺序每次都可能不同，多分析几次
+	for i := 0; i < 5; i++ {
+		if again := pageRoutes(); !reflect.DeepEqual(again, first) {
+			t.Fatalf("多次分析的分页结果应一致，第一次为 %v，之后为 %v", first, again)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// 文件位置: cmd/my-tool/check.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// unresolvedRoute 无法生成文档的路由及原因
+type unresolvedRoute struct {
+	route  models.RouteInfo
+	reason string
+}
+
+// isUnresolvedSchema 顶层结构为 unknown/any 时视为未能解析
+func isUnresolvedSchema(schema *models.APISchema) bool {
+	return schema == nil || schema.Type == "unknown" || schema.Type == "any" || schema.Type == ""
+}
+
+// findUnresolvedRoutes 找出处理函数、响应或请求体未能解析的路由；
+// 没有检测到响应的路由同样无法生成文档，只有 WebSocket 和只重定向的接口本来就没有响应体
+func findUnresolvedRoutes(apiInfo *models.APIInfo) []unresolvedRoute {
+	var unresolved []unresolvedRoute
+	for _, route := range apiInfo.Routes {
+		if route.HandlerUnresolved {
+			unresolved = append(unresolved, unresolvedRoute{route: route, reason: "处理函数未能解析"})
+			continue
+		}
+		switch {
+		case route.ResponseSchema == nil && route.Kind != models.RouteKindWebSocket && route.Kind != models.RouteKindRedirect:
+			unresolved = append(unresolved, unresolvedRoute{route: route, reason: "未检测到响应"})
+		case route.ResponseSchema != nil && isUnresolvedSchema(route.ResponseSchema):
+			unresolved = append(unresolved, unresolvedRoute{route: route, reason: "响应类型为 " + route.ResponseSchema.Type})
+		}
+		for _, param := range route.RequestParams {
+			if param.ParamType == "body" && isUnresolvedSchema(param.ParamSchema) {
+				unresolved = append(unresolved, unresolvedRoute{route: route, reason: "请求体未能解析 (来源: " + param.Source + ")"})
+			}
+		}
+	}
+	return unresolved
+}
+
+// unresolvedWarningMarkers 表示响应无法解析的分析警告（如配置的响应器获取函数不存在，相关接口的响应都无法解析）；
+// 无法解析处理函数的警告已对应 HandlerUnresolved 的路由，路径参数校验等警告不影响文档生成
+var unresolvedWarningMarkers = []string{"未找到响应器获取函数"}
+
+// findUnresolvedWarnings 分析警告中表示响应无法解析的部分
+func findUnresolvedWarnings(apiInfo *models.APIInfo) []string {
+	var warnings []string
+	for _, warning := range apiInfo.Warnings {
+		for _, marker := range unresolvedWarningMarkers {
+			if strings.Contains(warning, marker) {
+				warnings = append(warnings, warning)
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+// failOnUnresolved 存在未能解析的路由或相关警告时打印并以非零状态码退出（用于CI）
+func failOnUnresolved(apiInfo *models.APIInfo) {
+	unresolved := findUnresolvedRoutes(apiInfo)
+	warnings := findUnresolvedWarnings(apiInfo)
+	if len(unresolved) == 0 && len(warnings) == 0 {
+		return
+	}
+
+	if len(unresolved) > 0 {
+		console.Fprintf(os.Stderr, "❌ %d 处响应或请求体未能解析:\n", len(unresolved))
+		for _, item := range unresolved {
+			fmt.Fprintf(os.Stderr, "  %s %s (%s.%s): %s\n",
+				item.route.Method, item.route.Path, item.route.PackageName, item.route.Handler, item.reason)
+		}
+	}
+	if len(warnings) > 0 {
+		console.Fprintf(os.Stderr, "❌ %d 条警告导致响应无法解析:\n", len(warnings))
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "  %s\n", warning)
+		}
+	}
+	os.Exit(1)
+}
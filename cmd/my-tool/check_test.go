@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+func TestFindUnresolvedRoutes(t *testing.T) {
+	apiInfo := &models.APIInfo{Routes: []models.RouteInfo{
+		{Method: "GET", Path: "/ok", ResponseSchema: &models.APISchema{Type: "User"}},
+		{Method: "GET", Path: "/no-response"},
+		{Method: "GET", Path: "/ws", Kind: models.RouteKindWebSocket},
+		{Method: "GET", Path: "/login", Kind: models.RouteKindRedirect},
+		{Method: "GET", Path: "/unknown", ResponseSchema: &models.APISchema{Type: "unknown"}},
+		{Method: "GET", Path: "/any", ResponseSchema: &models.APISchema{Type: "any"}},
+		{Method: "GET", Path: "/dynamic", HandlerUnresolved: true},
+		{Method: "POST", Path: "/body", ResponseSchema: &models.APISchema{Type: "User"}, RequestParams: []models.RequestParamInfo{
+			{ParamName: "request_body", ParamType: "body", Source: "c.ShouldBindJSON"},
+		}},
+	}}
+
+	var got []string
+	for _, item := range findUnresolvedRoutes(apiInfo) {
+		got = append(got, item.route.Path+": "+item.reason)
+	}
+	// 没有检测到响应的路由同样无法生成文档，WebSocket 和只重定向的接口本来就没有响应体
+	want := []string{
+		"/no-response: 未检测到响应",
+		"/unknown: 响应类型为 unknown",
+		"/any: 响应类型为 any",
+		"/dynamic: 处理函数未能解析",
+		"/body: 请求体未能解析 (来源: c.ShouldBindJSON)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("未能解析的路由应为 %v，实际为 %v", want, got)
+	}
+}
+
+func TestFindUnresolvedWarnings(t *testing.T) {
+	apiInfo := &models.APIInfo{Warnings: []string{
+		"GET /users/:id: 路径参数 id 未被处理函数读取",
+		"未找到响应器获取函数 example.com/app/resp.FromCtx",
+	}}
+
+	got := findUnresolvedWarnings(apiInfo)
+	if want := []string{"未找到响应器获取函数 example.com/app/resp.FromCtx"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("应只报告导致响应无法解析的警告 %v，实际为 %v", want, got)
+	}
+}
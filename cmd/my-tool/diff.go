@@ -0,0 +1,73 @@
+// 文件位置: cmd/my-tool/diff.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/exporter"
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// runDiff diff 子命令：比较两个 analyze 生成的 JSON 文件
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	oldFile := fs.String("old", "", "旧版本的API JSON文件路径")
+	newFile := fs.String("new", "", "新版本的API JSON文件路径")
+	outputDir := fs.String("output", "", "输出目录")
+	outputRoot := fs.String("output-dir", "", "统一输出目录，差异报告写入其中的 diff 子目录，-output 优先")
+	format := fs.String("format", "markdown", "输出格式 (markdown, json)")
+	failOnBreaking := fs.Bool("fail-on-breaking", false, "存在破坏性变更时以非零状态码退出（用于CI）")
+	noEmoji := fs.Bool("no-emoji", false, "输出纯文本状态信息，不使用 emoji")
+	compact := fs.Bool("compact", false, "json 格式输出紧凑的JSON（无缩进、无换行）")
+	fs.Parse(args)
+	if *noEmoji {
+		console.SetNoEmoji(true)
+	}
+
+	if *oldFile == "" || *newFile == "" {
+		fmt.Fprintln(os.Stderr, "必须同时指定 -old 和 -new")
+		os.Exit(2)
+	}
+
+	oldInfo, err := loadAPIInfo(*oldFile)
+	if err != nil {
+		log.Fatalf("读取旧版本失败: %v", err)
+	}
+	newInfo, err := loadAPIInfo(*newFile)
+	if err != nil {
+		log.Fatalf("读取新版本失败: %v", err)
+	}
+
+	diffExporter := exporter.NewDiffExporter(exporter.ResolveOutputDir(*outputDir, *outputRoot, exporter.OutputFormatDiff), *format)
+	diffExporter.SetCompact(*compact)
+	diff, err := diffExporter.Export(oldInfo, newInfo)
+	if err != nil {
+		log.Fatalf("差异导出失败: %v", err)
+	}
+
+	if diff.HasBreakingChanges() {
+		console.Println("⚠️  检测到破坏性变更")
+		if *failOnBreaking {
+			os.Exit(1)
+		}
+	}
+}
+
+// loadAPIInfo 读取 analyze 生成的API JSON文件
+func loadAPIInfo(path string) (*models.APIInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiInfo models.APIInfo
+	if err := json.Unmarshal(data, &apiInfo); err != nil {
+		return nil, fmt.Errorf("JSON解析失败: %v", err)
+	}
+	return &apiInfo, nil
+}
@@ -0,0 +1,149 @@
+// 文件位置: cmd/my-tool/serve.go
+package main
+
+import (
+	"context"
+	"embed"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/exporter"
+)
+
+// swaggerUIAssets 内嵌的 Swagger UI 页面及 swagger-ui-dist 5.18.2 中的 swagger-ui-bundle.js、swagger-ui.css，
+// 页面只从本地加载这些资源，不依赖外部CDN或网络（资源来源和许可见 swaggerui/NOTICE）。
+//
+//go:embed swaggerui
+var swaggerUIAssets embed.FS
+
+// specServer 持有最近一次分析生成的 OpenAPI 文档
+type specServer struct {
+	af      analysisFlags
+	ef      envelopeFlags
+	baseURL string
+
+	refreshMu sync.Mutex // 串行化重新分析
+	mu        sync.RWMutex
+	spec      []byte
+}
+
+// runServe serve 子命令：分析项目并在本地提供 Swagger UI
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	s := &specServer{}
+	s.af.register(flags)
+	s.ef.register(flags)
+	port := flags.Int("port", 8090, "Swagger UI 监听端口。")
+	s.af.parseArgs(flags, args)
+	s.ef.validate()
+	s.ef.resolveTrimPrefix(s.af.projectPath)
+	s.baseURL = fmt.Sprintf("http://localhost:%d", *port)
+
+	if err := s.refresh(); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	handler, err := s.handler()
+	if err != nil {
+		log.Fatalf("加载 Swagger UI 资源失败: %v", err)
+	}
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", *port),
+		Handler: handler,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		console.Printf("🚀 Swagger UI: http://localhost:%d/ (Ctrl+C 退出)\n", *port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("HTTP服务启动失败: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	fmt.Println("\n正在关闭服务...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP服务关闭失败: %v", err)
+	}
+}
+
+// refresh 重新分析项目并更新内存中的 OpenAPI 文档
+func (s *specServer) refresh() error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	apiInfo, err := analyzeProject(&s.af)
+	if err != nil {
+		return err
+	}
+
+	swaggerExporter := exporter.NewSwaggerExporter(s.af.projectName, "1.0.0", swaggerServerURL(s.baseURL, s.af.basePath), "", true)
+	swaggerExporter.SetOptions(s.ef.options())
+	start := time.Now()
+	spec, err := exporter.MarshalOutput(swaggerExporter.Generate(apiInfo), s.ef.compact)
+	if err != nil {
+		return fmt.Errorf("JSON序列化失败: %v", err)
+	}
+	s.af.recordTiming("export:swagger", start, len(apiInfo.Routes))
+	s.af.reportTimings()
+
+	s.mu.Lock()
+	s.spec = spec
+	s.mu.Unlock()
+
+	log.Printf("已重新分析项目，共 %d 个接口", len(apiInfo.Routes))
+	return nil
+}
+
+// handler 提供 OpenAPI 文档、重新分析接口及内嵌的 Swagger UI 静态资源
+func (s *specServer) handler() (http.Handler, error) {
+	uiFS, err := fs.Sub(swaggerUIAssets, "swaggerui")
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", s.handleSpec)
+	mux.HandleFunc("/refresh", s.handleRefresh)
+	mux.Handle("/", http.FileServer(http.FS(uiFS)))
+	return mux, nil
+}
+
+// handleSpec 返回最近一次生成的 OpenAPI 文档
+func (s *specServer) handleSpec(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	spec := s.spec
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(spec)
+}
+
+// handleRefresh 重新分析项目后跳转回 Swagger UI 页面。重新分析耗时较长，只接受页面按钮提交的 POST，
+// 避免浏览器预取或爬虫的 GET 请求触发
+func (s *specServer) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "重新分析只接受 POST 请求", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := s.refresh(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSwaggerUIPageLoadsLocalAssets(t *testing.T) {
+	page, err := swaggerUIAssets.ReadFile("swaggerui/index.html")
+	if err != nil {
+		t.Fatalf("读取内嵌的 index.html 失败: %v", err)
+	}
+	html := string(page)
+
+	for _, asset := range []string{`src="./swagger-ui-bundle.js"`, `href="./swagger-ui.css"`} {
+		if !strings.Contains(html, asset) {
+			t.Errorf("index.html 应从本地加载 %s", asset)
+		}
+	}
+	// 不依赖外部CDN
+	if strings.Contains(html, "http://") || strings.Contains(html, "https://") {
+		t.Errorf("index.html 不应引用外部资源")
+	}
+}
+
+func TestHandleSpec(t *testing.T) {
+	s := &specServer{spec: []byte(`{"openapi":"3.0.0"}`)}
+	recorder := httptest.NewRecorder()
+	s.handleSpec(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("Content-Type 应为 application/json，实际为 %q", got)
+	}
+	if got := recorder.Body.String(); got != `{"openapi":"3.0.0"}` {
+		t.Errorf("应返回最近一次生成的文档，实际为 %s", got)
+	}
+}
+
+func TestSwaggerUIServesBundledAssets(t *testing.T) {
+	handler, err := (&specServer{}).handler()
+	if err != nil {
+		t.Fatalf("加载 Swagger UI 资源失败: %v", err)
+	}
+
+	for path, want := range map[string]struct{ contentType, content string }{
+		"/":                     {"text/html", "swagger-ui-bundle.js"},
+		"/swagger-ui-bundle.js": {"javascript", "SwaggerUIBundle"},
+		"/swagger-ui.css":       {"text/css", ".swagger-ui"},
+	} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != http.StatusOK {
+			t.Errorf("%s 应返回 200，实际为 %d", path, recorder.Code)
+			continue
+		}
+		if got := recorder.Header().Get("Content-Type"); !strings.Contains(got, want.contentType) {
+			t.Errorf("%s 的 Content-Type 应包含 %s，实际为 %q", path, want.contentType, got)
+		}
+		if !strings.Contains(recorder.Body.String(), want.content) {
+			t.Errorf("%s 应包含 %s", path, want.content)
+		}
+	}
+}
+
+func TestHandleRefreshRequiresPost(t *testing.T) {
+	// 非 POST 请求在重新分析之前拒绝
+	s := &specServer{}
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPut} {
+		recorder := httptest.NewRecorder()
+		s.handleRefresh(recorder, httptest.NewRequest(method, "/refresh", nil))
+		if recorder.Code != http.StatusMethodNotAllowed || recorder.Header().Get("Allow") != http.MethodPost {
+			t.Errorf("%s /refresh 应返回 405 且 Allow: POST，实际为 %d %q", method, recorder.Code, recorder.Header().Get("Allow"))
+		}
+	}
+
+	page, err := swaggerUIAssets.ReadFile("swaggerui/index.html")
+	if err != nil {
+		t.Fatalf("读取内嵌的 index.html 失败: %v", err)
+	}
+	if !strings.Contains(string(page), `<form method="post" action="refresh">`) {
+		t.Errorf("页面的重新分析按钮应以 POST 提交")
+	}
+}
@@ -4,13 +4,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
 	"log"
+	"mime"
+	"net/http"
 	"os"
+	"path"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/models"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -21,15 +30,67 @@ type APISchema struct {
 	Items       *APISchema            `json:"items,omitempty"`
 	Description string                `json:"description,omitempty"`
 	JSONTag     string                `json:"json_tag,omitempty"`
+	FormTag     string                `json:"form_tag,omitempty"`     // form 标签，表单绑定时的字段名
+	OneOf       []*APISchema          `json:"one_of,omitempty"`       // 多个候选结构（如接口有多个实现）
+	Package     string                `json:"package,omitempty"`      // 命名类型所在的包路径，用于区分不同包中的同名类型
+	Format      string                `json:"format,omitempty"`       // 格式，如 binary（文件/二进制响应）
+	ContentType string                `json:"content_type,omitempty"` // 非JSON响应体的格式，如 image/png
+	Nullable    bool                  `json:"nullable,omitempty"`     // 值可以为 null（指针类型的字段、切片元素、map值）
+	Required    bool                  `json:"required,omitempty"`     // 字段为必填项（binding:"required" 或 validate:"required"）
+	ReadOnly    bool                  `json:"read_only,omitempty"`    // 字段只出现在响应中（readonly:"true"）
+	WriteOnly   bool                  `json:"write_only,omitempty"`   // 字段只出现在请求中（writeonly:"true"）
+	Default     interface{}           `json:"default,omitempty"`      // 字段的默认值（default:"10"），按字段类型解析为对应的JSON类型
+	Example     interface{}           `json:"example,omitempty"`      // 字段的示例值，如响应 map 中枚举常量（StatusActive）的值
+	Map         bool                  `json:"map,omitempty"`          // 键值对类型的 map（含以map为底层类型的命名类型），Properties 中为 <key>/<value>
 }
 
 // 请求参数信息
 type RequestParamInfo struct {
-	ParamType   string     `json:"param_type"`   // "query", "body", "path"
-	ParamName   string     `json:"param_name"`   // 参数名称
-	ParamSchema *APISchema `json:"param_schema"` // 参数结构
-	IsRequired  bool       `json:"is_required"`  // 是否必需
-	Source      string     `json:"source"`       // 来源方法: "c.Query", "c.ShouldBindJSON", etc.
+	ParamType   string     `json:"param_type"`             // "query", "body", "path"
+	ParamName   string     `json:"param_name"`             // 参数名称
+	ParamSchema *APISchema `json:"param_schema"`           // 参数结构
+	IsRequired  bool       `json:"is_required"`            // 是否必需
+	Source      string     `json:"source"`                 // 来源方法: "c.Query", "c.ShouldBindJSON", etc.
+	ContentType string     `json:"content_type,omitempty"` // 请求体格式，如 "application/json"、"multipart/form-data"
+}
+
+// 请求体格式
+const (
+	contentTypeJSON      = "application/json"
+	contentTypeXML       = "application/xml"
+	contentTypeForm      = "application/x-www-form-urlencoded"
+	contentTypeMultipart = "multipart/form-data"
+	contentTypeYAML      = "application/x-yaml"
+	contentTypeTOML      = "application/toml"
+	contentTypeProtoBuf  = "application/x-protobuf"
+	contentTypeMsgPack   = "application/x-msgpack"
+)
+
+// 固定格式的绑定方法对应的请求体格式
+var bindMethodContentTypes = map[string]string{
+	"ShouldBindJSON": contentTypeJSON,
+	"BindJSON":       contentTypeJSON,
+	"ShouldBindXML":  contentTypeXML,
+	"BindXML":        contentTypeXML,
+
+	// c.ShouldBindBodyWithJSON 等缓存请求体的绑定方法，请求体可以被多次绑定
+	"ShouldBindBodyWithJSON": contentTypeJSON,
+	"ShouldBindBodyWithXML":  contentTypeXML,
+	"ShouldBindBodyWithYAML": contentTypeYAML,
+	"ShouldBindBodyWithTOML": contentTypeTOML,
+}
+
+// binding 包中的绑定器对应的请求体格式（如 c.ShouldBindWith(&req, binding.Form)）
+var bindingContentTypes = map[string]string{
+	"JSON":          contentTypeJSON,
+	"XML":           contentTypeXML,
+	"Form":          contentTypeForm,
+	"FormPost":      contentTypeForm,
+	"FormMultipart": contentTypeMultipart,
+	"YAML":          contentTypeYAML,
+	"TOML":          contentTypeTOML,
+	"ProtoBuf":      contentTypeProtoBuf,
+	"MsgPack":       contentTypeMsgPack,
 }
 
 // Handler分析结果 (包含请求和响应)
@@ -39,29 +100,82 @@ type HandlerAnalysisResult struct {
 	HandlerName   string             `json:"handler"`
 	RequestParams []RequestParamInfo `json:"request_params,omitempty"`
 	Response      *APISchema         `json:"response,omitempty"`
+	SuccessStatus int                `json:"success_status,omitempty"` // 成功响应的状态码，无法静态确定时为0
+	Responses     []ResponseCallInfo `json:"responses,omitempty"`      // 处理函数中的所有响应调用（含错误响应），按执行顺序（延迟执行的调用在最后）
+
+	StatusResponses map[string]*APISchema `json:"status_responses,omitempty"` // 按状态码合并的响应结构，状态码无法静态确定时为 "default"
+
+	MiddlewareRendered bool `json:"middleware_rendered,omitempty"` // 处理函数没有直接渲染响应，只通过 c.Set 存储数据，由中间件渲染
+}
+
+// 处理函数中的单个响应调用（c.JSON、响应封装函数等）及其所在分支
+type ResponseCallInfo struct {
+	StatusCode int                   `json:"status_code"`      // 状态码，无法静态确定时为0
+	Schema     *APISchema            `json:"schema,omitempty"` // 响应结构
+	LineNumber int                   `json:"line_number"`      // 调用所在行号
+	Branch     *models.BranchContext `json:"branch,omitempty"` // 调用所在的分支（if/switch/defer/normal）
 }
 
 // 响应封装函数信息
 type ResponseWrapperFunc struct {
-	FuncObj         *types.Func    // 函数对象
-	GinContextIdx   int            // gin.Context 参数索引
-	DataParamIdx    int            // 业务数据参数索引
-	JSONCallSite    *ast.CallExpr  // 内部 c.JSON 调用位置
-	ReturnType      *types.Named   // 返回的结构体类型
-	ParamToFieldMap map[string]int // 参数→字段映射
+	FuncObj         *types.Func       // 函数对象
+	FuncDecl        *ast.FuncDecl     // 函数声明
+	Package         *packages.Package // 函数所在包
+	GinContextIdx   int               // gin.Context 参数索引 (-1表示Context来自接收者字段等其他位置)
+	DataParamIdx    int               // 业务数据参数索引
+	JSONCallSite    *ast.CallExpr     // 内部 c.JSON 调用位置
+	ReturnType      *types.Named      // 返回的结构体类型
+	ParamToFieldMap map[string]int    // 参数→字段映射
 }
 
 // 全局预处理映射 (重新设计的数据结构)
 type GlobalMappings struct {
 	ResponseWrappers map[*types.Func]*ResponseWrapperFunc `json:"-"` // 响应封装函数映射
-	StructTagMap     map[*types.Named]map[string]string   `json:"-"` // 结构体字段的 JSON Tag
+	StructTagMap     map[types.Type]map[string]string     `json:"-"` // 结构体字段的 JSON Tag（命名类型，或别名声明的结构体字面量类型）
+	FieldComments    map[types.Type]map[string]string     `json:"-"` // 结构体字段的注释 (字段名 → 注释)
+	InterfaceImpls   map[*types.Named][]*types.Named      `json:"-"` // 接口类型 → 项目内的实现类型
 }
 
 // 响应解析引擎 (技术规范实现)
 type ResponseParsingEngine struct {
 	allPackages    []*packages.Package
 	globalMappings *GlobalMappings
-	maxDepth       int // 递归深度限制
+	maxDepth       int                         // 递归深度限制
+	paramBindings  map[types.Object]types.Type // 当前展开的函数调用中，形参 → 实参类型
+	typeMappings   map[string]TypeMapping      // 自定义类型映射：包路径.类型名 → schema类型
+	overrides      map[string]*APISchema       // 手写的类型schema：包路径.类型名 → 完整结构（如自定义 MarshalJSON 的类型）
+	queryTags      []string                    // 查询参数结构体字段命名使用的标签，按优先级排列
+	responders     []resolvedResponder         // 配置的上下文响应器：获取函数的返回类型 → 响应方法
+	literalDepth   int                         // 当前展开的嵌套 map 字面量层数（如 gin.H{"a": gin.H{...}}），不超过 maxDepth
+	beego          bool                        // 分析Beego控制器：识别 this.Data["json"] = v 形式的响应
+}
+
+// Responder 存放在请求上下文中的响应器，如 resp := FromCtx(c); resp.OK(data)：
+// Accessor 为获取响应器的函数（包路径.函数名），Methods 为响应器上的响应方法，调用按响应封装函数处理
+type Responder struct {
+	Accessor string
+	Methods  map[string]ResponderMethod
+}
+
+// ResponderMethod 响应器的响应方法
+type ResponderMethod struct {
+	DataIdx int // 业务数据参数索引
+	Status  int // 响应状态码，0 表示成功响应
+}
+
+// 获取函数已解析的响应器：响应器的类型（获取函数的第一个返回值）及其响应方法
+type resolvedResponder struct {
+	typ     types.Type
+	methods map[string]ResponderMethod
+}
+
+// DefaultQueryTag gin 按 form 标签绑定查询参数
+const DefaultQueryTag = "form"
+
+// TypeMapping 自定义标量类型对应的schema类型和格式，如 type Email string -> string/email
+type TypeMapping struct {
+	Type   string // schema类型 (string, integer, number, boolean, object)
+	Format string // 格式，如 email、int64、date-time，可为空
 }
 
 // 请求参数解析器
@@ -94,7 +208,9 @@ func NewResponseParsingEngine(packages []*packages.Package) *ResponseParsingEngi
 		maxDepth:    10, // 增加递归深度限制，支持更深层嵌套
 		globalMappings: &GlobalMappings{
 			ResponseWrappers: make(map[*types.Func]*ResponseWrapperFunc),
-			StructTagMap:     make(map[*types.Named]map[string]string),
+			StructTagMap:     make(map[types.Type]map[string]string),
+			FieldComments:    make(map[types.Type]map[string]string),
+			InterfaceImpls:   make(map[*types.Named][]*types.Named),
 		},
 	}
 
@@ -103,6 +219,209 @@ func NewResponseParsingEngine(packages []*packages.Package) *ResponseParsingEngi
 	return engine
 }
 
+// SetTypeMappings 设置自定义类型映射，键为完整类型名（包路径.类型名）
+func (engine *ResponseParsingEngine) SetTypeMappings(mappings map[string]TypeMapping) {
+	engine.typeMappings = mappings
+}
+
+// SetBeego 设置是否按Beego控制器分析处理函数，开启时 this.Data["json"] = v 赋值视为响应，
+// 仅在使用Beego提取器时开启，避免其他框架中名为 Data 的 map 字段被误识别
+func (engine *ResponseParsingEngine) SetBeego(enabled bool) {
+	engine.beego = enabled
+}
+
+// SetQueryTags 设置查询参数结构体（c.ShouldBindQuery）字段命名使用的标签，按优先级依次查找，
+// 如 query,form 表示优先使用第三方绑定库的 query 标签，没有时使用 form 标签；为空时使用 form
+func (engine *ResponseParsingEngine) SetQueryTags(tags []string) {
+	engine.queryTags = tags
+}
+
+// 查询参数结构体字段命名使用的标签
+func (engine *ResponseParsingEngine) queryTagKeys() []string {
+	if len(engine.queryTags) == 0 {
+		return []string{DefaultQueryTag}
+	}
+	return engine.queryTags
+}
+
+// SetSchemaOverrides 设置手写的类型schema，键为完整类型名（包路径.类型名），解析到这些类型时直接使用对应的结构
+func (engine *ResponseParsingEngine) SetSchemaOverrides(overrides map[string]*APISchema) {
+	engine.overrides = overrides
+}
+
+// LoadSchemaOverrides 从JSON文件加载手写的类型schema，文件内容为 {"包路径.类型名": APISchema, ...}，
+// 用于实现了 json.Marshaler 等无法从类型定义推断输出结构的类型
+func LoadSchemaOverrides(filename string) (map[string]*APISchema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取schema覆盖文件失败: %v", err)
+	}
+	var overrides map[string]*APISchema
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("解析schema覆盖文件 %s 失败: %v", filename, err)
+	}
+	for name, schema := range overrides {
+		if !strings.Contains(name, ".") {
+			return nil, fmt.Errorf("schema覆盖的类型名格式错误: %s (应为 包路径.类型名)", name)
+		}
+		if schema == nil || schema.Type == "" {
+			return nil, fmt.Errorf("schema覆盖 %s 缺少 type", name)
+		}
+	}
+	return overrides, nil
+}
+
+// cloneSchema 深拷贝结构，调用方修改返回值（如设置 JSONTag）时不影响原结构
+func cloneSchema(schema *APISchema) *APISchema {
+	if schema == nil {
+		return nil
+	}
+	copied := *schema
+	if schema.Properties != nil {
+		copied.Properties = make(map[string]*APISchema, len(schema.Properties))
+		for key, prop := range schema.Properties {
+			copied.Properties[key] = cloneSchema(prop)
+		}
+	}
+	copied.Items = cloneSchema(schema.Items)
+	if schema.OneOf != nil {
+		copied.OneOf = make([]*APISchema, len(schema.OneOf))
+		for i, candidate := range schema.OneOf {
+			copied.OneOf[i] = cloneSchema(candidate)
+		}
+	}
+	return &copied
+}
+
+// ParseTypeMappings 解析 包路径.类型名=类型[/格式] 形式的类型映射，如 example.com/app/types.Email=string/email
+func ParseTypeMappings(specs []string) (map[string]TypeMapping, error) {
+	mappings := make(map[string]TypeMapping)
+	for _, spec := range specs {
+		name, target, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || !strings.Contains(name, ".") {
+			return nil, fmt.Errorf("类型映射格式错误: %s (应为 包路径.类型名=类型[/格式])", spec)
+		}
+
+		schemaType, format, _ := strings.Cut(strings.TrimSpace(target), "/")
+		switch schemaType {
+		case "string", "integer", "number", "boolean", "object":
+		default:
+			return nil, fmt.Errorf("类型映射 %s 的类型不受支持: %s (可选: string, integer, number, boolean, object)", name, schemaType)
+		}
+		mappings[name] = TypeMapping{Type: schemaType, Format: format}
+	}
+	return mappings, nil
+}
+
+// ParseResponders 解析 包路径.函数名:方法=数据参数索引[/状态码][,方法=...] 形式的响应器配置，
+// 如 example.com/app/resp.FromCtx:OK=0,Fail=1/400
+func ParseResponders(specs []string) ([]Responder, error) {
+	var responders []Responder
+	for _, spec := range specs {
+		accessor, methodSpecs, ok := strings.Cut(spec, ":")
+		accessor = strings.TrimSpace(accessor)
+		if !ok || accessor == "" || !strings.Contains(accessor, ".") {
+			return nil, fmt.Errorf("响应器配置格式错误: %s (应为 包路径.函数名:方法=数据参数索引[/状态码],...)", spec)
+		}
+
+		responder := Responder{Accessor: accessor, Methods: make(map[string]ResponderMethod)}
+		for _, methodSpec := range strings.Split(methodSpecs, ",") {
+			name, target, ok := strings.Cut(strings.TrimSpace(methodSpec), "=")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("响应器 %s 的方法配置格式错误: %s (应为 方法=数据参数索引[/状态码])", accessor, methodSpec)
+			}
+			idxText, statusText, hasStatus := strings.Cut(target, "/")
+			method := ResponderMethod{}
+			var err error
+			if method.DataIdx, err = strconv.Atoi(idxText); err != nil || method.DataIdx < 0 {
+				return nil, fmt.Errorf("响应器 %s 的方法 %s 数据参数索引无效: %s", accessor, name, idxText)
+			}
+			if hasStatus {
+				if method.Status, err = strconv.Atoi(statusText); err != nil || method.Status < 100 || method.Status > 599 {
+					return nil, fmt.Errorf("响应器 %s 的方法 %s 状态码无效: %s", accessor, name, statusText)
+				}
+			}
+			responder.Methods[name] = method
+		}
+		responders = append(responders, responder)
+	}
+	return responders, nil
+}
+
+// SetResponders 设置上下文响应器，按获取函数的返回类型识别响应器上的方法调用；返回未找到的获取函数
+func (engine *ResponseParsingEngine) SetResponders(responders []Responder) []string {
+	engine.responders = nil
+	var missing []string
+	for _, responder := range responders {
+		dot := strings.LastIndex(responder.Accessor, ".")
+		accessor := engine.lookupPackageFunc(responder.Accessor[:dot], responder.Accessor[dot+1:])
+		if accessor == nil || accessor.Type().(*types.Signature).Results().Len() == 0 {
+			missing = append(missing, responder.Accessor)
+			continue
+		}
+		responderType := accessor.Type().(*types.Signature).Results().At(0).Type()
+		engine.responders = append(engine.responders, resolvedResponder{typ: responderType, methods: responder.Methods})
+		log.Printf("[DEBUG] 响应器获取函数 %s 返回 %s\n", responder.Accessor, responderType)
+	}
+	return missing
+}
+
+// 在已加载的包及其依赖中查找包级函数
+func (engine *ResponseParsingEngine) lookupPackageFunc(pkgPath, name string) *types.Func {
+	visited := make(map[*packages.Package]bool)
+	var lookup func(pkg *packages.Package) *types.Func
+	lookup = func(pkg *packages.Package) *types.Func {
+		if pkg == nil || visited[pkg] {
+			return nil
+		}
+		visited[pkg] = true
+		if pkg.PkgPath == pkgPath && pkg.Types != nil {
+			fn, _ := pkg.Types.Scope().Lookup(name).(*types.Func)
+			return fn
+		}
+		for _, imported := range pkg.Imports {
+			if fn := lookup(imported); fn != nil {
+				return fn
+			}
+		}
+		return nil
+	}
+	for _, pkg := range engine.allPackages {
+		if fn := lookup(pkg); fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+// 配置的响应器方法调用（如 resp.OK(data)、FromCtx(c).Fail(err)）：按响应封装函数展开，
+// 数据参数注入默认响应结构的 data 字段；返回响应结构和配置的状态码
+func (engine *ResponseParsingEngine) responderCall(callExpr *ast.CallExpr, pkg *packages.Package) (*APISchema, int, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(engine.responders) == 0 {
+		return nil, 0, false
+	}
+	recvType := pkg.TypesInfo.TypeOf(selExpr.X)
+	if recvType == nil {
+		return nil, 0, false
+	}
+	for _, responder := range engine.responders {
+		method, ok := responder.methods[selExpr.Sel.Name]
+		if !ok || !types.Identical(recvType, responder.typ) {
+			continue
+		}
+		methodObj, _ := pkg.TypesInfo.Uses[selExpr.Sel].(*types.Func)
+		if methodObj == nil {
+			continue
+		}
+		log.Printf("[DEBUG] 找到响应器方法调用: %s\n", types.ExprString(callExpr.Fun))
+		wrapper := &ResponseWrapperFunc{FuncObj: methodObj, GinContextIdx: -1, DataParamIdx: method.DataIdx}
+		return engine.analyzeWrapperFunctionArgs(wrapper, callExpr.Args, pkg), method.Status, true
+	}
+	return nil, 0, false
+}
+
 // 全局预处理阶段 (技术规范步骤1)
 func (engine *ResponseParsingEngine) performGlobalPreprocessing() {
 	log.Printf("[DEBUG] 开始全局预处理阶段...\n")
@@ -112,9 +431,62 @@ func (engine *ResponseParsingEngine) performGlobalPreprocessing() {
 		engine.preprocessPackage(pkg)
 	}
 
-	log.Printf("[DEBUG] 全局预处理完成: 发现 %d 个响应封装函数, %d 个结构体\n",
+	// 构建接口→实现类型索引 (需要所有包的类型信息)
+	engine.buildInterfaceImplIndex()
+
+	log.Printf("[DEBUG] 全局预处理完成: 发现 %d 个响应封装函数, %d 个结构体, %d 个有实现的接口\n",
 		len(engine.globalMappings.ResponseWrappers),
-		len(engine.globalMappings.StructTagMap))
+		len(engine.globalMappings.StructTagMap),
+		len(engine.globalMappings.InterfaceImpls))
+}
+
+// 构建接口→实现类型索引
+func (engine *ResponseParsingEngine) buildInterfaceImplIndex() {
+	var interfaces []*types.Named
+	var concretes []*types.Named
+
+	for _, pkg := range engine.allPackages {
+		if pkg.Types == nil {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+			named, ok := typeName.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			if iface, ok := named.Underlying().(*types.Interface); ok {
+				if !iface.Empty() {
+					interfaces = append(interfaces, named)
+				}
+			} else {
+				concretes = append(concretes, named)
+			}
+		}
+	}
+
+	for _, ifaceNamed := range interfaces {
+		iface := ifaceNamed.Underlying().(*types.Interface)
+		var impls []*types.Named
+		for _, concrete := range concretes {
+			if types.Implements(concrete, iface) || types.Implements(types.NewPointer(concrete), iface) {
+				impls = append(impls, concrete)
+			}
+		}
+		if len(impls) > 0 {
+			// 保证输出顺序稳定
+			sort.Slice(impls, func(i, j int) bool {
+				return impls[i].Obj().Pkg().Path()+"."+impls[i].Obj().Name() <
+					impls[j].Obj().Pkg().Path()+"."+impls[j].Obj().Name()
+			})
+			engine.globalMappings.InterfaceImpls[ifaceNamed] = impls
+			log.Printf("[DEBUG] 接口 %s 有 %d 个实现\n", ifaceNamed.Obj().Name(), len(impls))
+		}
+	}
 }
 
 // 预处理单个包
@@ -155,39 +527,44 @@ func (engine *ResponseParsingEngine) identifyResponseWrapperFunctions(pkg *packa
 }
 
 // 分析函数是否为响应封装函数候选者
+// 包含 c.JSON 调用的函数（包括方法）均可作为封装函数：gin.Context 可以是参数，也可以来自接收者字段等，
+// 后者要求 c.JSON 的响应数据引用了函数参数
 func (engine *ResponseParsingEngine) analyzeResponseWrapperCandidate(funcDecl *ast.FuncDecl, pkg *packages.Package) *ResponseWrapperFunc {
 	if funcDecl.Type.Params == nil || len(funcDecl.Type.Params.List) < 1 {
-		return nil // 响应封装函数至少需要1个参数: gin.Context
-	}
-
-	// 1. 查找gin.Context参数
-	ginContextIdx := engine.findGinContextParameter(funcDecl, pkg)
-	if ginContextIdx == -1 {
-		return nil // 必须有gin.Context参数
+		return nil // 响应封装函数至少需要1个参数: gin.Context 或业务数据
 	}
 
-	// 2. 确保不是Handler (Handler只有一个gin.Context参数)
+	// 1. 确保不是Handler (Handler只有一个gin.Context参数)
 	if engine.isGinHandlerFunction(funcDecl, pkg.TypesInfo) {
 		return nil // 排除Handler函数
 	}
 
-	// 3. 查找函数体内的c.JSON调用
+	// 2. 查找函数体内的c.JSON调用
 	jsonCallSite := engine.findJSONCallInFunction(funcDecl, pkg)
 	if jsonCallSite == nil {
 		return nil // 必须内部调用c.JSON
 	}
 
+	// 3. 查找gin.Context参数和数据参数索引 (interface{} 或具体类型的参数)
+	ginContextIdx := engine.findGinContextParameter(funcDecl, pkg)
+	dataParamIdx := engine.findJSONDataParameter(funcDecl, jsonCallSite, ginContextIdx, pkg)
+	if dataParamIdx == -1 {
+		if ginContextIdx == -1 {
+			return nil // Context不是参数时，c.JSON必须使用调用方传入的数据
+		}
+		dataParamIdx = engine.findDataParameter(funcDecl, ginContextIdx)
+	}
+
 	// 4. 获取返回类型 (可能返回结构体或void)
 	returnType := engine.getReturnStructType(funcDecl, pkg)
 
-	// 5. 查找数据参数索引 (interface{} 或具体类型的参数)
-	dataParamIdx := engine.findDataParameter(funcDecl, ginContextIdx)
-
-	// 6. 分析参数→字段映射
+	// 5. 分析参数→字段映射
 	paramToFieldMap := engine.analyzeParameterFieldMapping(funcDecl, pkg)
 
 	return &ResponseWrapperFunc{
 		FuncObj:         pkg.TypesInfo.ObjectOf(funcDecl.Name).(*types.Func),
+		FuncDecl:        funcDecl,
+		Package:         pkg,
 		GinContextIdx:   ginContextIdx,
 		DataParamIdx:    dataParamIdx,
 		JSONCallSite:    jsonCallSite,
@@ -211,8 +588,15 @@ func (engine *ResponseParsingEngine) findGinContextParameter(funcDecl *ast.FuncD
 	return -1
 }
 
-// 检查类型是否为*gin.Context
-func (engine *ResponseParsingEngine) isGinContextType(expr ast.Expr, _ *packages.Package) bool {
+// 检查类型是否为*gin.Context (Iris 的 iris.Context 同样视为请求上下文)
+func (engine *ResponseParsingEngine) isGinContextType(expr ast.Expr, pkg *packages.Package) bool {
+	// 优先通过类型信息判断，支持别名导入 (import g "github.com/gin-gonic/gin")
+	if pkg != nil && pkg.TypesInfo != nil {
+		if typ := pkg.TypesInfo.TypeOf(expr); typ != nil {
+			return IsGinContextType(typ) || IsIrisContextType(typ)
+		}
+	}
+
 	if starExpr, ok := expr.(*ast.StarExpr); ok {
 		if selExpr, ok := starExpr.X.(*ast.SelectorExpr); ok {
 			if ident, ok := selExpr.X.(*ast.Ident); ok {
@@ -223,7 +607,43 @@ func (engine *ResponseParsingEngine) isGinContextType(expr ast.Expr, _ *packages
 	return false
 }
 
-// 检查是否为Gin Handler (只有一个gin.Context参数)
+// GinPackagePath Gin框架的导入路径
+const GinPackagePath = "github.com/gin-gonic/gin"
+
+// IsGinPackagePath 检查包路径是否为Gin框架的包：按 /gin-gonic/gin 后缀匹配而非完全相等，
+// 兼容 vendor 目录（如 example.com/app/vendor/github.com/gin-gonic/gin）及镜像、replace 后的模块路径
+func IsGinPackagePath(path string) bool {
+	return path == GinPackagePath || strings.HasSuffix(path, "/gin-gonic/gin")
+}
+
+// IsGinContextType 检查类型是否为*gin.Context (按真实导入路径判断，与导入别名无关)
+func IsGinContextType(typ types.Type) bool {
+	ptr, ok := typ.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj != nil && obj.Pkg() != nil && IsGinPackagePath(obj.Pkg().Path()) && obj.Name() == "Context"
+}
+
+// IsIrisContextType 检查类型是否为 Iris 的请求上下文 (iris.Context 为 *context.Context 的别名)
+func IsIrisContextType(typ types.Type) bool {
+	typ = unaliasType(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = unaliasType(ptr.Elem())
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return strings.HasPrefix(named.Obj().Pkg().Path(), "github.com/kataras/iris") && named.Obj().Name() == "Context"
+}
+
+// 检查是否为Gin Handler (只有一个gin.Context或iris.Context参数)
 func (engine *ResponseParsingEngine) isGinHandlerFunction(funcDecl *ast.FuncDecl, typeInfo *types.Info) bool {
 	if funcDecl.Type.Params == nil || len(funcDecl.Type.Params.List) != 1 {
 		return false
@@ -235,8 +655,7 @@ func (engine *ResponseParsingEngine) isGinHandlerFunction(funcDecl *ast.FuncDecl
 	}
 
 	if paramType := typeInfo.TypeOf(param.Type); paramType != nil {
-		typeStr := paramType.String()
-		return typeStr == "*github.com/gin-gonic/gin.Context" || typeStr == "*gin.Context"
+		return IsGinContextType(paramType) || IsIrisContextType(paramType)
 	}
 	return false
 }
@@ -250,7 +669,7 @@ func (engine *ResponseParsingEngine) findJSONCallInFunction(funcDecl *ast.FuncDe
 	var jsonCall *ast.CallExpr
 	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
 		if callExpr, ok := node.(*ast.CallExpr); ok {
-			if engine.isGinJSONCall(callExpr, pkg) {
+			if engine.isIrisJSONCall(callExpr, pkg) || engine.isGinJSONCall(callExpr, pkg) {
 				jsonCall = callExpr
 				return false // 找到第一个就停止
 			}
@@ -271,9 +690,43 @@ func (engine *ResponseParsingEngine) getReturnStructType(funcDecl *ast.FuncDecl,
 	returnExpr := funcDecl.Type.Results.List[0].Type
 	returnType := pkg.TypesInfo.TypeOf(returnExpr)
 
+	// 返回接口（如 Responder）时，使用 return 语句中构造的具体结构体
+	if returnType != nil && types.IsInterface(returnType) {
+		for _, result := range returnResults(funcDecl) {
+			if named := engine.resolveNamedStruct(pkg.TypesInfo.TypeOf(result)); named != nil {
+				log.Printf("[DEBUG] 函数 %s 返回接口，具体返回类型: %s\n", funcDecl.Name.Name, named.Obj().Name())
+				return named
+			}
+		}
+		return nil
+	}
+
 	return engine.resolveNamedStruct(returnType)
 }
 
+// 函数体中各 return 语句的第一个结果（不含闭包中的 return 和 nil）
+func returnResults(funcDecl *ast.FuncDecl) []ast.Expr {
+	var results []ast.Expr
+	if funcDecl.Body == nil {
+		return nil
+	}
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) > 0 {
+				if ident, ok := n.Results[0].(*ast.Ident); ok && ident.Name == "nil" {
+					return true
+				}
+				results = append(results, n.Results[0])
+			}
+		}
+		return true
+	})
+	return results
+}
+
 // 查找数据参数索引 (非gin.Context的参数)
 func (engine *ResponseParsingEngine) findDataParameter(funcDecl *ast.FuncDecl, ginContextIdx int) int {
 	paramIdx := 0
@@ -288,6 +741,32 @@ func (engine *ResponseParsingEngine) findDataParameter(funcDecl *ast.FuncDecl, g
 	return -1
 }
 
+// 查找被 c.JSON 响应数据引用的参数索引 (不含gin.Context参数)
+func (engine *ResponseParsingEngine) findJSONDataParameter(funcDecl *ast.FuncDecl, jsonCall *ast.CallExpr, ginContextIdx int, pkg *packages.Package) int {
+	dataExpr := engine.jsonCallData(jsonCall, pkg)
+	if dataExpr == nil {
+		return -1
+	}
+
+	paramIdx := -1
+	ast.Inspect(dataExpr, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		// 只接受在参数列表中声明的对象，排除同名的结构体字段等
+		obj := pkg.TypesInfo.ObjectOf(ident)
+		if obj == nil || obj.Pos() < funcDecl.Type.Params.Pos() || obj.Pos() >= funcDecl.Type.Params.End() {
+			return true
+		}
+		if idx := engine.getParameterIndex(obj, funcDecl); idx != -1 && idx != ginContextIdx {
+			paramIdx = idx
+		}
+		return paramIdx == -1
+	})
+	return paramIdx
+}
+
 // 分析参数→字段映射
 func (engine *ResponseParsingEngine) analyzeParameterFieldMapping(funcDecl *ast.FuncDecl, pkg *packages.Package) map[string]int {
 	fieldMapping := make(map[string]int)
@@ -343,8 +822,11 @@ func (engine *ResponseParsingEngine) buildStructTagMap(pkg *packages.Package) {
 						if structType, ok := typeSpec.Type.(*ast.StructType); ok {
 							// 获取类型对象
 							if obj := pkg.TypesInfo.ObjectOf(typeSpec.Name); obj != nil {
-								if named := obj.Type().(*types.Named); named != nil {
+								// 别名声明（type X = struct{...}）不产生命名类型，使用其底层的结构体类型
+								if named, ok := obj.Type().(*types.Named); ok {
 									engine.extractStructTags(named, structType)
+								} else {
+									engine.extractStructTags(obj.Type().Underlying(), structType)
 								}
 							}
 						}
@@ -356,11 +838,19 @@ func (engine *ResponseParsingEngine) buildStructTagMap(pkg *packages.Package) {
 	}
 }
 
-// 提取结构体字段的JSON Tag
-func (engine *ResponseParsingEngine) extractStructTags(named *types.Named, structType *ast.StructType) {
+// 提取结构体字段的JSON Tag和字段注释
+func (engine *ResponseParsingEngine) extractStructTags(typ types.Type, structType *ast.StructType) {
 	tagMap := make(map[string]string)
+	commentMap := make(map[string]string)
 
 	for _, field := range structType.Fields.List {
+		// 字段注释：优先使用字段上方的注释，其次使用行尾注释
+		if comment := fieldCommentText(field); comment != "" {
+			for _, name := range field.Names {
+				commentMap[name.Name] = comment
+			}
+		}
+
 		if len(field.Names) > 0 && field.Tag != nil {
 			fieldName := field.Names[0].Name
 			tag := strings.Trim(field.Tag.Value, "`")
@@ -378,8 +868,23 @@ func (engine *ResponseParsingEngine) extractStructTags(named *types.Named, struc
 	}
 
 	if len(tagMap) > 0 {
-		engine.globalMappings.StructTagMap[named] = tagMap
+		engine.globalMappings.StructTagMap[typ] = tagMap
+	}
+	if len(commentMap) > 0 {
+		engine.globalMappings.FieldComments[typ] = commentMap
+	}
+}
+
+// 获取结构体字段的注释文本
+func fieldCommentText(field *ast.Field) string {
+	var text string
+	if field.Doc != nil {
+		text = field.Doc.Text()
 	}
+	if strings.TrimSpace(text) == "" && field.Comment != nil {
+		text = field.Comment.Text()
+	}
+	return strings.Join(strings.Fields(text), " ")
 }
 
 // 分析结构体字面量中的参数映射
@@ -433,134 +938,399 @@ func (engine *ResponseParsingEngine) getParameterIndex(obj types.Object, funcDec
 // Handler解析阶段 (技术规范步骤2) - 核心响应表达式解析
 func (engine *ResponseParsingEngine) AnalyzeHandlerResponse(handlerDecl *ast.FuncDecl, pkg *packages.Package) *APISchema {
 	// 步骤1: 定位业务响应表达式（c.JSON调用或响应封装函数调用）
-	responseExpr := engine.findLastResponseExpression(handlerDecl, pkg)
-	if responseExpr == nil {
+	candidates := engine.findResponseExpressions(handlerDecl, pkg)
+	if len(candidates) == 0 {
 		log.Printf("[DEBUG] 未找到响应表达式\n")
 		return nil
 	}
 
-	// 步骤2: 响应表达式类型解析（核心）
-	return engine.resolveResponseExpression(responseExpr, pkg)
+	// 步骤2: 响应表达式类型解析（核心），合并各成功分支的结构
+	return engine.mergeSuccessResponses(candidates, func(expr ast.Expr) *APISchema {
+		return engine.resolveResponseExpression(expr, pkg)
+	})
+}
+
+// JSONP响应的内容类型
+const contentTypeJavaScript = "application/javascript"
+
+// Gin的JSON渲染方法，签名均为 (code int, obj any)，值为非JSON时的响应内容类型
+var ginJSONRenderMethods = map[string]string{
+	"JSON":         "",
+	"IndentedJSON": "",
+	"SecureJSON":   "",
+	"PureJSON":     "",
+	"AsciiJSON":    "",
+	"JSONP":        contentTypeJavaScript, // c.JSONP 使用回调函数包裹JSON
 }
 
 // 检查是否为gin.Context的JSON调用
 func (engine *ResponseParsingEngine) isGinJSONCall(callExpr *ast.CallExpr, pkg *packages.Package) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-		// 检查方法名是否为JSON
-		if selExpr.Sel.Name != "JSON" {
+		// 检查方法名是否为JSON渲染方法
+		if _, ok := ginJSONRenderMethods[selExpr.Sel.Name]; !ok {
 			return false
 		}
-
-		// 检查调用对象是否为*gin.Context类型
-		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			if obj := pkg.TypesInfo.ObjectOf(ident); obj != nil {
-				objType := obj.Type()
-				// 处理指针类型
-				if ptr, ok := objType.(*types.Pointer); ok {
-					objType = ptr.Elem()
-				}
-				// 检查是否为gin.Context
-				if named, ok := objType.(*types.Named); ok {
-					return named.Obj().Name() == "Context"
-				}
-			}
-		}
+		return engine.isGinContextReceiver(selExpr, pkg)
 	}
 	return false
 }
 
-// 响应表达式类型解析 (技术规范核心算法)
-func (engine *ResponseParsingEngine) resolveResponseExpression(expr ast.Expr, pkg *packages.Package) *APISchema {
-	log.Printf("[DEBUG] 统一递归解析响应表达式: %T\n", expr)
+// Iris的JSON渲染方法，签名均为 (v any, opts ...)，值为非JSON时的响应内容类型
+var irisJSONRenderMethods = map[string]string{
+	"JSON":  "",
+	"JSONP": contentTypeJavaScript,
+}
 
-	switch e := expr.(type) {
-	case *ast.CallExpr:
-		// 1. 函数调用表达式 - 递归解析函数调用
-		return engine.resolveFunctionCallRecursive(e, pkg)
-	case *ast.CompositeLit:
-		// 2. 字面量表达式 - 递归解析复合字面量 (结构体、map等)
-		return engine.resolveCompositeLiteralRecursive(e, pkg)
-	case *ast.Ident:
-		// 3. 标识符表达式 - 递归解析变量
-		return engine.resolveIdentifierRecursive(e, pkg)
-	case *ast.SelectorExpr:
-		// 4. 选择器表达式 - 递归解析包选择器
-		return engine.resolveSelectorExprRecursive(e, pkg)
-	default:
-		log.Printf("[DEBUG] 未支持的响应表达式类型: %T\n", expr)
-		return &APISchema{Type: "unknown", Description: "unsupported expression type"}
+// 检查是否为Iris Context的JSON调用 (ctx.JSON(v))
+func (engine *ResponseParsingEngine) isIrisJSONCall(callExpr *ast.CallExpr, pkg *packages.Package) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(callExpr.Args) < 1 {
+		return false
 	}
+	if _, ok := irisJSONRenderMethods[selExpr.Sel.Name]; !ok {
+		return false
+	}
+	typ := pkg.TypesInfo.TypeOf(selExpr.X)
+	return typ != nil && IsIrisContextType(typ)
 }
 
-// 直接分析封装函数的参数 (简化版本)
-func (engine *ResponseParsingEngine) analyzeWrapperFunctionArgs(wrapper *ResponseWrapperFunc, callArgs []ast.Expr, pkg *packages.Package) *APISchema {
-	log.Printf("[DEBUG] 直接分析封装函数参数，参数数量: %d，数据参数索引: %d\n", len(callArgs), wrapper.DataParamIdx)
-
-	// 创建基础响应结构 (基于Response类型)
-	responseSchema := &APISchema{
-		Type: "object",
-		Properties: map[string]*APISchema{
-			"request_id": {Type: "string", JSONTag: "request_id"},
-			"code":       {Type: "integer", JSONTag: "code"},
-			"message":    {Type: "string", JSONTag: "message"},
-			"data":       {Type: "any", JSONTag: "data", Description: "interface{}"},
-		},
+// JSON渲染调用中的响应数据参数：gin 的 c.JSON(code, obj) 为第二个参数，Iris 的 ctx.JSON(v) 为第一个参数
+func (engine *ResponseParsingEngine) jsonCallData(callExpr *ast.CallExpr, pkg *packages.Package) ast.Expr {
+	if engine.isIrisJSONCall(callExpr, pkg) {
+		return callExpr.Args[0]
 	}
+	if len(callExpr.Args) < 2 {
+		return nil
+	}
+	return callExpr.Args[1]
+}
+
+// 检查调用对象是否为*gin.Context类型 (c.JSON、h.ctx.JSON 等任意表达式)
+func (engine *ResponseParsingEngine) isGinContextReceiver(selExpr *ast.SelectorExpr, pkg *packages.Package) bool {
+	if objType := pkg.TypesInfo.TypeOf(selExpr.X); objType != nil {
+		// 处理指针类型
+		if ptr, ok := objType.(*types.Pointer); ok {
+			objType = ptr.Elem()
+		}
+		// 检查是否为gin.Context
+		if named, ok := objType.(*types.Named); ok {
+			return named.Obj().Name() == "Context"
+		}
+	}
+	return false
+}
 
-	// 如果有数据参数，解析其具体类型
-	if wrapper.DataParamIdx >= 0 && wrapper.DataParamIdx < len(callArgs) {
-		dataArg := callArgs[wrapper.DataParamIdx]
-		log.Printf("[DEBUG] 分析数据参数[%d]: %T\n", wrapper.DataParamIdx, dataArg)
+// 默认的二进制内容类型
+const contentTypeOctetStream = "application/octet-stream"
 
-		dataType := pkg.TypesInfo.TypeOf(dataArg)
-		if dataType != nil {
-			log.Printf("[DEBUG] 数据参数类型: %s\n", dataType.String())
-			injectedSchema := engine.resolveType(dataType, engine.maxDepth)
-			log.Printf("[DEBUG] ✅ 参数类型注入成功: Data字段 interface{} -> %s\n", injectedSchema.Type)
+// 二进制/文件响应方法中内容类型参数的位置 (-1表示按文件扩展名推断)
+var ginBinaryRenderMethods = map[string]int{
+	"Data":           1, // c.Data(code, contentType, data)
+	"DataFromReader": 2, // c.DataFromReader(code, contentLength, contentType, reader, extraHeaders)
+	"File":           -1,
+	"FileAttachment": -1,
+	"FileFromFS":     -1,
+}
 
-			// 替换 Data 字段的类型信息
-			responseSchema.Properties["data"] = injectedSchema
-		} else {
-			log.Printf("[DEBUG] ❌ 无法获取数据参数类型\n")
+// 解析 c.Data / c.File 等二进制或文件响应，返回响应结构及状态码，非此类调用时返回nil
+func (engine *ResponseParsingEngine) binaryResponseSchema(callExpr *ast.CallExpr, pkg *packages.Package) (*APISchema, int) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, 0
+	}
+	contentTypeIdx, ok := ginBinaryRenderMethods[selExpr.Sel.Name]
+	if !ok || !engine.isGinContextReceiver(selExpr, pkg) {
+		return nil, 0
+	}
+
+	contentType := ""
+	status := http.StatusOK
+	if contentTypeIdx >= 0 {
+		if contentTypeIdx >= len(callExpr.Args) {
+			return nil, 0
+		}
+		status = constantStatusCode(callExpr.Args[0], pkg.TypesInfo)
+		if tv, ok := pkg.TypesInfo.Types[callExpr.Args[contentTypeIdx]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			contentType = constant.StringVal(tv.Value)
+		}
+	} else if len(callExpr.Args) > 0 {
+		// 文件响应按文件扩展名推断内容类型
+		if tv, ok := pkg.TypesInfo.Types[callExpr.Args[0]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			contentType = mime.TypeByExtension(path.Ext(constant.StringVal(tv.Value)))
 		}
-	} else {
-		log.Printf("[DEBUG] ❌ 数据参数索引无效: %d >= %d\n", wrapper.DataParamIdx, len(callArgs))
+	}
+	if contentType == "" {
+		contentType = contentTypeOctetStream
 	}
 
-	return responseSchema
+	log.Printf("[DEBUG] 找到c.%s调用，二进制响应: %s\n", selExpr.Sel.Name, contentType)
+	return &APISchema{
+		Type:        "string",
+		Format:      "binary",
+		ContentType: contentType,
+		Description: fmt.Sprintf("二进制响应 (c.%s)", selExpr.Sel.Name),
+	}, status
 }
 
-// 获取参数的实际类型 (用于类型注入)
-func (engine *ResponseParsingEngine) getParameterType(paramName string, funcDecl *ast.FuncDecl, callArgs []ast.Expr, pkg *packages.Package) types.Type {
-	// 查找参数在函数签名中的索引
-	paramIdx := -1
-	currentIdx := 0
+// 自定义渲染器的内容类型无法静态确定
+const contentTypeUnknown = "*/*"
+
+// gin render 包中的JSON渲染器，响应数据均在 Data 字段
+var ginJSONRenderTypes = map[string]bool{
+	"JSON":         true,
+	"IndentedJSON": true,
+	"SecureJSON":   true,
+	"JsonpJSON":    true,
+	"AsciiJSON":    true,
+	"PureJSON":     true,
+}
 
-	if funcDecl.Type.Params != nil {
-		for _, paramList := range funcDecl.Type.Params.List {
-			for _, paramIdent := range paramList.Names {
-				if paramIdent.Name == paramName {
-					paramIdx = currentIdx
-					break
-				}
-				currentIdx++
-			}
-			if paramIdx != -1 {
-				break
+// 渲染器中承载响应数据的字段名（按优先级）
+var renderPayloadFields = []string{"Data", "Payload"}
+
+// 解析 c.Render(code, r) 调用：从渲染器的 Data/Payload 字段获取响应结构，非此类调用时返回false
+// gin 内置的JSON渲染器按JSON处理，其他渲染器的内容类型标记为未知
+func (engine *ResponseParsingEngine) renderResponseCandidate(callExpr *ast.CallExpr, pkg *packages.Package) (responseCandidate, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Render" || len(callExpr.Args) != 2 || !engine.isGinContextReceiver(selExpr, pkg) {
+		return responseCandidate{}, false
+	}
+
+	candidate := responseCandidate{
+		status:      constantStatusCode(callExpr.Args[0], pkg.TypesInfo),
+		contentType: contentTypeUnknown,
+	}
+	renderExpr := callExpr.Args[1]
+	if unary, ok := renderExpr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		renderExpr = unary.X
+	}
+
+	var structType *types.Struct
+	if typ := pkg.TypesInfo.TypeOf(renderExpr); typ != nil {
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil &&
+			IsGinPackagePath(strings.TrimSuffix(named.Obj().Pkg().Path(), "/render")) && ginJSONRenderTypes[named.Obj().Name()] {
+			candidate.contentType = ""
+			if named.Obj().Name() == "JsonpJSON" {
+				candidate.contentType = contentTypeJavaScript
 			}
 		}
+		structType, _ = typ.Underlying().(*types.Struct)
 	}
 
-	// 如果找到参数且有对应的调用参数，返回调用参数的类型
-	if paramIdx >= 0 && paramIdx < len(callArgs) {
-		return pkg.TypesInfo.TypeOf(callArgs[paramIdx])
+	payload := renderPayloadField(structType)
+	if payload == nil {
+		log.Printf("[DEBUG] c.Render 的渲染器没有 Data/Payload 字段\n")
+		candidate.schema = &APISchema{Type: "unknown", ContentType: candidate.contentType, Description: "自定义渲染器 (c.Render)"}
+		return candidate, true
 	}
 
-	return nil
+	// 渲染器为结构体字面量时解析字段的值表达式，否则使用字段的声明类型
+	if compLit, ok := renderExpr.(*ast.CompositeLit); ok {
+		if valueExpr := compositeLitField(compLit, structType, payload.Name()); valueExpr != nil {
+			candidate.expr = valueExpr
+			log.Printf("[DEBUG] 找到c.Render调用，响应表达式类型: %T\n", valueExpr)
+			return candidate, true
+		}
+	}
+	candidate.schema = withContentType(engine.resolveType(payload.Type(), engine.maxDepth), candidate.contentType)
+	return candidate, true
 }
 
-// 查找函数声明
-func (engine *ResponseParsingEngine) findFunctionDeclaration(funcObj *types.Func, targetPkg *packages.Package) *ast.FuncDecl {
+// 返回渲染器结构体中承载响应数据的字段
+func renderPayloadField(structType *types.Struct) *types.Var {
+	if structType == nil {
+		return nil
+	}
+	for _, name := range renderPayloadFields {
+		for i := 0; i < structType.NumFields(); i++ {
+			if field := structType.Field(i); field.Name() == name {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+// 返回结构体字面量中指定字段的值表达式，支持键值形式和按位置赋值
+func compositeLitField(compLit *ast.CompositeLit, structType *types.Struct, name string) ast.Expr {
+	for i, elt := range compLit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+				return kv.Value
+			}
+		} else if structType != nil && i < structType.NumFields() && structType.Field(i).Name() == name {
+			return elt
+		}
+	}
+	return nil
+}
+
+// 为响应结构标记内容类型（复制一份，避免修改缓存中共享的结构）
+func withContentType(schema *APISchema, contentType string) *APISchema {
+	if schema == nil || contentType == "" {
+		return schema
+	}
+	copied := *schema
+	copied.ContentType = contentType
+	return &copied
+}
+
+// 响应表达式类型解析 (技术规范核心算法)
+func (engine *ResponseParsingEngine) resolveResponseExpression(expr ast.Expr, pkg *packages.Package) *APISchema {
+	log.Printf("[DEBUG] 统一递归解析响应表达式: %T\n", expr)
+
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		// 1. 函数调用表达式 - 递归解析函数调用
+		return engine.resolveFunctionCallRecursive(e, pkg)
+	case *ast.CompositeLit:
+		// 2. 字面量表达式 - 递归解析复合字面量 (结构体、map等)
+		return engine.resolveCompositeLiteralRecursive(e, pkg)
+	case *ast.Ident:
+		// 3. 标识符表达式 - 递归解析变量
+		return engine.resolveIdentifierRecursive(e, pkg)
+	case *ast.SelectorExpr:
+		// 4. 选择器表达式 - 递归解析包选择器
+		return engine.resolveSelectorExprRecursive(e, pkg)
+	default:
+		log.Printf("[DEBUG] 未支持的响应表达式类型: %T\n", expr)
+		return &APISchema{Type: "unknown", Description: "unsupported expression type"}
+	}
+}
+
+// 展开响应封装函数：在封装函数所在包中解析其 c.JSON 的响应数据，形参类型替换为调用方传入的实参类型
+func (engine *ResponseParsingEngine) analyzeWrapperFunctionArgs(wrapper *ResponseWrapperFunc, callArgs []ast.Expr, pkg *packages.Package) *APISchema {
+	log.Printf("[DEBUG] 展开响应封装函数 %s，参数数量: %d，数据参数索引: %d\n", wrapper.FuncObj.Name(), len(callArgs), wrapper.DataParamIdx)
+
+	var responseSchema *APISchema
+	if wrapper.FuncDecl != nil && wrapper.JSONCallSite != nil {
+		if dataExpr := engine.jsonCallData(wrapper.JSONCallSite, wrapper.Package); dataExpr != nil {
+			restore := engine.bindCallArgs(wrapper.FuncDecl, wrapper.Package, callArgs, pkg)
+			responseSchema = engine.resolveResponseExpression(dataExpr, wrapper.Package)
+			restore()
+		}
+	}
+
+	if responseSchema == nil || responseSchema.Type == "unknown" {
+		// 无法解析封装函数内部的响应数据时，使用默认的响应结构
+		responseSchema = &APISchema{
+			Type: "object",
+			Properties: map[string]*APISchema{
+				"request_id": {Type: "string", JSONTag: "request_id"},
+				"code":       {Type: "integer", JSONTag: "code"},
+				"message":    {Type: "string", JSONTag: "message"},
+				"data":       {Type: "any", JSONTag: "data", Description: "interface{}"},
+			},
+		}
+	}
+
+	// 响应数据中仍为 interface{} 的 data 字段，注入数据参数的实际类型
+	if wrapper.DataParamIdx < 0 || wrapper.DataParamIdx >= len(callArgs) {
+		return responseSchema
+	}
+	for name, prop := range responseSchema.Properties {
+		if prop.Type != "any" || (prop.JSONTag != "data" && name != "data") {
+			continue
+		}
+		// 带类型的 nil（如 (*User)(nil)）按指向的类型注入，无类型的 nil 保持 any
+		if dataType := engine.typeOf(callArgs[wrapper.DataParamIdx], pkg); dataType != nil && !isUntypedNil(dataType) {
+			injectedSchema := engine.resolveType(dataType, engine.maxDepth)
+			injectedSchema.JSONTag = prop.JSONTag
+			responseSchema.Properties[name] = injectedSchema
+			log.Printf(console.Text("[DEBUG] ✅ 参数类型注入成功: %s字段 interface{} -> %s\n"), name, injectedSchema.Type)
+		}
+	}
+
+	return responseSchema
+}
+
+// 将调用实参的类型绑定到被调用函数的形参上，返回恢复之前绑定的函数
+// 实参类型在调用方所在包中解析（可能本身是上一层调用的形参），形参对象来自被调用函数所在包
+func (engine *ResponseParsingEngine) bindCallArgs(funcDecl *ast.FuncDecl, declPkg *packages.Package, callArgs []ast.Expr, callerPkg *packages.Package) func() {
+	previous := engine.paramBindings
+	bindings := make(map[types.Object]types.Type)
+
+	if funcDecl.Type.Params != nil && declPkg != nil {
+		paramIdx := 0
+		for _, paramList := range funcDecl.Type.Params.List {
+			for _, paramIdent := range paramList.Names {
+				if paramIdx < len(callArgs) {
+					// 无类型的 nil 不绑定，形参保持声明的类型（如 interface{}）
+					if argType := engine.typeOf(callArgs[paramIdx], callerPkg); argType != nil && !isUntypedNil(argType) {
+						if obj := declPkg.TypesInfo.Defs[paramIdent]; obj != nil {
+							bindings[obj] = argType
+						}
+					}
+				}
+				paramIdx++
+			}
+		}
+	}
+
+	engine.paramBindings = bindings
+	return func() { engine.paramBindings = previous }
+}
+
+// 获取表达式的类型，当前展开的函数形参使用调用方实参的类型
+func (engine *ResponseParsingEngine) typeOf(expr ast.Expr, pkg *packages.Package) types.Type {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if argType, ok := engine.paramBindings[pkg.TypesInfo.ObjectOf(ident)]; ok {
+			return argType
+		}
+	}
+	return pkg.TypesInfo.TypeOf(expr)
+}
+
+// 解析字段的 default 标签，按字段类型转换为对应的JSON类型（integer、number、boolean，其他类型保留字符串）；
+// 没有标签或无法按字段类型解析时返回nil
+func parseDefaultTag(tag reflect.StructTag, schema *APISchema) interface{} {
+	value, ok := tag.Lookup("default")
+	if !ok || schema == nil {
+		return nil
+	}
+
+	var parsed interface{}
+	var err error
+	switch schema.Type {
+	case "integer":
+		parsed, err = strconv.ParseInt(value, 10, 64)
+	case "number":
+		parsed, err = strconv.ParseFloat(value, 64)
+	case "boolean":
+		parsed, err = strconv.ParseBool(value)
+	default:
+		parsed = value
+	}
+	if err != nil {
+		log.Printf("[DEBUG] 无法按类型 %s 解析默认值 %q: %v\n", schema.Type, value, err)
+		return nil
+	}
+	return parsed
+}
+
+// 是否为无类型的 nil（字面量 nil），带类型的 nil 如 (*User)(nil) 不属于此类
+func isUntypedNil(typ types.Type) bool {
+	basic, ok := typ.(*types.Basic)
+	return ok && basic.Kind() == types.UntypedNil
+}
+
+// 查找函数声明所在的包
+func (engine *ResponseParsingEngine) packageOf(funcDecl *ast.FuncDecl) *packages.Package {
+	for _, pkg := range engine.allPackages {
+		for _, file := range pkg.Syntax {
+			if file.Pos() <= funcDecl.Pos() && funcDecl.End() <= file.End() {
+				return pkg
+			}
+		}
+	}
+	return nil
+}
+
+// 查找函数声明
+func (engine *ResponseParsingEngine) findFunctionDeclaration(funcObj *types.Func, targetPkg *packages.Package) *ast.FuncDecl {
 	// 首先在目标包中查找
 	for _, file := range targetPkg.Syntax {
 		for _, decl := range file.Decls {
@@ -645,11 +1415,11 @@ func (engine *ResponseParsingEngine) resolveFunctionByTypeInfo(callExpr *ast.Cal
 				log.Printf("[DEBUG] 尝试Response类型参数注入\n")
 				if len(callExpr.Args) >= 2 {
 					dataArg := callExpr.Args[1]
-					dataType := pkg.TypesInfo.TypeOf(dataArg)
+					dataType := engine.typeOf(dataArg, pkg)
 					if dataType != nil {
 						injectedSchema := engine.resolveType(dataType, engine.maxDepth)
 						schema.Properties["Data"] = injectedSchema
-						log.Printf("[DEBUG] ✅ 参数注入成功: %s\n", injectedSchema.Type)
+						log.Printf(console.Text("[DEBUG] ✅ 参数注入成功: %s\n"), injectedSchema.Type)
 					}
 				}
 			}
@@ -669,135 +1439,64 @@ func (engine *ResponseParsingEngine) analyzeFunctionReturnRecursive(funcDecl *as
 	}
 
 	// 查找return语句
-	var returnExpr ast.Expr
-	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
-		if retStmt, ok := node.(*ast.ReturnStmt); ok && len(retStmt.Results) > 0 {
-			returnExpr = retStmt.Results[0]
-			return false // 找到第一个return就停止
-		}
-		return true
-	})
-
-	if returnExpr == nil {
+	results := returnResults(funcDecl)
+	if len(results) == 0 {
 		return &APISchema{Type: "unknown", Description: "no return statement"}
 	}
 
-	log.Printf("[DEBUG] 找到返回表达式: %T\n", returnExpr)
+	log.Printf("[DEBUG] 找到返回表达式: %T\n", results[0])
+
+	// 在函数所在包中解析返回表达式，并注入调用参数的类型信息
+	declPkg := engine.packageOf(funcDecl)
+	if declPkg == nil {
+		declPkg = pkg
+	}
+	restore := engine.bindCallArgs(funcDecl, declPkg, callArgs, pkg)
+	defer restore()
+
+	// 返回接口（如 Responder）的函数可能在不同分支构造不同的具体封装类型，合并各 return 语句的结构
+	if declaresInterfaceResult(funcDecl, declPkg) && len(results) > 1 {
+		var schemas []*APISchema
+		for _, result := range results {
+			schemas = append(schemas, engine.resolveReturnExpression(result, declPkg))
+		}
+		return mergeBranchSchemas(schemas)
+	}
+	return engine.resolveReturnExpression(results[0], declPkg)
+}
 
-	// 递归解析返回表达式，并注入调用参数的类型信息
-	return engine.resolveReturnExpressionWithArgs(returnExpr, funcDecl, callArgs, pkg)
+// 函数声明的第一个返回值是否为接口类型
+func declaresInterfaceResult(funcDecl *ast.FuncDecl, pkg *packages.Package) bool {
+	if funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) == 0 {
+		return false
+	}
+	typ := pkg.TypesInfo.TypeOf(funcDecl.Type.Results.List[0].Type)
+	return typ != nil && types.IsInterface(typ)
 }
 
-// 解析返回表达式并注入参数类型
-func (engine *ResponseParsingEngine) resolveReturnExpressionWithArgs(returnExpr ast.Expr, funcDecl *ast.FuncDecl, callArgs []ast.Expr, pkg *packages.Package) *APISchema {
+// 解析函数的返回表达式
+func (engine *ResponseParsingEngine) resolveReturnExpression(returnExpr ast.Expr, pkg *packages.Package) *APISchema {
 	switch retExpr := returnExpr.(type) {
-	case *ast.CompositeLit:
-		// 复合字面量 (如 gin.H{...} 或 Response{...})
-		return engine.resolveCompositeLiteralWithArgs(retExpr, funcDecl, callArgs, pkg)
+	case *ast.CompositeLit, *ast.UnaryExpr:
+		// 复合字面量 (如 gin.H{...}、Response{...} 或 &Response{...})
+		return engine.resolveLiteralValue(retExpr, pkg)
 	case *ast.CallExpr:
 		// 函数调用 (如 ResponseOK(ctx, data))
 		return engine.resolveFunctionCallRecursive(retExpr, pkg)
 	case *ast.Ident:
 		// 变量引用
 		return engine.resolveIdentifierRecursive(retExpr, pkg)
-	case *ast.UnaryExpr:
-		// 一元表达式 (如 &Response{...})
-		return engine.resolveUnaryExpressionWithArgs(retExpr, funcDecl, callArgs, pkg)
 	default:
 		// 其他类型，使用基础解析
 		returnType := pkg.TypesInfo.TypeOf(returnExpr)
 		if returnType == nil {
-			log.Printf("[DEBUG] ❌ 无法获取返回表达式类型: %T\n", returnExpr)
+			log.Printf(console.Text("[DEBUG] ❌ 无法获取返回表达式类型: %T\n"), returnExpr)
 			return &APISchema{Type: "unknown", Description: "unable to get return expression type"}
 		}
 		return engine.resolveType(returnType, engine.maxDepth)
 	}
 }
 
-// 解析复合字面量并注入参数类型
-func (engine *ResponseParsingEngine) resolveCompositeLiteralWithArgs(compLit *ast.CompositeLit, funcDecl *ast.FuncDecl, callArgs []ast.Expr, pkg *packages.Package) *APISchema {
-	log.Printf("[DEBUG] 解析复合字面量并注入参数类型\n")
-
-	// 创建字段映射
-	properties := make(map[string]*APISchema)
-
-	for i, elt := range compLit.Elts {
-		if kv, ok := elt.(*ast.KeyValueExpr); ok {
-			// 获取key名称
-			var keyName string
-			if basicLit, ok := kv.Key.(*ast.BasicLit); ok && basicLit.Kind == token.STRING {
-				// 字符串字面量（如 gin.H{"key": value}）
-				keyName = strings.Trim(basicLit.Value, "`\"")
-			} else if ident, ok := kv.Key.(*ast.Ident); ok {
-				// 标识符（如 struct{Field: value}）
-				keyName = ident.Name
-			} else {
-				keyName = fmt.Sprintf("field_%d", i)
-			}
-
-			// 解析value，如果是参数则注入实际类型
-			valueSchema := engine.resolveValueWithParameterInjection(kv.Value, funcDecl, callArgs, pkg)
-			properties[keyName] = valueSchema
-
-			log.Printf("[DEBUG] 字段 %s: %s\n", keyName, valueSchema.Type)
-		}
-	}
-
-	return &APISchema{
-		Type:       "object",
-		Properties: properties,
-	}
-}
-
-// 解析值并注入参数类型
-func (engine *ResponseParsingEngine) resolveValueWithParameterInjection(valueExpr ast.Expr, funcDecl *ast.FuncDecl, callArgs []ast.Expr, pkg *packages.Package) *APISchema {
-	switch val := valueExpr.(type) {
-	case *ast.Ident:
-		// 如果是参数标识符，注入实际参数类型
-		if paramType := engine.getParameterType(val.Name, funcDecl, callArgs, pkg); paramType != nil {
-			log.Printf("[DEBUG] ✅ 参数类型注入: %s -> %s\n", val.Name, paramType.String())
-			return engine.resolveType(paramType, engine.maxDepth)
-		}
-		// 否则使用默认解析
-		return engine.resolveIdentifierRecursive(val, pkg)
-	default:
-		// 其他类型使用基础解析
-		valueType := pkg.TypesInfo.TypeOf(valueExpr)
-		if valueType != nil {
-			return engine.resolveType(valueType, engine.maxDepth)
-		}
-		return &APISchema{Type: "any", Description: "interface{}"}
-	}
-}
-
-// 解析一元表达式 (如 &Response{...})
-func (engine *ResponseParsingEngine) resolveUnaryExpressionWithArgs(unaryExpr *ast.UnaryExpr, funcDecl *ast.FuncDecl, callArgs []ast.Expr, pkg *packages.Package) *APISchema {
-	log.Printf("[DEBUG] 解析一元表达式: %s\n", unaryExpr.Op.String())
-
-	// 处理取地址操作符 (&)
-	if unaryExpr.Op == token.AND {
-		// 递归解析内部表达式
-		if compLit, ok := unaryExpr.X.(*ast.CompositeLit); ok {
-			// &Response{...} 这种情况
-			return engine.resolveCompositeLiteralWithArgs(compLit, funcDecl, callArgs, pkg)
-		} else {
-			// 其他情况，使用基础解析
-			innerType := pkg.TypesInfo.TypeOf(unaryExpr.X)
-			if innerType != nil {
-				return engine.resolveType(innerType, engine.maxDepth)
-			}
-		}
-	}
-
-	// 其他一元操作符
-	exprType := pkg.TypesInfo.TypeOf(unaryExpr)
-	if exprType != nil {
-		return engine.resolveType(exprType, engine.maxDepth)
-	}
-
-	return &APISchema{Type: "unknown", Description: "unable to resolve unary expression"}
-}
-
 // 递归解析复合字面量 (暂时使用原有逻辑)
 func (engine *ResponseParsingEngine) resolveCompositeLiteralRecursive(compLit *ast.CompositeLit, pkg *packages.Package) *APISchema {
 	// 目前使用原有的解析逻辑
@@ -835,6 +1534,10 @@ func (engine *ResponseParsingEngine) getFunctionObject(callExpr *ast.CallExpr, p
 		// 包选择器调用
 		if obj := pkg.TypesInfo.ObjectOf(fun.Sel); obj != nil {
 			if funcObj, ok := obj.(*types.Func); ok {
+				// 接口方法调用（如 h.resp.OK(c, data)）解析到具体实现
+				if impl := engine.concreteMethod(funcObj, pkg.TypesInfo.TypeOf(fun.X)); impl != nil {
+					return impl
+				}
 				return funcObj
 			}
 		}
@@ -842,78 +1545,676 @@ func (engine *ResponseParsingEngine) getFunctionObject(callExpr *ast.CallExpr, p
 	return nil
 }
 
+// 接口方法对应的具体实现方法：接收者为项目内定义的接口且只有一个实现时使用该实现；
+// 有多个实现时，仅当其中恰好一个实现的该方法是响应封装函数时使用它，否则返回nil
+func (engine *ResponseParsingEngine) concreteMethod(method *types.Func, recvType types.Type) *types.Func {
+	if recvType == nil {
+		return nil
+	}
+	named, ok := unaliasType(recvType).(*types.Named)
+	if !ok || !types.IsInterface(named) {
+		return nil
+	}
+
+	var impls, wrappers []*types.Func
+	for _, impl := range engine.globalMappings.InterfaceImpls[named] {
+		obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(impl), true, method.Pkg(), method.Name())
+		implMethod, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+		impls = append(impls, implMethod)
+		if _, isWrapper := engine.globalMappings.ResponseWrappers[implMethod]; isWrapper {
+			wrappers = append(wrappers, implMethod)
+		}
+	}
+
+	switch {
+	case len(impls) == 1:
+		log.Printf("[DEBUG] 接口方法 %s 解析为唯一实现 %s\n", method.Name(), impls[0].FullName())
+		return impls[0]
+	case len(wrappers) == 1:
+		log.Printf("[DEBUG] 接口方法 %s 解析为响应封装实现 %s\n", method.Name(), wrappers[0].FullName())
+		return wrappers[0]
+	}
+	return nil
+}
+
 // 解析直接结构体字面量
 func (engine *ResponseParsingEngine) resolveCompositeLiteral(compLit *ast.CompositeLit, pkg *packages.Package) *APISchema {
 	structType := pkg.TypesInfo.TypeOf(compLit)
 	if structType != nil {
-		return engine.resolveType(structType, engine.maxDepth)
+		// gin.H{...} 等 map[string]interface{} 字面量按键展开，值可能是匿名结构体或嵌套的 gin.H
+		if mapType, ok := unaliasType(structType).Underlying().(*types.Map); ok && isFreeFormMap(mapType) {
+			if schema := engine.resolveMapLiteral(compLit, pkg); schema != nil {
+				return schema
+			}
+		}
+		// []gin.H{{...}, {...}} 等元素为任意对象的切片字面量，按各元素的键展开
+		if schema := engine.resolveMapSliceLiteral(compLit, structType, pkg); schema != nil {
+			return schema
+		}
+		schema := engine.resolveType(structType, engine.maxDepth)
+		if st, ok := unaliasType(structType).Underlying().(*types.Struct); ok {
+			engine.resolveStructLiteralFields(schema, st, compLit, pkg)
+		}
+		return schema
 	}
 	return &APISchema{Type: "object", Description: "composite literal"}
 }
 
-// 解析标识符（变量）
-func (engine *ResponseParsingEngine) resolveIdentifier(ident *ast.Ident, pkg *packages.Package) *APISchema {
-	if obj := pkg.TypesInfo.ObjectOf(ident); obj != nil {
-		return engine.resolveType(obj.Type(), engine.maxDepth)
+// 结构体字面量中 interface{} 类型的字段按赋值表达式解析 (如 Response{Data: user})，保留字段的标签和注释；
+// 字段类型是内含 interface{} 的结构体时（如 Page{Meta: Meta{Data: x}} 或 Page{Meta: pkg.BuildMeta()}），同样按赋值表达式展开
+func (engine *ResponseParsingEngine) resolveStructLiteralFields(schema *APISchema, structType *types.Struct, compLit *ast.CompositeLit, pkg *packages.Package) {
+	for i, elt := range compLit.Elts {
+		fieldName, valueExpr := "", elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			fieldName, valueExpr = key.Name, kv.Value
+		} else if i < structType.NumFields() {
+			fieldName = structType.Field(i).Name()
+		}
+
+		fieldSchema, ok := schema.Properties[fieldName]
+		if !ok {
+			continue
+		}
+		if fieldSchema.Type != "any" && !structFieldHasOpaqueField(structType, fieldName) {
+			continue
+		}
+		valueSchema := engine.resolveLiteralValue(valueExpr, pkg)
+		if valueSchema.Type == "any" || valueSchema.Type == "unknown" {
+			continue
+		}
+		valueSchema.JSONTag = fieldSchema.JSONTag
+		valueSchema.FormTag = fieldSchema.FormTag
+		if fieldSchema.Description != "" && fieldSchema.Description != "interface{}" {
+			valueSchema.Description = fieldSchema.Description
+		}
+		schema.Properties[fieldName] = valueSchema
 	}
-	return &APISchema{Type: "unknown", Description: "unresolved identifier"}
 }
 
-// 解析选择器表达式
-func (engine *ResponseParsingEngine) resolveSelectorExpr(selExpr *ast.SelectorExpr, pkg *packages.Package) *APISchema {
-	exprType := pkg.TypesInfo.TypeOf(selExpr)
-	if exprType != nil {
-		return engine.resolveType(exprType, engine.maxDepth)
+// 结构体中名为 name 的字段本身不是 interface{}，但其类型（如嵌套结构体）内含 interface{} 字段
+func structFieldHasOpaqueField(structType *types.Struct, name string) bool {
+	for i := 0; i < structType.NumFields(); i++ {
+		if field := structType.Field(i); field.Name() == name {
+			return !isOpaqueType(field.Type()) && hasOpaqueField(field.Type())
+		}
 	}
-	return &APISchema{Type: "unknown", Description: "unresolved selector"}
+	return false
 }
 
-// 回退类型解析
-func (engine *ResponseParsingEngine) resolveFallbackType(expr ast.Expr, pkg *packages.Package) *APISchema {
-	if exprType := pkg.TypesInfo.TypeOf(expr); exprType != nil {
-		return engine.resolveType(exprType, engine.maxDepth)
+// 解析 map 字面量的各个键值，键必须是字符串常量；值为嵌套的 map 字面量时逐层展开，
+// 超过 maxDepth 层时返回nil，按静态类型解析
+func (engine *ResponseParsingEngine) resolveMapLiteral(compLit *ast.CompositeLit, pkg *packages.Package) *APISchema {
+	if engine.literalDepth >= engine.maxDepth {
+		log.Printf("[DEBUG] map 字面量嵌套超过 %d 层，按静态类型解析\n", engine.maxDepth)
+		return nil
 	}
-	return &APISchema{Type: "unknown", Description: "fallback resolution failed"}
-}
+	engine.literalDepth++
+	defer func() { engine.literalDepth-- }()
 
-// 递归结构体解析 (技术规范步骤3) - 类型系统优先
-func (engine *ResponseParsingEngine) resolveType(typ types.Type, depth int) *APISchema {
-	if depth <= 0 {
-		return &APISchema{Type: "object", Description: "max depth reached"}
+	properties := make(map[string]*APISchema)
+	for _, elt := range compLit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		tv, ok := pkg.TypesInfo.Types[kv.Key]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			continue
+		}
+		properties[constant.StringVal(tv.Value)] = engine.resolveLiteralValue(kv.Value, pkg)
 	}
 
-	// 处理指针类型
-	if ptr, ok := typ.(*types.Pointer); ok {
-		return engine.resolveType(ptr.Elem(), depth)
+	if len(properties) == 0 {
+		return nil
 	}
+	return &APISchema{Type: "object", Properties: properties}
+}
 
-	// 处理基础类型
-	if basic, ok := typ.(*types.Basic); ok {
-		return &APISchema{Type: engine.mapBasicType(basic.Kind())}
+// 解析元素为 map[string]interface{} 的切片或数组字面量（如 []gin.H{{"id": 1}, {"id": 2, "name": "x"}}）：
+// 合并各元素展开后的键作为元素结构，同名键保留先出现的；不是这类字面量或元素无法展开时返回nil
+func (engine *ResponseParsingEngine) resolveMapSliceLiteral(compLit *ast.CompositeLit, typ types.Type, pkg *packages.Package) *APISchema {
+	var elemType types.Type
+	switch t := unaliasType(typ).Underlying().(type) {
+	case *types.Slice:
+		elemType = t.Elem()
+	case *types.Array:
+		elemType = t.Elem()
+	default:
+		return nil
+	}
+	if mapType, ok := unaliasType(elemType).Underlying().(*types.Map); !ok || !isFreeFormMap(mapType) {
+		return nil
 	}
 
-	// 处理切片类型
-	if slice, ok := typ.(*types.Slice); ok {
-		return &APISchema{
-			Type:  "array",
-			Items: engine.resolveType(slice.Elem(), depth-1),
+	properties := make(map[string]*APISchema)
+	for _, elt := range compLit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			// 数组字面量的下标形式 [...]gin.H{0: {...}}
+			elt = kv.Value
+		}
+		elemLit, ok := astutil.Unparen(elt).(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		elemSchema := engine.resolveMapLiteral(elemLit, pkg)
+		if elemSchema == nil {
+			continue
+		}
+		for key, prop := range elemSchema.Properties {
+			if _, exists := properties[key]; !exists {
+				properties[key] = prop
+			}
 		}
 	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return &APISchema{Type: "array", Items: &APISchema{Type: "object", Properties: properties}}
+}
 
-	// 处理数组类型
+// 解析字面量中的值：嵌套字面量和函数调用（如 ResponseOK(c, x)）继续展开，其他表达式按静态类型解析
+func (engine *ResponseParsingEngine) resolveLiteralValue(valueExpr ast.Expr, pkg *packages.Package) *APISchema {
+	switch val := valueExpr.(type) {
+	case *ast.CompositeLit:
+		return engine.resolveCompositeLiteral(val, pkg)
+	case *ast.UnaryExpr:
+		if compLit, ok := val.X.(*ast.CompositeLit); ok && val.Op == token.AND {
+			return engine.resolveCompositeLiteral(compLit, pkg)
+		}
+	case *ast.CallExpr:
+		return engine.resolveCallValue(val, pkg)
+	case *ast.Ident:
+		if schema := engine.resolveMapVariable(val, pkg); schema != nil {
+			return schema
+		}
+		if schema := engine.resolveCallResultVariable(val, pkg); schema != nil {
+			return schema
+		}
+		if schema := engine.resolvePackageVar(pkg.TypesInfo.ObjectOf(val)); schema != nil {
+			return schema
+		}
+	case *ast.SelectorExpr:
+		if schema := engine.resolvePackageVar(pkg.TypesInfo.ObjectOf(val.Sel)); schema != nil {
+			return schema
+		}
+	}
+	if valueType := engine.typeOf(valueExpr, pkg); valueType != nil {
+		schema := engine.resolveType(valueType, engine.maxDepth)
+		// 枚举常量（如 "status": StatusActive）按底层的字符串/整数类型解析（配置了类型映射时使用映射的类型），常量值作为示例
+		if example := enumConstExample(valueExpr, pkg.TypesInfo); example != nil {
+			enumSchema := *schema
+			if !isScalarSchemaType(enumSchema.Type) {
+				enumSchema = *engine.resolveType(valueType.Underlying(), engine.maxDepth)
+				enumSchema.Description = types.TypeString(valueType, func(*types.Package) string { return "" })
+			}
+			enumSchema.Example = example
+			return &enumSchema
+		}
+		return schema
+	}
+	return &APISchema{Type: "any", Description: "interface{}"}
+}
+
+// 是否为基本类型（字符串、整数、浮点数、布尔）的schema类型
+func isScalarSchemaType(schemaType string) bool {
+	switch schemaType {
+	case "string", "integer", "number", "boolean":
+		return true
+	}
+	return false
+}
+
+// 枚举常量（命名类型的常量，如 const StatusActive Status = "active"）的值，按常量种类转换为对应的JSON类型；
+// 非常量表达式、无类型常量及字面量（"ok"、200）返回nil
+func enumConstExample(expr ast.Expr, info *types.Info) interface{} {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil {
+		return nil
+	}
+	if _, ok := unaliasType(tv.Type).(*types.Named); !ok {
+		return nil
+	}
+	switch tv.Value.Kind() {
+	case constant.String:
+		return constant.StringVal(tv.Value)
+	case constant.Int:
+		if value, exact := constant.Int64Val(tv.Value); exact {
+			return value
+		}
+	case constant.Float:
+		value, _ := constant.Float64Val(tv.Value)
+		return value
+	case constant.Bool:
+		return constant.BoolVal(tv.Value)
+	}
+	return nil
+}
+
+// 解析字面量中的函数调用：静态类型已经完整时直接使用，
+// 返回类型为 interface{}、gin.H 或包含此类字段（如响应封装）时递归展开函数
+func (engine *ResponseParsingEngine) resolveCallValue(callExpr *ast.CallExpr, pkg *packages.Package) *APISchema {
+	var staticSchema *APISchema
+	if valueType := engine.typeOf(callExpr, pkg); valueType != nil {
+		staticSchema = engine.resolveType(valueType, engine.maxDepth)
+		if !hasOpaqueField(valueType) {
+			return staticSchema
+		}
+	}
+
+	expanded := engine.resolveFunctionCallRecursive(callExpr, pkg)
+	if expanded == nil || expanded.Type == "unknown" || expanded.Type == "any" {
+		if staticSchema != nil {
+			return staticSchema
+		}
+		return &APISchema{Type: "any", Description: "interface{}"}
+	}
+	return expanded
+}
+
+// 检查类型本身或其（嵌套结构体的）字段是否为 interface{} / map[string]interface{}，需要结合实际值才能确定结构
+func hasOpaqueField(typ types.Type) bool {
+	return hasOpaqueFieldVisited(typ, make(map[types.Type]bool))
+}
+
+func hasOpaqueFieldVisited(typ types.Type, visited map[types.Type]bool) bool {
+	if isOpaqueType(typ) {
+		return true
+	}
+	typ = unaliasType(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = unaliasType(ptr.Elem())
+	}
+	if visited[typ] {
+		return false
+	}
+	visited[typ] = true
+	if structType, ok := typ.Underlying().(*types.Struct); ok {
+		for i := 0; i < structType.NumFields(); i++ {
+			if hasOpaqueFieldVisited(structType.Field(i).Type(), visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 检查是否为 interface{} 或 map[string]interface{}（含 gin.H 等命名类型）
+func isOpaqueType(typ types.Type) bool {
+	typ = unaliasType(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = unaliasType(ptr.Elem())
+	}
+	switch underlying := typ.Underlying().(type) {
+	case *types.Interface:
+		return underlying.Empty()
+	case *types.Map:
+		return isFreeFormMap(underlying)
+	}
+	return false
+}
+
+// 解析标识符（变量）
+func (engine *ResponseParsingEngine) resolveIdentifier(ident *ast.Ident, pkg *packages.Package) *APISchema {
+	if obj := pkg.TypesInfo.ObjectOf(ident); obj != nil {
+		if argType, ok := engine.paramBindings[obj]; ok {
+			return engine.resolveType(argType, engine.maxDepth)
+		}
+		if schema := engine.resolveMapVariable(ident, pkg); schema != nil {
+			return schema
+		}
+		if schema := engine.resolveCallResultVariable(ident, pkg); schema != nil {
+			return schema
+		}
+		if schema := engine.resolvePackageVar(obj); schema != nil {
+			return schema
+		}
+		return engine.resolveType(obj.Type(), engine.maxDepth)
+	}
+	return &APISchema{Type: "unknown", Description: "unresolved identifier"}
+}
+
+// 解析包级变量（如 var DefaultConfig = Config{Data: Settings{}}）：变量类型含 interface{}、gin.H 等字段时，
+// 按其初始化字面量细化结构；不是包级变量、静态类型已经完整或没有字面量初始化时返回nil
+func (engine *ResponseParsingEngine) resolvePackageVar(obj types.Object) *APISchema {
+	variable, ok := obj.(*types.Var)
+	if !ok || variable.Pkg() == nil || variable.Parent() != variable.Pkg().Scope() || !hasOpaqueField(variable.Type()) {
+		return nil
+	}
+
+	for _, declPkg := range engine.allPackages {
+		if declPkg.PkgPath != variable.Pkg().Path() || declPkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range declPkg.Syntax {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					for i, name := range valueSpec.Names {
+						if name.Pos() != variable.Pos() || i >= len(valueSpec.Values) {
+							continue
+						}
+						value := valueSpec.Values[i]
+						if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+							value = unary.X
+						}
+						compLit, ok := value.(*ast.CompositeLit)
+						if !ok {
+							return nil
+						}
+						log.Printf("[DEBUG] 按包级变量 %s 的初始化字面量解析\n", variable.Name())
+						return engine.resolveCompositeLiteral(compLit, declPkg)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// 解析 gin.H 等任意对象类型的局部变量：合并变量初始化字面量中的键，以及使用位置之前的 m["key"] = value 索引赋值。
+// 各分支中的赋值都计入；一定会执行的赋值（与使用位置在同一语句块或其外层语句块中）得到的键标记为必有 (Required)，
+// 只在 if/switch/循环等分支中添加的键为可选。没有找到任何键时返回nil，按静态类型解析
+func (engine *ResponseParsingEngine) resolveMapVariable(ident *ast.Ident, pkg *packages.Package) *APISchema {
+	obj, ok := pkg.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || obj.Pkg() == nil || obj.Parent() == obj.Pkg().Scope() {
+		return nil
+	}
+	if mapType, ok := unaliasType(obj.Type()).Underlying().(*types.Map); !ok || !isFreeFormMap(mapType) {
+		return nil
+	}
+	body := enclosingFuncBody(pkg, obj.Pos())
+	if body == nil {
+		return nil
+	}
+
+	properties := make(map[string]*APISchema)
+	setKey := func(key string, schema *APISchema, required bool) {
+		// 键先前已确定必有时，之后分支中的赋值不改变其必有性
+		if existing, ok := properties[key]; ok && existing.Required {
+			required = true
+		}
+		copied := *schema
+		copied.Required = required
+		properties[key] = &copied
+	}
+	addLiteralKeys := func(value ast.Expr, required bool) {
+		if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			value = unary.X
+		}
+		if compLit, ok := value.(*ast.CompositeLit); ok {
+			if literal := engine.resolveMapLiteral(compLit, pkg); literal != nil {
+				for key, prop := range literal.Properties {
+					setKey(key, prop, required)
+				}
+			}
+		}
+	}
+
+	var stack []ast.Node
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if node.Pos() >= ident.Pos() {
+			return false
+		}
+		stack = append(stack, node)
+
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			// 跳过包含使用位置的赋值（如 m["self"] = m），避免递归解析自身
+			if len(n.Lhs) != len(n.Rhs) || n.End() > ident.Pos() {
+				return true
+			}
+			required := enclosesUse(stack, ident.Pos())
+			for i, lhs := range n.Lhs {
+				switch target := astutil.Unparen(lhs).(type) {
+				case *ast.Ident:
+					if pkg.TypesInfo.ObjectOf(target) == obj {
+						addLiteralKeys(n.Rhs[i], required)
+					}
+				case *ast.IndexExpr:
+					mapIdent, ok := astutil.Unparen(target.X).(*ast.Ident)
+					if !ok || pkg.TypesInfo.ObjectOf(mapIdent) != obj {
+						continue
+					}
+					tv, ok := pkg.TypesInfo.Types[target.Index]
+					if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+						continue
+					}
+					setKey(constant.StringVal(tv.Value), engine.resolveLiteralValue(n.Rhs[i], pkg), required)
+				}
+			}
+		case *ast.ValueSpec:
+			if n.End() > ident.Pos() {
+				return true
+			}
+			required := enclosesUse(stack, ident.Pos())
+			for i, name := range n.Names {
+				if pkg.TypesInfo.Defs[name] == obj && i < len(n.Values) {
+					addLiteralKeys(n.Values[i], required)
+				}
+			}
+		}
+		return true
+	})
+
+	if len(properties) == 0 {
+		return nil
+	}
+	log.Printf("[DEBUG] 变量 %s 通过字面量和索引赋值得到 %d 个键\n", ident.Name, len(properties))
+	return &APISchema{Type: "object", Properties: properties}
+}
+
+// 检查语句（栈顶）所在的语句块是否包含使用位置：包含时语句在到达使用位置前一定会执行，
+// 否则语句位于使用位置之外的分支、循环体或函数字面量中
+func enclosesUse(stack []ast.Node, use token.Pos) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch block := stack[i].(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+			return block.Pos() <= use && use < block.End()
+		}
+	}
+	return false
+}
+
+// 解析由多返回值调用赋值的接口类型局部变量（如 data, err := svc.Get()，Get 声明返回接口）：
+// 按被调函数各 return 语句第一个结果的具体类型解析，多个具体类型时合并为 oneOf。
+// 变量不是接口类型、不是由多返回值调用赋值或找不到函数体时返回nil，按静态类型解析
+func (engine *ResponseParsingEngine) resolveCallResultVariable(ident *ast.Ident, pkg *packages.Package) *APISchema {
+	obj, ok := pkg.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || obj.Pkg() == nil || obj.Parent() == obj.Pkg().Scope() || !types.IsInterface(obj.Type()) {
+		return nil
+	}
+	body := enclosingFuncBody(pkg, obj.Pos())
+	if body == nil {
+		return nil
+	}
+
+	// 使用位置之前最后一次以多返回值调用的第一个结果为该变量赋值的语句
+	var call *ast.CallExpr
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil || node.Pos() >= ident.Pos() {
+			return false
+		}
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) < 2 || len(assign.Rhs) != 1 || assign.End() > ident.Pos() {
+			return true
+		}
+		if lhs, ok := assign.Lhs[0].(*ast.Ident); ok && pkg.TypesInfo.ObjectOf(lhs) == obj {
+			call, _ = astutil.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+		}
+		return true
+	})
+	if call == nil {
+		return nil
+	}
+
+	var schemas []*APISchema
+	for _, typ := range engine.concreteReturnTypes(call, pkg, engine.maxDepth) {
+		schemas = append(schemas, engine.resolveType(typ, engine.maxDepth))
+	}
+	if len(schemas) == 0 {
+		return nil
+	}
+	log.Printf("[DEBUG] 变量 %s 按被调函数返回的具体类型解析\n", ident.Name)
+	return mergeBranchSchemas(schemas)
+}
+
+// 收集被调函数各 return 语句第一个结果的具体类型（跳过 nil 和接口类型的结果）；
+// 结果直接来自另一个调用（如 return s.repo.Find()）且为接口类型时继续展开该调用
+func (engine *ResponseParsingEngine) concreteReturnTypes(call *ast.CallExpr, pkg *packages.Package, depth int) []types.Type {
+	if depth <= 0 {
+		return nil
+	}
+	funcObj := engine.getFunctionObject(call, pkg)
+	if funcObj == nil {
+		return nil
+	}
+	funcDecl := engine.findFunctionDeclaration(funcObj, pkg)
+	if funcDecl == nil || funcDecl.Body == nil {
+		return nil
+	}
+	declPkg := engine.packageOf(funcDecl)
+	if declPkg == nil {
+		return nil
+	}
+
+	var concretes []types.Type
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		// 闭包中的 return 不属于该函数
+		if _, ok := node.(*ast.FuncLit); ok {
+			return false
+		}
+		ret, ok := node.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) == 0 {
+			return true
+		}
+		result := astutil.Unparen(ret.Results[0])
+		typ := declPkg.TypesInfo.TypeOf(result)
+		if tuple, ok := typ.(*types.Tuple); ok && tuple.Len() > 0 {
+			typ = tuple.At(0).Type()
+		}
+		if typ == nil {
+			return true
+		}
+		if isUntypedNil(typ) {
+			return true
+		}
+		if types.IsInterface(typ) {
+			if nested, ok := result.(*ast.CallExpr); ok {
+				concretes = append(concretes, engine.concreteReturnTypes(nested, declPkg, depth-1)...)
+			}
+			return true
+		}
+		concretes = append(concretes, typ)
+		return true
+	})
+	return concretes
+}
+
+// 返回包含指定位置的最内层函数（函数声明或函数字面量）的函数体
+func enclosingFuncBody(pkg *packages.Package, pos token.Pos) *ast.BlockStmt {
+	for _, file := range pkg.Syntax {
+		if pos < file.Pos() || pos >= file.End() {
+			continue
+		}
+		path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+		for _, node := range path {
+			switch fn := node.(type) {
+			case *ast.FuncLit:
+				return fn.Body
+			case *ast.FuncDecl:
+				return fn.Body
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// 解析选择器表达式
+func (engine *ResponseParsingEngine) resolveSelectorExpr(selExpr *ast.SelectorExpr, pkg *packages.Package) *APISchema {
+	// 其他包的包级变量 (如 config.Default)
+	if obj := pkg.TypesInfo.ObjectOf(selExpr.Sel); obj != nil {
+		if schema := engine.resolvePackageVar(obj); schema != nil {
+			return schema
+		}
+	}
+	exprType := pkg.TypesInfo.TypeOf(selExpr)
+	if exprType != nil {
+		return engine.resolveType(exprType, engine.maxDepth)
+	}
+	return &APISchema{Type: "unknown", Description: "unresolved selector"}
+}
+
+// 回退类型解析
+func (engine *ResponseParsingEngine) resolveFallbackType(expr ast.Expr, pkg *packages.Package) *APISchema {
+	if exprType := pkg.TypesInfo.TypeOf(expr); exprType != nil {
+		return engine.resolveType(exprType, engine.maxDepth)
+	}
+	return &APISchema{Type: "unknown", Description: "fallback resolution failed"}
+}
+
+// 递归结构体解析 (技术规范步骤3) - 类型系统优先
+func (engine *ResponseParsingEngine) resolveType(typ types.Type, depth int) *APISchema {
+	if depth <= 0 {
+		return &APISchema{Type: "object", Description: "max depth reached"}
+	}
+
+	// 处理类型别名（type UserID = int64），别名不会生成新的命名类型，直接解析其实际类型
+	typ = unaliasType(typ)
+
+	// 处理指针类型
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return engine.resolveType(ptr.Elem(), depth)
+	}
+
+	// 处理基础类型
+	if basic, ok := typ.(*types.Basic); ok {
+		return &APISchema{Type: engine.mapBasicType(basic.Kind())}
+	}
+
+	// 处理切片类型
+	if slice, ok := typ.(*types.Slice); ok {
+		return &APISchema{
+			Type:  "array",
+			Items: engine.resolveNullableType(slice.Elem(), depth-1),
+		}
+	}
+
+	// 处理数组类型
 	if array, ok := typ.(*types.Array); ok {
 		return &APISchema{
 			Type:  "array",
-			Items: engine.resolveType(array.Elem(), depth-1),
+			Items: engine.resolveNullableType(array.Elem(), depth-1),
 		}
 	}
 
 	// 处理Map类型
 	if mapType, ok := typ.(*types.Map); ok {
+		// map[string]interface{} 视为任意结构的对象
+		if isFreeFormMap(mapType) {
+			return &APISchema{Type: "object", Description: "free-form object"}
+		}
+
 		keyType := engine.resolveType(mapType.Key(), depth-1)
-		valueType := engine.resolveType(mapType.Elem(), depth-1)
+		valueType := engine.resolveNullableType(mapType.Elem(), depth-1)
 		return &APISchema{
 			Type: fmt.Sprintf("map[%s]%s", keyType.Type, valueType.Type),
+			Map:  true,
 			Properties: map[string]*APISchema{
 				"<key>":   keyType,
 				"<value>": valueType,
@@ -939,9 +2240,83 @@ func (engine *ResponseParsingEngine) resolveType(typ types.Type, depth int) *API
 		return engine.resolveStructType(structType, depth, nil)
 	}
 
+	// chan、func 等 encoding/json 无法序列化的类型（结构体字段中的已被跳过）
+	if isUnsupportedJSONType(typ) {
+		return &APISchema{Type: "unknown", Description: fmt.Sprintf("unsupported JSON type: %s", typ)}
+	}
+
 	return &APISchema{Type: typ.String(), Description: "unhandled type"}
 }
 
+// 检查类型是否无法被 encoding/json 序列化（chan、func、complex、unsafe.Pointer 及其指针），
+// 实现了 json.Marshaler 或 encoding.TextMarshaler 的命名类型除外
+func isUnsupportedJSONType(typ types.Type) bool {
+	typ = unaliasType(typ)
+	for {
+		ptr, ok := typ.(*types.Pointer)
+		if !ok {
+			break
+		}
+		typ = unaliasType(ptr.Elem())
+	}
+	if named, ok := typ.(*types.Named); ok {
+		methods := types.NewMethodSet(types.NewPointer(named))
+		for _, name := range []string{"MarshalJSON", "MarshalText"} {
+			if methods.Lookup(named.Obj().Pkg(), name) != nil {
+				return false
+			}
+		}
+	}
+	switch underlying := typ.Underlying().(type) {
+	case *types.Chan, *types.Signature:
+		return true
+	case *types.Basic:
+		switch underlying.Kind() {
+		case types.Complex64, types.Complex128, types.UnsafePointer:
+			return true
+		}
+	}
+	return false
+}
+
+// 解析字段、切片元素或map值的类型，指针类型（如 []*User 的元素、*[]User 字段）标记为可为 null
+// 顶层响应数据不经过这里，c.JSON(200, &user) 不会被标记
+func (engine *ResponseParsingEngine) resolveNullableType(typ types.Type, depth int) *APISchema {
+	schema := engine.resolveType(typ, depth)
+	if _, ok := unaliasType(typ).(*types.Pointer); ok {
+		schema.Nullable = true
+	}
+	return schema
+}
+
+// 表示任意JSON的类型
+var rawJSONTypes = map[string]bool{
+	"encoding/json.RawMessage":     true,
+	"encoding/json/jsontext.Value": true,
+}
+
+// 去除类型别名，返回实际类型
+// go/types 在启用别名类型时（Go 1.22+）使用 *types.Alias 表示别名，这里通过 Rhs 方法逐层展开以兼容旧版本
+func unaliasType(typ types.Type) types.Type {
+	for {
+		alias, ok := typ.(interface{ Rhs() types.Type })
+		if !ok {
+			return typ
+		}
+		typ = alias.Rhs()
+	}
+}
+
+// 检查是否为 map[string]interface{} 形式的任意对象
+func isFreeFormMap(mapType *types.Map) bool {
+	key, ok := unaliasType(mapType.Key()).Underlying().(*types.Basic)
+	if !ok || key.Kind() != types.String {
+		return false
+	}
+	value, ok := unaliasType(mapType.Elem()).Underlying().(*types.Interface)
+	return ok && value.Empty()
+}
+
 // 解析命名类型 (支持自定义结构体)
 func (engine *ResponseParsingEngine) resolveNamedType(named *types.Named, depth int) *APISchema {
 	obj := named.Obj()
@@ -949,15 +2324,40 @@ func (engine *ResponseParsingEngine) resolveNamedType(named *types.Named, depth
 		return &APISchema{Type: named.String()}
 	}
 
+	// 配置了类型映射的自定义类型（如 type Email string -> string/email），优先于按底层类型解析
+	if obj.Pkg() != nil {
+		if mapping, ok := engine.typeMappings[obj.Pkg().Path()+"."+obj.Name()]; ok {
+			return &APISchema{Type: mapping.Type, Format: mapping.Format}
+		}
+		// 手写的类型schema（如自定义 MarshalJSON 改变了输出结构的类型），整体替换推断结果
+		if override, ok := engine.overrides[obj.Pkg().Path()+"."+obj.Name()]; ok {
+			log.Printf("[DEBUG] 类型 %s 使用手写的schema\n", obj.Name())
+			return cloneSchema(override)
+		}
+	}
+
+	// json.RawMessage 底层为[]byte，但表示任意JSON（新版本中为 jsontext.Value 的别名）
+	if obj.Pkg() != nil && rawJSONTypes[obj.Pkg().Path()+"."+obj.Name()] {
+		return &APISchema{Type: "any", Description: "free-form JSON (json.RawMessage)"}
+	}
+
 	// 检查底层类型
 	underlying := named.Underlying()
 	if structType, ok := underlying.(*types.Struct); ok {
 		// 是结构体类型，递归解析字段
 		schema := engine.resolveStructType(structType, depth-1, named)
 		schema.Type = obj.Name() // 使用命名类型的名称
+		schema.Package = namedPackagePath(named)
 		return schema
 	}
 
+	// 非空接口：使用项目内已知的实现类型
+	if iface, ok := underlying.(*types.Interface); ok && !iface.Empty() {
+		if schema := engine.resolveInterfaceImpls(named, depth); schema != nil {
+			return schema
+		}
+	}
+
 	// 其他命名类型（如type alias）
 	underlyingSchema := engine.resolveType(underlying, depth-1)
 	return &APISchema{
@@ -965,20 +2365,62 @@ func (engine *ResponseParsingEngine) resolveNamedType(named *types.Named, depth
 		Description: fmt.Sprintf("alias for %s", underlyingSchema.Type),
 		Properties:  underlyingSchema.Properties,
 		Items:       underlyingSchema.Items,
+		Map:         underlyingSchema.Map,
+		Package:     namedPackagePath(named),
+	}
+}
+
+// 获取命名类型所在的包路径
+func namedPackagePath(named *types.Named) string {
+	if named.Obj() == nil || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path()
+}
+
+// 根据实现类型索引解析接口类型：单个实现直接展开，多个实现生成 OneOf
+func (engine *ResponseParsingEngine) resolveInterfaceImpls(named *types.Named, depth int) *APISchema {
+	impls := engine.globalMappings.InterfaceImpls[named]
+	if len(impls) == 0 {
+		return nil
+	}
+
+	ifaceName := named.Obj().Name()
+	// 展开实现类型算作一层递归，否则实现中又包含该接口字段时会绕过深度限制
+	if len(impls) == 1 {
+		schema := engine.resolveNamedType(impls[0], depth-1)
+		schema.Description = fmt.Sprintf("接口 %s 的唯一实现: %s", ifaceName, impls[0].Obj().Name())
+		return schema
+	}
+
+	var implNames []string
+	oneOf := make([]*APISchema, 0, len(impls))
+	for _, impl := range impls {
+		oneOf = append(oneOf, engine.resolveNamedType(impl, depth-1))
+		implNames = append(implNames, impl.Obj().Name())
+	}
+
+	return &APISchema{
+		Type:        ifaceName,
+		Description: fmt.Sprintf("接口 %s 的实现: %s", ifaceName, strings.Join(implNames, ", ")),
+		OneOf:       oneOf,
+		Package:     namedPackagePath(named),
 	}
 }
 
 // 解析结构体类型 (核心字段解析逻辑)
 func (engine *ResponseParsingEngine) resolveStructType(structType *types.Struct, depth int, named *types.Named) *APISchema {
 	properties := make(map[string]*APISchema)
+	var promoted []*APISchema // 匿名嵌入结构体的字段，在外层字段之后合并
+	var tagKey types.Type = structType
+	if named != nil {
+		tagKey = named
+	}
 
 	for i := 0; i < structType.NumFields(); i++ {
 		field := structType.Field(i)
 		tag := structType.Tag(i)
 
-		// 解析字段类型 (字段与结构体同级，只有在嵌套结构体时才减少深度)
-		fieldSchema := engine.resolveType(field.Type(), depth)
-
 		// 提取JSON标签
 		jsonTag := engine.extractJSONTag(tag)
 
@@ -987,29 +2429,130 @@ func (engine *ResponseParsingEngine) resolveStructType(structType *types.Struct,
 			continue
 		}
 
+		// chan、func 等类型的字段不会出现在JSON输出中（json.Marshal 会报错），不生成属性
+		if isUnsupportedJSONType(field.Type()) {
+			log.Printf("[DEBUG] 字段 %s 的类型 %s 无法序列化为JSON，已跳过\n", field.Name(), field.Type())
+			continue
+		}
+
+		// 没有JSON名称的匿名嵌入结构体（如 Pagination），按 encoding/json 的规则将其字段提升到外层
+		if field.Anonymous() && jsonTag == "" {
+			if embedded := engine.resolveEmbeddedStruct(field.Type(), depth); embedded != nil {
+				promoted = append(promoted, embedded)
+				continue
+			}
+		}
+
+		// 解析字段类型 (字段与结构体同级，只有在嵌套结构体时才减少深度)
+		fieldSchema := engine.resolveNullableType(field.Type(), depth)
+
 		// 如果没有JSON标签，使用字段名
 		if jsonTag == "" {
 			jsonTag = field.Name()
 		}
 
-		fieldSchema.JSONTag = jsonTag
+		fieldSchema.JSONTag = jsonTag
+		if formTag := strings.Split(reflect.StructTag(tag).Get("form"), ",")[0]; formTag != "" && formTag != "-" {
+			fieldSchema.FormTag = formTag
+		}
+		fieldSchema.Required = isRequiredField(reflect.StructTag(tag))
+		fieldSchema.ReadOnly = reflect.StructTag(tag).Get("readonly") == "true"
+		fieldSchema.WriteOnly = reflect.StructTag(tag).Get("writeonly") == "true"
+		fieldSchema.Default = parseDefaultTag(reflect.StructTag(tag), fieldSchema)
+
+		// 存在预构建的标签映射时使用预构建的标签（命名类型按类型查找，别名声明的结构体按结构体类型查找）
+		if tagMap, ok := engine.globalMappings.StructTagMap[tagKey]; ok {
+			if prebuiltTag, exists := tagMap[field.Name()]; exists {
+				fieldSchema.JSONTag = prebuiltTag
+			}
+		}
+		// 字段注释作为属性描述
+		if commentMap, ok := engine.globalMappings.FieldComments[tagKey]; ok {
+			if comment, exists := commentMap[field.Name()]; exists {
+				fieldSchema.Description = comment
+			}
+		}
+
+		properties[field.Name()] = fieldSchema
+	}
+
+	mergePromotedFields(properties, promoted)
+
+	return &APISchema{
+		Type:       "object",
+		Properties: properties,
+	}
+}
+
+// 检查字段的校验标签是否包含 required（gin 使用 binding 标签，validator 使用 validate 标签）
+func isRequiredField(tag reflect.StructTag) bool {
+	for _, key := range []string{"binding", "validate"} {
+		for _, rule := range strings.Split(tag.Get(key), ",") {
+			if strings.TrimSpace(rule) == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 解析匿名嵌入的结构体（T 或 *T），非结构体类型返回nil，按普通字段处理
+func (engine *ResponseParsingEngine) resolveEmbeddedStruct(typ types.Type, depth int) *APISchema {
+	typ = unaliasType(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = unaliasType(ptr.Elem())
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+	if obj := named.Obj(); obj.Pkg() != nil && engine.typeMappings[obj.Pkg().Path()+"."+obj.Name()].Type != "" {
+		// 配置了类型映射的类型按映射后的类型作为普通字段
+		return nil
+	}
+	return engine.resolveNamedType(named, depth)
+}
+
+// 将嵌入结构体的字段合并到外层：外层字段优先，JSON名称相同的字段只保留先出现的一个
+func mergePromotedFields(properties map[string]*APISchema, promoted []*APISchema) {
+	if len(promoted) == 0 {
+		return
+	}
+
+	usedNames := make(map[string]bool)
+	for key, prop := range properties {
+		usedNames[propertyName(key, prop)] = true
+	}
+
+	for _, embedded := range promoted {
+		// 按字段名排序，保证同名冲突时的结果稳定
+		keys := make([]string, 0, len(embedded.Properties))
+		for key := range embedded.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
 
-		// 如果有命名类型且存在预构建的标签映射，使用预构建的标签
-		if named != nil {
-			if tagMap, ok := engine.globalMappings.StructTagMap[named]; ok {
-				if prebuiltTag, exists := tagMap[field.Name()]; exists {
-					fieldSchema.JSONTag = prebuiltTag
-				}
+		for _, key := range keys {
+			prop := embedded.Properties[key]
+			name := propertyName(key, prop)
+			if _, exists := properties[key]; exists || usedNames[name] {
+				continue
 			}
+			properties[key] = prop
+			usedNames[name] = true
 		}
-
-		properties[field.Name()] = fieldSchema
 	}
+}
 
-	return &APISchema{
-		Type:       "object",
-		Properties: properties,
+// 属性在JSON中的名称：优先使用JSON标签
+func propertyName(key string, prop *APISchema) string {
+	if prop != nil && prop.JSONTag != "" {
+		return prop.JSONTag
 	}
+	return key
 }
 
 // 提取JSON标签
@@ -1211,6 +2754,15 @@ func (ra *ResponseAnalyzer) detectResponseMode(expr ast.Expr) int {
 		if _, isMap := e.Type.(*ast.MapType); isMap {
 			return MapLiteralResponse
 		}
+		// 命名类型的字面量（如 Response{...}、gin.H{...}）按底层类型判断
+		if typ := ra.pkg.TypesInfo.TypeOf(e); typ != nil {
+			switch unaliasType(typ).Underlying().(type) {
+			case *types.Struct:
+				return StructLiteralResponse
+			case *types.Map:
+				return MapLiteralResponse
+			}
+		}
 		return UnknownResponse
 
 	case *ast.CallExpr:
@@ -1254,16 +2806,16 @@ func (ra *ResponseAnalyzer) analyzeStructLiteral(expr ast.Expr) *APIResponse {
 
 	// 先按声明类型解析结构体
 	fields := ra.parseTypeFields(structType)
+	declared, _ := unaliasType(structType).Underlying().(*types.Struct)
 
-	// 遍历字面量中的每个字段赋值
-	for _, elt := range compLit.Elts {
-		kv, ok := elt.(*ast.KeyValueExpr)
-		if !ok {
-			continue
+	// 遍历字面量中的每个字段赋值，按位置赋值（如 Response{code, user}）时按声明顺序对应字段
+	for i, elt := range compLit.Elts {
+		fieldName, valueExpr := "", elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			fieldName, valueExpr = ra.extractFieldName(kv.Key), kv.Value
+		} else if declared != nil && i < declared.NumFields() {
+			fieldName = declared.Field(i).Name()
 		}
-
-		// 获取字段名
-		fieldName := ra.extractFieldName(kv.Key)
 		if fieldName == "" {
 			continue
 		}
@@ -1272,7 +2824,7 @@ func (ra *ResponseAnalyzer) analyzeStructLiteral(expr ast.Expr) *APIResponse {
 		if schema, exists := fields[fieldName]; exists {
 			if strings.Contains(schema.Type, "interface{}") {
 				// 获取实际值的类型
-				valueType := ra.pkg.TypesInfo.TypeOf(kv.Value)
+				valueType := ra.pkg.TypesInfo.TypeOf(valueExpr)
 				if valueType != nil {
 					// 更新字段类型信息
 					schema.Type = valueType.String()
@@ -1336,8 +2888,16 @@ func (ra *ResponseAnalyzer) analyzeMapLiteral(expr ast.Expr) *APIResponse {
 			JSONTag: keyStr,
 		}
 
+		switch kv.Value.(type) {
+		case *ast.CallExpr, *ast.CompositeLit:
+			// 值为函数调用（如 ResponseOK(c, x)）或嵌套字面量时递归展开
+			if nested := ra.AnalyzeResponseRecursively(kv.Value); nested != nil && len(nested.Fields) > 0 {
+				schema.Children = nested.Fields
+			}
+		}
+
 		// 递归解析嵌套结构（如果可能）
-		if valueType != nil && ra.isStructOrMap(valueType) {
+		if schema.Children == nil && valueType != nil && ra.isStructOrMap(valueType) {
 			schema.Children = ra.parseTypeFields(valueType)
 		}
 
@@ -1412,12 +2972,19 @@ func (ra *ResponseAnalyzer) extractWrappedData(callExpr *ast.CallExpr) *APIRespo
 					dataField.Children = dataResponse.Fields
 				}
 			} else {
-				// 处理nil值的情况
+				// 处理nil值的情况：带类型的 nil（如 (*User)(nil) 或 var u *User）按指向的类型解析，无类型的 nil 保持 any
 				dataFieldType := ra.pkg.TypesInfo.TypeOf(dataExpr)
-				if dataFieldType != nil {
+				if dataFieldType != nil && !isUntypedNil(dataFieldType) {
+					if ptr, ok := dataFieldType.(*types.Pointer); ok {
+						dataFieldType = ptr.Elem()
+						dataField.IsPointer = true
+					}
 					dataField.Type = dataFieldType.String()
+					if ra.isStructOrMap(dataFieldType) {
+						dataField.Children = ra.parseTypeFields(dataFieldType)
+					}
 				} else {
-					dataField.Type = "nil"
+					dataField.Type = "any"
 				}
 			}
 			baseFields["Data"] = dataField
@@ -1717,111 +3284,664 @@ func (a *GinHandlerAnalyzer) Analyze() map[string]*HandlerAnalysisResult {
 					// 使用新的完整Handler分析方法（包含请求参数和响应）
 					result := a.responseParsingEngine.AnalyzeHandlerComplete(funcDecl, pkg)
 
-					// 输出完整的分析结果（包含请求参数和响应）
-					if jsonData, err := json.MarshalIndent(result, "", "  "); err == nil {
-						log.Printf("📋 Handler分析结果:\n%s\n\n", string(jsonData))
-					}
-					results[result.PackagePath+"."+result.HandlerName] = result
-				}
-				return true
-			})
+					// 输出完整的分析结果（包含请求参数和响应）
+					if jsonData, err := json.MarshalIndent(result, "", "  "); err == nil {
+						log.Printf(console.Text("📋 Handler分析结果:\n%s\n\n"), string(jsonData))
+					}
+					results[result.PackagePath+"."+result.HandlerName] = result
+				}
+				return true
+			})
+		}
+	}
+	return results
+}
+
+// 检查是否是 Gin Handler
+func (a *GinHandlerAnalyzer) isGinHandler(funcDecl *ast.FuncDecl, info *types.Info) bool {
+	if len(funcDecl.Type.Params.List) != 1 {
+		return false
+	}
+
+	param := funcDecl.Type.Params.List[0]
+	if paramType := info.TypeOf(param.Type); paramType != nil {
+		typeStr := paramType.String()
+		return strings.Contains(typeStr, "gin.Context")
+	}
+	return false
+}
+
+// 完整分析Handler（包含请求参数和响应）
+func (engine *ResponseParsingEngine) AnalyzeHandlerComplete(handlerDecl *ast.FuncDecl, pkg *packages.Package) *HandlerAnalysisResult {
+	result := &HandlerAnalysisResult{
+		PackageName: pkg.Name,
+		PackagePath: pkg.PkgPath,
+		HandlerName: handlerDecl.Name.Name,
+	}
+
+	// 分析请求参数
+	paramAnalyzer := NewRequestParamAnalyzer(engine, pkg)
+	result.RequestParams = paramAnalyzer.AnalyzeHandlerParams(handlerDecl)
+
+	// 分析响应（合并各成功分支的结构）
+	if candidates := engine.findResponseExpressions(handlerDecl, pkg); len(candidates) > 0 {
+		result.Response = engine.mergeSuccessResponses(candidates, func(expr ast.Expr) *APISchema {
+			return engine.analyzeUnifiedResponseExpression(expr, pkg)
+		})
+		result.SuccessStatus = successStatusCode(candidates)
+		result.Responses = engine.responseCalls(candidates, pkg)
+		result.StatusResponses = responsesByStatus(result.Responses)
+	} else if schema := engine.contextStoredResponse(handlerDecl, pkg); schema != nil {
+		// 没有直接渲染响应，但通过 c.Set 存储了数据：通常由中间件读取后渲染
+		result.Response = schema
+		result.MiddlewareRendered = true
+	}
+
+	return result
+}
+
+// 常见的由中间件渲染的响应数据键名
+var middlewareResponseKeys = map[string]bool{
+	"data":     true,
+	"response": true,
+	"resp":     true,
+	"result":   true,
+}
+
+// 查找处理函数中 c.Set(key, value) 存储的数据作为由中间件渲染的响应：
+// 优先使用键名为 data、response、resp、result 的调用，否则使用最后一次调用；没有 c.Set 时返回nil
+func (engine *ResponseParsingEngine) contextStoredResponse(handlerDecl *ast.FuncDecl, pkg *packages.Package) *APISchema {
+	if handlerDecl.Body == nil {
+		return nil
+	}
+
+	var chosen *ast.CallExpr
+	chosenKey := ""
+	ast.Inspect(handlerDecl.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Set" || !IsGinContextType(pkg.TypesInfo.TypeOf(sel.X)) {
+			return true
+		}
+		key := types.ExprString(call.Args[0])
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if unquoted, err := strconv.Unquote(lit.Value); err == nil {
+				key = unquoted
+			}
+		}
+		// 已找到常见响应键名时，不再被其他键覆盖
+		if chosen == nil || !middlewareResponseKeys[strings.ToLower(chosenKey)] {
+			chosen, chosenKey = call, key
+		}
+		return true
+	})
+	if chosen == nil {
+		return nil
+	}
+
+	log.Printf("[DEBUG] 处理函数 %s 未直接渲染响应，使用 c.Set(%q) 存储的值\n", handlerDecl.Name.Name, chosenKey)
+	schema := engine.analyzeUnifiedResponseExpression(chosen.Args[1], pkg)
+	if schema == nil {
+		schema = &APISchema{Type: "unknown"}
+	}
+	described := *schema
+	described.Description = fmt.Sprintf("由中间件渲染: c.Set(%q) 存储的值", chosenKey)
+	return &described
+}
+
+// 解析每个响应调用的结构，保留状态码、行号及分支信息
+func (engine *ResponseParsingEngine) responseCalls(candidates []responseCandidate, pkg *packages.Package) []ResponseCallInfo {
+	calls := make([]ResponseCallInfo, 0, len(candidates))
+	for _, candidate := range candidates {
+		schema := candidate.schema
+		if schema == nil {
+			schema = withContentType(engine.analyzeUnifiedResponseExpression(candidate.expr, pkg), candidate.contentType)
+		}
+		calls = append(calls, ResponseCallInfo{
+			StatusCode: candidate.status,
+			Schema:     schema,
+			LineNumber: pkg.Fset.Position(candidate.pos).Line,
+			Branch:     candidate.branch,
+		})
+	}
+	return calls
+}
+
+// 按状态码分组合并各响应调用的结构，状态码无法静态确定的响应（如响应封装函数）使用 "default" 键
+func responsesByStatus(calls []ResponseCallInfo) map[string]*APISchema {
+	grouped := make(map[string][]*APISchema)
+	var keys []string
+	for _, call := range calls {
+		if call.Schema == nil {
+			continue
+		}
+		key := "default"
+		if call.StatusCode != 0 {
+			key = strconv.Itoa(call.StatusCode)
+		}
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], call.Schema)
+	}
+
+	responses := make(map[string]*APISchema, len(keys))
+	for _, key := range keys {
+		responses[key] = mergeBranchSchemas(grouped[key])
+	}
+	return responses
+}
+
+// 统一分析响应表达式（支持c.JSON第二个参数和响应封装函数调用）
+func (engine *ResponseParsingEngine) analyzeUnifiedResponseExpression(responseExpr ast.Expr, pkg *packages.Package) *APISchema {
+	switch expr := responseExpr.(type) {
+	case *ast.CallExpr:
+		// 响应封装函数调用
+		if engine.isResponseWrapperCall(expr, pkg) {
+			return engine.resolveFunctionCallRecursive(expr, pkg)
+		}
+		// 其他函数调用
+		return engine.resolveFunctionCallRecursive(expr, pkg)
+	case *ast.CompositeLit:
+		// 结构体字面量
+		return engine.resolveCompositeLiteral(expr, pkg)
+	case *ast.Ident:
+		// 变量
+		return engine.resolveIdentifier(expr, pkg)
+	case *ast.SelectorExpr:
+		// 选择器表达式
+		return engine.resolveSelectorExpr(expr, pkg)
+	default:
+		// 使用通用的类型解析
+		if exprType := pkg.TypesInfo.TypeOf(responseExpr); exprType != nil {
+			return engine.resolveType(exprType, engine.maxDepth)
+		}
+		return &APISchema{
+			Type:        "unknown",
+			Description: fmt.Sprintf("unsupported expression type: %T", responseExpr),
+		}
+	}
+}
+
+// 响应表达式及其HTTP状态码
+type responseCandidate struct {
+	expr   ast.Expr
+	status int        // 状态码，无法静态确定时为0（如响应封装函数）
+	schema *APISchema // 已确定的响应结构（如 c.Data 的二进制响应），无需再解析表达式
+
+	contentType string // 非JSON响应的内容类型（如自定义渲染器），为空表示JSON
+
+	pos    token.Pos             // 响应调用的位置
+	branch *models.BranchContext // 响应调用所在的分支
+}
+
+// 查找所有响应表达式 (c.JSON 或响应封装函数调用)，按执行顺序返回（延迟执行的调用在最后）
+func (engine *ResponseParsingEngine) findResponseExpressions(handlerDecl *ast.FuncDecl, pkg *packages.Package) []responseCandidate {
+	var candidates []responseCandidate
+
+	if handlerDecl.Body == nil {
+		return nil
+	}
+
+	// 记录从函数体到当前节点的路径，用于确定响应调用所在的分支
+	var stack []ast.Node
+	ast.Inspect(handlerDecl.Body, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, node)
+
+		found := len(candidates)
+		defer func() {
+			for i := found; i < len(candidates); i++ {
+				candidates[i].pos = node.Pos()
+				candidates[i].branch = responseBranch(stack, candidates[i].status)
+			}
+		}()
+
+		if callExpr, ok := node.(*ast.CallExpr); ok {
+			// 检查是否为c.JSON调用
+			if engine.isIrisJSONCall(callExpr, pkg) {
+				// Iris 的 ctx.JSON(v) 响应数据为第一个参数，状态码由 ctx.StatusCode 单独设置
+				candidates = append(candidates, responseCandidate{
+					expr:        callExpr.Args[0],
+					contentType: irisJSONRenderMethods[callExpr.Fun.(*ast.SelectorExpr).Sel.Name],
+				})
+				log.Printf("[DEBUG] 找到ctx.%s调用，响应表达式类型: %T\n", callExpr.Fun.(*ast.SelectorExpr).Sel.Name, callExpr.Args[0])
+			} else if engine.isGinJSONCall(callExpr, pkg) {
+				if len(callExpr.Args) >= 2 {
+					candidates = append(candidates, responseCandidate{
+						expr:        callExpr.Args[1],
+						status:      constantStatusCode(callExpr.Args[0], pkg.TypesInfo),
+						contentType: ginJSONRenderMethods[callExpr.Fun.(*ast.SelectorExpr).Sel.Name],
+					})
+					log.Printf("[DEBUG] 找到c.%s调用，响应表达式类型: %T\n", callExpr.Fun.(*ast.SelectorExpr).Sel.Name, callExpr.Args[1])
+				}
+			} else if schema, status := engine.binaryResponseSchema(callExpr, pkg); schema != nil {
+				// 检查是否为c.Data/c.File等二进制或文件响应
+				candidates = append(candidates, responseCandidate{schema: schema, status: status})
+			} else if candidate, ok := engine.renderResponseCandidate(callExpr, pkg); ok {
+				// 检查是否为 c.Render(code, r) 自定义渲染器调用
+				candidates = append(candidates, candidate)
+			} else if engine.isResponseWrapperCall(callExpr, pkg) {
+				// 检查是否为响应封装函数调用，状态码取封装函数内部 c.JSON 的状态码
+				candidates = append(candidates, responseCandidate{expr: callExpr, status: engine.wrapperStatusCode(callExpr, pkg)})
+				log.Printf("[DEBUG] 找到响应封装函数调用: %T\n", callExpr)
+			} else if schema, status, ok := engine.responderCall(callExpr, pkg); ok {
+				// 检查是否为配置的上下文响应器方法调用
+				candidates = append(candidates, responseCandidate{schema: schema, status: status})
+			} else if engine.isJSONEncoderCall(callExpr, pkg) {
+				// 检查是否为标准库处理函数中的 json.NewEncoder(w).Encode(x) 调用
+				candidates = append(candidates, responseCandidate{expr: callExpr.Args[0]})
+				log.Printf("[DEBUG] 找到json.NewEncoder(w).Encode调用，响应表达式类型: %T\n", callExpr.Args[0])
+			}
+		} else if assignStmt, ok := node.(*ast.AssignStmt); ok {
+			// 检查是否为Beego的 this.Data["json"] = v 赋值
+			if valueExpr := engine.beegoJSONDataValue(assignStmt, pkg); valueExpr != nil {
+				candidates = append(candidates, responseCandidate{expr: valueExpr})
+				log.Printf("[DEBUG] 找到Data[\"json\"]赋值，响应表达式类型: %T\n", valueExpr)
+			}
+		}
+		return true
+	})
+
+	return orderDeferredResponses(candidates)
+}
+
+// 根据节点路径确定响应调用所在的分支：最近的 switch 分支或 if 分支，都不在时为 normal
+// 延迟执行的调用（defer c.JSON(...) 或 defer func() { ... }() 中的调用）为 defer，保留其中 if/switch 的条件
+// 状态码为4xx/5xx的响应视为错误处理分支
+func responseBranch(stack []ast.Node, status int) *models.BranchContext {
+	branch := &models.BranchContext{Type: "normal", IsErrorPath: status >= 400}
+	found := false
+	for i := len(stack) - 2; i >= 0; i-- {
+		child := stack[i+1]
+		switch n := stack[i].(type) {
+		case *ast.DeferStmt:
+			branch.Type = "defer"
+			return branch
+		case *ast.FuncLit:
+			// 闭包中的响应调用不属于外层的分支，延迟执行的闭包除外
+			if i >= 2 && isDeferredFuncLit(n, stack[i-1], stack[i-2]) {
+				branch.Type = "defer"
+			}
+			return branch
+		case *ast.CaseClause:
+			if !found {
+				branch.Type = "switch"
+				branch.Condition = caseCondition(n, stack[:i])
+				found = true
+			}
+		case *ast.IfStmt:
+			// 只有 if 的主体和 else 分支中的调用属于该分支，条件和初始化语句中的不算
+			if found {
+				continue
+			}
+			if child == n.Body {
+				branch.Type = "if"
+				branch.Condition = types.ExprString(n.Cond)
+				found = true
+			} else if child == n.Else {
+				branch.Type = "if"
+				branch.Condition = "!(" + types.ExprString(n.Cond) + ")"
+				found = true
+			}
+		}
+	}
+	return branch
+}
+
+// 闭包是否直接被 defer 调用，如 defer func() { ... }()
+func isDeferredFuncLit(lit *ast.FuncLit, parent, grandparent ast.Node) bool {
+	call, ok := parent.(*ast.CallExpr)
+	if !ok || call.Fun != lit {
+		return false
+	}
+	deferStmt, ok := grandparent.(*ast.DeferStmt)
+	return ok && deferStmt.Call == call
+}
+
+// 按执行顺序排列响应调用：延迟执行的调用在函数返回前按注册的逆序执行，排在其他响应调用之后
+func orderDeferredResponses(candidates []responseCandidate) []responseCandidate {
+	ordered := make([]responseCandidate, 0, len(candidates))
+	var deferred []responseCandidate
+	for _, candidate := range candidates {
+		if candidate.branch != nil && candidate.branch.Type == "defer" {
+			deferred = append(deferred, candidate)
+			continue
+		}
+		ordered = append(ordered, candidate)
+	}
+	for i := len(deferred) - 1; i >= 0; i-- {
+		ordered = append(ordered, deferred[i])
+	}
+	return ordered
+}
+
+// switch 分支的条件描述，如 role: case "admin", "root"；类型 switch 为 v.(type): case *User
+func caseCondition(clause *ast.CaseClause, parents []ast.Node) string {
+	condition := "default"
+	if clause.List != nil {
+		values := make([]string, 0, len(clause.List))
+		for _, expr := range clause.List {
+			values = append(values, types.ExprString(expr))
+		}
+		condition = "case " + strings.Join(values, ", ")
+	}
+
+	// 分支的上两层为 switch 的主体和 switch 语句
+	if len(parents) < 2 {
+		return condition
+	}
+	switch stmt := parents[len(parents)-2].(type) {
+	case *ast.SwitchStmt:
+		if stmt.Tag != nil {
+			return types.ExprString(stmt.Tag) + ": " + condition
+		}
+	case *ast.TypeSwitchStmt:
+		if subject := typeSwitchSubject(stmt.Assign); subject != nil {
+			return types.ExprString(subject) + ".(type): " + condition
+		}
+	}
+	return condition
+}
+
+// 类型 switch 判断的表达式，如 switch v := x.(type) 中的 x
+func typeSwitchSubject(assign ast.Stmt) ast.Expr {
+	var expr ast.Expr
+	switch s := assign.(type) {
+	case *ast.ExprStmt:
+		expr = s.X
+	case *ast.AssignStmt:
+		if len(s.Rhs) == 1 {
+			expr = s.Rhs[0]
+		}
+	}
+	if assert, ok := expr.(*ast.TypeAssertExpr); ok {
+		return assert.X
+	}
+	return nil
+}
+
+// 返回状态码参数的常量值（如 200、http.StatusOK），非常量时返回0
+func constantStatusCode(expr ast.Expr, typeInfo *types.Info) int {
+	if tv, ok := typeInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.Int {
+		if status, exact := constant.Int64Val(tv.Value); exact {
+			return int(status)
+		}
+	}
+	return 0
+}
+
+// 响应封装函数调用的状态码：封装函数内部各 c.JSON 调用的状态码为常量，或为封装函数的参数且调用处传入常量时，
+// 所有调用的状态码相同则返回该状态码；存在无法静态确定或不同的状态码时返回0
+func (engine *ResponseParsingEngine) wrapperStatusCode(callExpr *ast.CallExpr, pkg *packages.Package) int {
+	wrapper := engine.globalMappings.ResponseWrappers[engine.getFunctionObject(callExpr, pkg)]
+	if wrapper == nil || wrapper.FuncDecl == nil || wrapper.FuncDecl.Body == nil {
+		return 0
+	}
+	typeInfo := wrapper.Package.TypesInfo
+
+	status := 0
+	resolved := true
+	ast.Inspect(wrapper.FuncDecl.Body, func(node ast.Node) bool {
+		jsonCall, ok := node.(*ast.CallExpr)
+		if !resolved || !ok || !engine.isGinJSONCall(jsonCall, wrapper.Package) || len(jsonCall.Args) < 2 {
+			return resolved
+		}
+		callStatus := constantStatusCode(jsonCall.Args[0], typeInfo)
+		if ident, ok := jsonCall.Args[0].(*ast.Ident); ok && callStatus == 0 {
+			// 状态码为封装函数的参数，如 func Error(c *gin.Context, status int, msg string) 中的 status
+			if idx := paramIndex(wrapper.FuncDecl, typeInfo.ObjectOf(ident), typeInfo); idx >= 0 && idx < len(callExpr.Args) {
+				callStatus = constantStatusCode(callExpr.Args[idx], pkg.TypesInfo)
+			}
+		}
+		if callStatus == 0 || (status != 0 && callStatus != status) {
+			resolved = false
+			return false
+		}
+		status = callStatus
+		return true
+	})
+	if !resolved {
+		return 0
+	}
+	return status
+}
+
+// 对象在函数参数列表中的位置，不是函数参数时返回-1
+func paramIndex(funcDecl *ast.FuncDecl, obj types.Object, typeInfo *types.Info) int {
+	if obj == nil || funcDecl.Type.Params == nil {
+		return -1
+	}
+	idx := 0
+	for _, field := range funcDecl.Type.Params.List {
+		if len(field.Names) == 0 {
+			idx++
+			continue
+		}
+		for _, name := range field.Names {
+			if typeInfo.ObjectOf(name) == obj {
+				return idx
+			}
+			idx++
+		}
+	}
+	return -1
+}
+
+// 合并成功分支（2xx或状态码未知）的响应结构
+// 没有成功分支时沿用最后一个响应表达式
+func (engine *ResponseParsingEngine) mergeSuccessResponses(candidates []responseCandidate, resolve func(ast.Expr) *APISchema) *APISchema {
+	resolveCandidate := func(candidate responseCandidate) *APISchema {
+		if candidate.schema != nil {
+			return candidate.schema
+		}
+		return withContentType(resolve(candidate.expr), candidate.contentType)
+	}
+
+	var schemas []*APISchema
+	for _, candidate := range candidates {
+		if candidate.status == 0 || (candidate.status >= 200 && candidate.status < 300) {
+			schemas = append(schemas, resolveCandidate(candidate))
+		}
+	}
+	if len(schemas) == 0 {
+		return resolveCandidate(candidates[len(candidates)-1])
+	}
+	return mergeBranchSchemas(schemas)
+}
+
+// 成功分支共同的状态码（如都为 c.JSON(http.StatusCreated, ...) 时返回201）
+// 各分支状态码不同或存在无法静态确定的分支时返回0
+func successStatusCode(candidates []responseCandidate) int {
+	status := 0
+	for _, candidate := range candidates {
+		if candidate.status != 0 && (candidate.status < 200 || candidate.status >= 300) {
+			continue
+		}
+		if candidate.status == 0 || (status != 0 && candidate.status != status) {
+			return 0
+		}
+		status = candidate.status
+	}
+	return status
+}
+
+// 合并不同分支返回的响应结构：
+// 结构相同时直接使用；同一类型的对象（如都是 gin.H 或同一响应结构体）按字段合并，取值不同的字段生成 OneOf；
+// 其他情况在顶层生成 OneOf
+func mergeBranchSchemas(schemas []*APISchema) *APISchema {
+	variants := distinctSchemas(schemas)
+	if len(variants) == 1 {
+		return variants[0]
+	}
+
+	sameObjectType := true
+	for _, variant := range variants {
+		if len(variant.Properties) == 0 || variant.Type != variants[0].Type || len(variant.OneOf) > 0 {
+			sameObjectType = false
+			break
+		}
+	}
+	if !sameObjectType {
+		return &APISchema{Type: "object", Description: "不同分支返回不同结构", OneOf: variants}
+	}
+
+	// 按字段合并，保留字段在各分支中的不同取值
+	fieldValues := make(map[string][]*APISchema)
+	var fieldNames []string
+	for _, variant := range variants {
+		for name, prop := range variant.Properties {
+			if _, exists := fieldValues[name]; !exists {
+				fieldNames = append(fieldNames, name)
+			}
+			fieldValues[name] = append(fieldValues[name], prop)
+		}
+	}
+
+	merged := &APISchema{
+		Type:        variants[0].Type,
+		Description: variants[0].Description,
+		Package:     variants[0].Package,
+		Properties:  make(map[string]*APISchema),
+	}
+	for _, name := range fieldNames {
+		values := distinctSchemas(fieldValues[name])
+		if len(values) == 1 {
+			merged.Properties[name] = values[0]
+			continue
+		}
+		merged.Properties[name] = &APISchema{
+			Type:        "object",
+			Description: "不同分支返回不同结构",
+			JSONTag:     values[0].JSONTag,
+			OneOf:       values,
+		}
+	}
+	return merged
+}
+
+// 去除重复的结构；存在具体结构时忽略 unknown/any（如错误分支中的 nil）
+func distinctSchemas(schemas []*APISchema) []*APISchema {
+	var specific, vague []*APISchema
+	for _, schema := range schemas {
+		if schema == nil {
+			continue
+		}
+		if schema.Type == "unknown" || schema.Type == "any" || schema.Type == "" {
+			vague = appendDistinctSchema(vague, schema)
+		} else {
+			specific = appendDistinctSchema(specific, schema)
+		}
+	}
+	if len(specific) > 0 {
+		return specific
+	}
+	if len(vague) > 0 {
+		return vague[:1]
+	}
+	return []*APISchema{{Type: "unknown", Description: "no response schema"}}
+}
+
+// 追加不重复的结构
+func appendDistinctSchema(schemas []*APISchema, schema *APISchema) []*APISchema {
+	for _, existing := range schemas {
+		if reflect.DeepEqual(existing, schema) {
+			return schemas
 		}
 	}
-	return results
+	return append(schemas, schema)
 }
 
-// 检查是否是 Gin Handler
-func (a *GinHandlerAnalyzer) isGinHandler(funcDecl *ast.FuncDecl, info *types.Info) bool {
-	if len(funcDecl.Type.Params.List) != 1 {
+// 检查是否为 json.NewEncoder(w).Encode(x) 调用（标准库处理函数的JSON响应）
+func (engine *ResponseParsingEngine) isJSONEncoderCall(callExpr *ast.CallExpr, pkg *packages.Package) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Encode" || len(callExpr.Args) != 1 {
 		return false
 	}
 
-	param := funcDecl.Type.Params.List[0]
-	if paramType := info.TypeOf(param.Type); paramType != nil {
-		typeStr := paramType.String()
-		return strings.Contains(typeStr, "gin.Context")
+	encoderCall, ok := selExpr.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	encoderSel, ok := encoderCall.Fun.(*ast.SelectorExpr)
+	if !ok || encoderSel.Sel.Name != "NewEncoder" {
+		return false
+	}
+	if ident, ok := encoderSel.X.(*ast.Ident); ok {
+		if pkgName, ok := pkg.TypesInfo.ObjectOf(ident).(*types.PkgName); ok {
+			return pkgName.Imported().Path() == "encoding/json"
+		}
 	}
 	return false
 }
 
-// 完整分析Handler（包含请求参数和响应）
-func (engine *ResponseParsingEngine) AnalyzeHandlerComplete(handlerDecl *ast.FuncDecl, pkg *packages.Package) *HandlerAnalysisResult {
-	result := &HandlerAnalysisResult{
-		PackageName: pkg.Name,
-		PackagePath: pkg.PkgPath,
-		HandlerName: handlerDecl.Name.Name,
-	}
-
-	// 分析请求参数
-	paramAnalyzer := NewRequestParamAnalyzer(engine, pkg)
-	result.RequestParams = paramAnalyzer.AnalyzeHandlerParams(handlerDecl)
+// Beego控制器类型 Controller 所在的包路径（v1 与 v2）
+var beegoControllerPackages = map[string]bool{
+	"github.com/astaxie/beego":                true,
+	"github.com/beego/beego/server/web":       true,
+	"github.com/beego/beego/v2/server/web":    true,
+	"github.com/beego/beego/v2/adapter":       true,
+	"github.com/beego/beego/v2/adapter/beego": true,
+}
 
-	// 分析响应
-	responseExpr := engine.findLastResponseExpression(handlerDecl, pkg)
-	if responseExpr != nil {
-		result.Response = engine.analyzeUnifiedResponseExpression(responseExpr, pkg)
+// 检查是否为 X.Data["json"] = v 形式的赋值（Beego控制器），返回赋值的表达式；
+// 只在分析Beego项目时识别，且 X 的类型必须嵌入了Beego的 Controller
+func (engine *ResponseParsingEngine) beegoJSONDataValue(assignStmt *ast.AssignStmt, pkg *packages.Package) ast.Expr {
+	if !engine.beego || len(assignStmt.Lhs) != 1 || len(assignStmt.Rhs) != 1 {
+		return nil
 	}
 
-	return result
-}
+	indexExpr, ok := assignStmt.Lhs[0].(*ast.IndexExpr)
+	if !ok {
+		return nil
+	}
 
-// 统一分析响应表达式（支持c.JSON第二个参数和响应封装函数调用）
-func (engine *ResponseParsingEngine) analyzeUnifiedResponseExpression(responseExpr ast.Expr, pkg *packages.Package) *APISchema {
-	switch expr := responseExpr.(type) {
-	case *ast.CallExpr:
-		// 响应封装函数调用
-		if engine.isResponseWrapperCall(expr, pkg) {
-			return engine.resolveFunctionCallRecursive(expr, pkg)
-		}
-		// 其他函数调用
-		return engine.resolveFunctionCallRecursive(expr, pkg)
-	case *ast.CompositeLit:
-		// 结构体字面量
-		return engine.resolveCompositeLiteral(expr, pkg)
-	case *ast.Ident:
-		// 变量
-		return engine.resolveIdentifier(expr, pkg)
-	case *ast.SelectorExpr:
-		// 选择器表达式
-		return engine.resolveSelectorExpr(expr, pkg)
-	default:
-		// 使用通用的类型解析
-		if exprType := pkg.TypesInfo.TypeOf(responseExpr); exprType != nil {
-			return engine.resolveType(exprType, engine.maxDepth)
-		}
-		return &APISchema{
-			Type:        "unknown",
-			Description: fmt.Sprintf("unsupported expression type: %T", responseExpr),
-		}
+	selExpr, ok := indexExpr.X.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Data" {
+		return nil
 	}
-}
 
-// 查找最后一个响应表达式 (c.JSON 或响应封装函数调用)
-func (engine *ResponseParsingEngine) findLastResponseExpression(handlerDecl *ast.FuncDecl, pkg *packages.Package) ast.Expr {
-	var lastResponseExpr ast.Expr
+	keyLit, ok := indexExpr.Index.(*ast.BasicLit)
+	if !ok || keyLit.Kind != token.STRING || strings.Trim(keyLit.Value, "\"`") != "json" {
+		return nil
+	}
 
-	if handlerDecl.Body == nil {
+	if !embedsBeegoController(pkg.TypesInfo.TypeOf(selExpr.X), engine.maxDepth) {
 		return nil
 	}
+	return assignStmt.Rhs[0]
+}
 
-	ast.Inspect(handlerDecl.Body, func(node ast.Node) bool {
-		if callExpr, ok := node.(*ast.CallExpr); ok {
-			// 检查是否为c.JSON调用
-			if engine.isGinJSONCall(callExpr, pkg) {
-				if len(callExpr.Args) >= 2 {
-					lastResponseExpr = callExpr.Args[1]
-					log.Printf("[DEBUG] 找到c.JSON调用，响应表达式类型: %T\n", lastResponseExpr)
-				}
-			} else if engine.isResponseWrapperCall(callExpr, pkg) {
-				// 检查是否为响应封装函数调用
-				lastResponseExpr = callExpr
-				log.Printf("[DEBUG] 找到响应封装函数调用: %T\n", lastResponseExpr)
-			}
+// 检查类型（或其指针指向的类型）是否为Beego的 Controller，或直接、间接嵌入了它
+func embedsBeegoController(typ types.Type, depth int) bool {
+	if typ == nil || depth <= 0 {
+		return false
+	}
+	typ = unaliasType(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = unaliasType(ptr.Elem())
+	}
+	if named, ok := typ.(*types.Named); ok {
+		if obj := named.Obj(); obj.Pkg() != nil && obj.Name() == "Controller" && beegoControllerPackages[obj.Pkg().Path()] {
+			return true
 		}
-		return true
-	})
-
-	return lastResponseExpr
+	}
+	structType, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < structType.NumFields(); i++ {
+		if field := structType.Field(i); field.Anonymous() && embedsBeegoController(field.Type(), depth-1) {
+			return true
+		}
+	}
+	return false
 }
 
 // 检查是否为响应封装函数调用
@@ -1868,6 +3988,16 @@ func (analyzer *RequestParamAnalyzer) AnalyzeHandlerParams(handlerDecl *ast.Func
 			if bodyParams := analyzer.analyzeBodyParams(callExpr); len(bodyParams) > 0 {
 				params = append(params, bodyParams...)
 			}
+
+			// 分析路径参数
+			if param := analyzer.analyzePathParam(callExpr); param != nil {
+				params = append(params, *param)
+			}
+
+			// 分析 Iris 的请求读取调用（ctx.ReadJSON、ctx.URLParam 等）
+			if irisParams := analyzer.analyzeIrisParams(callExpr); len(irisParams) > 0 {
+				params = append(params, irisParams...)
+			}
 		}
 		return true
 	})
@@ -1892,7 +4022,13 @@ func (analyzer *RequestParamAnalyzer) analyzeQueryParams(callExpr *ast.CallExpr)
 			params = append(params, *param)
 		}
 	case "ShouldBindQuery":
-		// c.ShouldBindQuery(&struct{}) -> struct type
+		// c.ShouldBindQuery(&struct{}) -> 按 form 标签（或配置的查询标签）展开为各个查询参数
+		if len(callExpr.Args) > 0 {
+			if fieldParams := analyzer.expandStructParams(callExpr.Args[0], "query", analyzer.engine.queryTagKeys(), "c.ShouldBindQuery"); fieldParams != nil {
+				params = append(params, fieldParams...)
+				break
+			}
+		}
 		if param := analyzer.analyzeShouldBindQueryCall(callExpr); param != nil {
 			params = append(params, *param)
 		}
@@ -1924,20 +4060,44 @@ func (analyzer *RequestParamAnalyzer) analyzeBodyParams(callExpr *ast.CallExpr)
 	case "ShouldBindJSON":
 		// c.ShouldBindJSON(&struct{}) -> struct type
 		if param := analyzer.analyzeShouldBindJSONCall(callExpr); param != nil {
+			param.ContentType = contentTypeJSON
 			params = append(params, *param)
 		}
+	case "BindJSON", "ShouldBindXML", "BindXML",
+		"ShouldBindBodyWithJSON", "ShouldBindBodyWithXML", "ShouldBindBodyWithYAML", "ShouldBindBodyWithTOML":
+		// c.BindJSON(&struct{}) / c.ShouldBindXML(&struct{}) / c.ShouldBindBodyWithJSON(&struct{}) -> struct type
+		if param := analyzer.analyzeBodyBindCall(callExpr, methodName); param != nil {
+			param.ContentType = bindMethodContentTypes[methodName]
+			params = append(params, *param)
+		}
+	case "ShouldBindWith", "ShouldBindBodyWith":
+		// c.ShouldBindWith(&struct{}, binding.Form) / c.ShouldBindBodyWith(&struct{}, binding.JSON) -> 按绑定器确定格式
+		if contentType := analyzer.bindingContentType(callExpr); contentType != "" {
+			if param := analyzer.analyzeBodyBindCall(callExpr, methodName); param != nil {
+				param.ContentType = contentType
+				params = append(params, *param)
+			}
+		}
 	case "Bind":
 		// c.Bind(&struct{}) -> struct type
 		if param := analyzer.analyzeBindCall(callExpr); param != nil {
+			param.ContentType = analyzer.inferBindContentType(callExpr.Args[0])
 			params = append(params, *param)
 		}
 	case "ShouldBind":
 		// c.ShouldBind(&struct{}) -> struct type (supports multiple formats)
 		if param := analyzer.analyzeShouldBindCall(callExpr); param != nil {
+			param.ContentType = analyzer.inferBindContentType(callExpr.Args[0])
 			params = append(params, *param)
 		}
 	case "ShouldBindUri":
-		// c.ShouldBindUri(&struct{}) -> URI parameters
+		// c.ShouldBindUri(&struct{}) -> 按 uri 标签展开为各个路径参数
+		if len(callExpr.Args) > 0 {
+			if fieldParams := analyzer.expandStructParams(callExpr.Args[0], "path", []string{"uri"}, "c.ShouldBindUri"); fieldParams != nil {
+				params = append(params, fieldParams...)
+				break
+			}
+		}
 		if param := analyzer.analyzeShouldBindUriCall(callExpr); param != nil {
 			params = append(params, *param)
 		}
@@ -1959,6 +4119,84 @@ func (analyzer *RequestParamAnalyzer) isGinContextCall(callExpr *ast.CallExpr) b
 	return false
 }
 
+// 检查是否为 Iris Context 上的调用（iris.Context 为 *context.Context 的别名）
+func (analyzer *RequestParamAnalyzer) isIrisContextCall(callExpr *ast.CallExpr) bool {
+	selector, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	typ := analyzer.typeInfo.TypeOf(selector.X)
+	return typ != nil && IsIrisContextType(typ)
+}
+
+// Iris 读取请求体的方法对应的请求体格式
+var irisReadContentTypes = map[string]string{
+	"ReadJSON": contentTypeJSON,
+	"ReadXML":  contentTypeXML,
+	"ReadYAML": contentTypeYAML,
+	"ReadForm": contentTypeForm,
+}
+
+// Iris 读取查询参数的方法对应的参数类型
+var irisURLParamTypes = map[string]string{
+	"URLParam":           "string",
+	"URLParamTrim":       "string",
+	"URLParamDefault":    "string",
+	"URLParamInt":        "integer",
+	"URLParamIntDefault": "integer",
+	"URLParamInt64":      "integer",
+	"URLParamFloat64":    "number",
+	"URLParamBool":       "boolean",
+}
+
+// 分析 Iris 的请求读取调用，结构体与 gin 的绑定调用使用同一套类型解析（json 标签、必填、注释等）：
+// ctx.ReadJSON(&req) 等为请求体，ctx.ReadQuery(&req) 按 url 标签展开为查询参数，ctx.URLParam("key") 等为单个查询参数
+func (analyzer *RequestParamAnalyzer) analyzeIrisParams(callExpr *ast.CallExpr) []RequestParamInfo {
+	if len(callExpr.Args) < 1 || !analyzer.isIrisContextCall(callExpr) {
+		return nil
+	}
+
+	methodName := analyzer.getMethodName(callExpr)
+	source := "ctx." + methodName
+	switch {
+	case methodName == "ReadBody":
+		// ctx.ReadBody(&req) 按请求的 Content-Type 选择格式，与 c.ShouldBind 相同按结构体声明推断
+		if schema := analyzer.extractStructSchemaFromArg(callExpr.Args[0]); schema != nil {
+			return []RequestParamInfo{{
+				ParamType:   "body",
+				ParamName:   "request_body",
+				ParamSchema: schema,
+				IsRequired:  true,
+				Source:      source,
+				ContentType: analyzer.inferBindContentType(callExpr.Args[0]),
+			}}
+		}
+	case irisReadContentTypes[methodName] != "":
+		if schema := analyzer.extractStructSchemaFromArg(callExpr.Args[0]); schema != nil {
+			return []RequestParamInfo{{
+				ParamType:   "body",
+				ParamName:   "request_body",
+				ParamSchema: schema,
+				IsRequired:  true,
+				Source:      source,
+				ContentType: irisReadContentTypes[methodName],
+			}}
+		}
+	case methodName == "ReadQuery":
+		return analyzer.expandStructParams(callExpr.Args[0], "query", []string{"url"}, source)
+	case irisURLParamTypes[methodName] != "":
+		if paramName := analyzer.extractStringFromExpr(callExpr.Args[0]); paramName != "" {
+			return []RequestParamInfo{{
+				ParamType:   "query",
+				ParamName:   paramName,
+				ParamSchema: &APISchema{Type: irisURLParamTypes[methodName], Description: "Query parameter from " + source + "()"},
+				Source:      source,
+			}}
+		}
+	}
+	return nil
+}
+
 // 获取方法名
 func (analyzer *RequestParamAnalyzer) getMethodName(callExpr *ast.CallExpr) string {
 	if selector, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
@@ -2121,6 +4359,139 @@ func (analyzer *RequestParamAnalyzer) analyzeShouldBindCall(callExpr *ast.CallEx
 	}
 }
 
+// 分析其他请求体绑定调用（c.BindJSON、c.ShouldBindXML、c.ShouldBindWith 等）
+func (analyzer *RequestParamAnalyzer) analyzeBodyBindCall(callExpr *ast.CallExpr, methodName string) *RequestParamInfo {
+	if len(callExpr.Args) < 1 {
+		return nil
+	}
+
+	schema := analyzer.extractStructSchemaFromArg(callExpr.Args[0])
+	if schema == nil {
+		return nil
+	}
+
+	return &RequestParamInfo{
+		ParamType:   "body",
+		ParamName:   "request_body",
+		ParamSchema: schema,
+		IsRequired:  true,
+		Source:      "c." + methodName,
+	}
+}
+
+// 获取 c.ShouldBindWith 第二个参数（binding.JSON、binding.Form 等）对应的请求体格式
+// 非请求体绑定器（binding.Query、binding.Uri 等）返回空字符串
+func (analyzer *RequestParamAnalyzer) bindingContentType(callExpr *ast.CallExpr) string {
+	if len(callExpr.Args) < 2 {
+		return ""
+	}
+	selector, ok := callExpr.Args[1].(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	return bindingContentTypes[selector.Sel.Name]
+}
+
+// 推断 c.Bind/c.ShouldBind 的请求体格式
+// gin 运行时按请求的 Content-Type 选择绑定器，这里根据结构体声明推断：
+// 含文件字段时为 multipart，仅使用 form 标签时为表单，否则为 JSON
+func (analyzer *RequestParamAnalyzer) inferBindContentType(arg ast.Expr) string {
+	typ := analyzer.typeInfo.TypeOf(arg)
+	if typ == nil {
+		return contentTypeJSON
+	}
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	structType, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return contentTypeJSON
+	}
+
+	hasForm, hasJSON := false, false
+	for i := 0; i < structType.NumFields(); i++ {
+		if isMultipartFileType(structType.Field(i).Type()) {
+			return contentTypeMultipart
+		}
+		tag := reflect.StructTag(structType.Tag(i))
+		if _, ok := tag.Lookup("form"); ok {
+			hasForm = true
+		}
+		if _, ok := tag.Lookup("json"); ok {
+			hasJSON = true
+		}
+	}
+	if hasForm && !hasJSON {
+		return contentTypeForm
+	}
+	return contentTypeJSON
+}
+
+// 检查是否为上传文件类型（*multipart.FileHeader 或其切片）
+func isMultipartFileType(typ types.Type) bool {
+	for {
+		switch t := typ.(type) {
+		case *types.Pointer:
+			typ = t.Elem()
+			continue
+		case *types.Slice:
+			typ = t.Elem()
+			continue
+		case *types.Named:
+			obj := t.Obj()
+			return obj.Pkg() != nil && obj.Pkg().Path() == "mime/multipart" && obj.Name() == "FileHeader"
+		}
+		return false
+	}
+}
+
+// 分析路径参数：c.Param("id")、c.Params.ByName("id") 及 c.Params.Get("id")
+func (analyzer *RequestParamAnalyzer) analyzePathParam(callExpr *ast.CallExpr) *RequestParamInfo {
+	if len(callExpr.Args) != 1 {
+		return nil
+	}
+	selector, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	var source string
+	switch {
+	case selector.Sel.Name == "Param" && analyzer.isGinContextCall(callExpr):
+		source = "c.Param"
+	case (selector.Sel.Name == "ByName" || selector.Sel.Name == "Get") && analyzer.isGinParams(selector.X):
+		source = "c.Params." + selector.Sel.Name
+	default:
+		return nil
+	}
+
+	paramName := analyzer.extractStringFromExpr(callExpr.Args[0])
+	if paramName == "" {
+		log.Printf("[DEBUG] 路径参数名无法静态确定: %s\n", source)
+		return nil
+	}
+
+	return &RequestParamInfo{
+		ParamType: "path",
+		ParamName: paramName,
+		ParamSchema: &APISchema{
+			Type:        "string",
+			Description: fmt.Sprintf("Path parameter from %s()", source),
+		},
+		IsRequired: true, // 路径参数总是必需的
+		Source:     source,
+	}
+}
+
+// 检查表达式是否为 gin.Params 类型（c.Params 或赋值后的变量）
+func (analyzer *RequestParamAnalyzer) isGinParams(expr ast.Expr) bool {
+	named, ok := unaliasType(analyzer.typeInfo.TypeOf(expr)).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return IsGinPackagePath(named.Obj().Pkg().Path()) && named.Obj().Name() == "Params"
+}
+
 // 分析c.ShouldBindUri()调用
 func (analyzer *RequestParamAnalyzer) analyzeShouldBindUriCall(callExpr *ast.CallExpr) *RequestParamInfo {
 	if len(callExpr.Args) < 1 {
@@ -2141,12 +4512,98 @@ func (analyzer *RequestParamAnalyzer) analyzeShouldBindUriCall(callExpr *ast.Cal
 	}
 }
 
-// 从表达式中提取字符串字面量
+// 将绑定的结构体（如 c.ShouldBindQuery(&req)）按字段展开为参数：参数名取 tagKey 标签（form、uri），没有标签时使用字段名，
+// 是否必需由字段的 binding:"required" 决定；匿名嵌入的结构体字段一并展开。参数不是结构体时返回nil
+func (analyzer *RequestParamAnalyzer) expandStructParams(arg ast.Expr, paramType string, tagKeys []string, source string) []RequestParamInfo {
+	if unaryExpr, ok := arg.(*ast.UnaryExpr); ok && unaryExpr.Op == token.AND {
+		arg = unaryExpr.X
+	}
+	argType := analyzer.typeInfo.TypeOf(arg)
+	if argType == nil {
+		return nil
+	}
+	argType = unaliasType(argType)
+	if ptr, ok := argType.(*types.Pointer); ok {
+		argType = unaliasType(ptr.Elem())
+	}
+	structType, ok := argType.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	// 字段结构（含注释描述）复用结构体的解析结果，嵌入结构体的字段已提升到外层
+	schema := analyzer.engine.resolveType(argType, analyzer.engine.maxDepth)
+	return analyzer.structFieldParams(structType, schema, paramType, tagKeys, source, analyzer.engine.maxDepth)
+}
+
+// 展开结构体的各个字段为参数
+func (analyzer *RequestParamAnalyzer) structFieldParams(structType *types.Struct, schema *APISchema, paramType string, tagKeys []string, source string, depth int) []RequestParamInfo {
+	params := []RequestParamInfo{}
+	if depth <= 0 {
+		return params
+	}
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		tag := reflect.StructTag(structType.Tag(i))
+		name := structTagName(tag, tagKeys)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous() && name == "" {
+			fieldType := unaliasType(field.Type())
+			if ptr, ok := fieldType.(*types.Pointer); ok {
+				fieldType = unaliasType(ptr.Elem())
+			}
+			if embedded, ok := fieldType.Underlying().(*types.Struct); ok {
+				params = append(params, analyzer.structFieldParams(embedded, schema, paramType, tagKeys, source, depth-1)...)
+				continue
+			}
+		}
+		if !field.Exported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name()
+		}
+
+		var fieldSchema *APISchema
+		if schema != nil {
+			fieldSchema = schema.Properties[field.Name()]
+		}
+		if fieldSchema == nil {
+			fieldSchema = analyzer.engine.resolveType(field.Type(), depth-1)
+			fieldSchema.Default = parseDefaultTag(tag, fieldSchema)
+		}
+		params = append(params, RequestParamInfo{
+			ParamType:   paramType,
+			ParamName:   name,
+			ParamSchema: fieldSchema,
+			IsRequired:  isRequiredField(tag),
+			Source:      source,
+		})
+	}
+	return params
+}
+
+// 按优先级依次查找标签，返回第一个指定了名称的标签中的字段名（可能为 "-"），都未指定时返回空字符串
+func structTagName(tag reflect.StructTag, tagKeys []string) string {
+	for _, key := range tagKeys {
+		if name := strings.Split(tag.Get(key), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// 从表达式中提取字符串字面量或字符串常量（如 const idKey = "id"）
 func (analyzer *RequestParamAnalyzer) extractStringFromExpr(expr ast.Expr) string {
 	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
 		// 移除引号
 		return strings.Trim(lit.Value, `"`)
 	}
+	if tv, ok := analyzer.typeInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value)
+	}
 	return ""
 }
 
@@ -6,9 +6,11 @@ import (
 	"os"
 
 	"github.com/YogeLiu/api-tool/helper"
+	"github.com/YogeLiu/api-tool/pkg/console"
 )
 
 func main() {
+	log.SetOutput(console.Writer(os.Stderr))
 	if len(os.Args) < 2 {
 		fmt.Println("用法: go run main.go <项目目录>")
 		fmt.Println("示例: go run main.go ./my-gin-project")
@@ -17,11 +19,11 @@ func main() {
 
 	projectDir := os.Args[1]
 	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
-		fmt.Printf("❌ 目录不存在: %s\n", projectDir)
+		console.Printf("❌ 目录不存在: %s\n", projectDir)
 		os.Exit(1)
 	}
 
-	fmt.Printf("🔍 开始解析项目: %s\n", projectDir)
+	console.Printf("🔍 开始解析项目: %s\n", projectDir)
 
 	analyzer, err := helper.NewGinHandlerAnalyzer(projectDir)
 	if err != nil {
@@ -29,5 +31,5 @@ func main() {
 	}
 
 	analyzer.Analyze()
-	fmt.Println("\n✅ 解析完成")
+	console.Println("\n✅ 解析完成")
 }
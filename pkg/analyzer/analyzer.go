@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"go/ast"
 	"log"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"go/token"
 	"go/types"
 
 	"path/filepath"
@@ -15,16 +19,38 @@ import (
 	"github.com/YogeLiu/api-tool/pkg/extractor"
 	"github.com/YogeLiu/api-tool/pkg/models"
 	"github.com/YogeLiu/api-tool/pkg/parser"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
 )
 
 // Analyzer 核心分析器，执行与框架无关的业务逻辑分析
 type Analyzer struct {
+	dir                   string // 项目根目录
 	project               *parser.Project
 	extractor             extractor.Extractor
 	routeCache            map[string]bool                        // 路由去重映射
 	routerGroupFunctions  map[string]*models.RouterGroupFunction // 路由分组函数索引
 	responseParsingEngine *helper.ResponseParsingEngine
+	options               Options
+	timings               []models.PhaseTiming // 各分析阶段的耗时
+	warnings              []string             // 分析过程中的警告，随结果返回
+	routeCount            int                  // 已发现的路由数量，用于 MaxRoutes 检查
+	routeLimitHit         bool                 // 路由数量超过 MaxRoutes，后续解析直接返回
+	gitHistory            *gitHistory          // 开启 GitSince 且项目在git仓库中时查询处理函数的引入时间
+}
+
+// Options 分析器配置
+type Options struct {
+	ExportedOnly bool                          // 仅包含导出的处理函数，跳过未导出函数和匿名函数
+	TypeMappings map[string]helper.TypeMapping // 自定义类型映射：包路径.类型名 → schema类型和格式
+	Annotations  bool                          // 解析处理函数注释中的 swaggo 风格注解 (@Summary、@Param 等) 并与推断结果合并
+
+	SchemaOverrides map[string]*helper.APISchema // 手写的类型schema：包路径.类型名 → 完整结构，优先于类型推断
+	SourceLocation  bool                         // 在路由信息中输出处理函数所在的源文件，便于IDE跳转
+	QueryTags       []string                     // 查询参数结构体字段命名使用的标签，按优先级排列，为空时使用 form
+	MaxRoutes       int                          // 路由数量上限，超过时停止分析并返回错误，防止异常输入导致递归解析失控；0 表示不限制
+	Responders      []helper.Responder           // 存放在请求上下文中的响应器，如 resp := FromCtx(c); resp.OK(data)
+	GitSince        bool                         // 通过 git log -L 查询处理函数的引入日期，标注为路由的 since
 }
 
 // RouteContext 路由解析上下文
@@ -33,6 +59,9 @@ type RouteContext struct {
 	RouterObject   types.Object      // 当前路由器对象
 	VisitedFuncs   map[string]bool   // 已访问的函数，防止循环调用
 	CallingPackage *packages.Package // 调用的包
+	Middlewares    []string          // 外层路由分组注册的中间件，如 r.Group("/admin", AuthMW)
+	ReturnTarget   types.Object      // 路由分组函数的返回值在调用处赋给的变量，如 v4 := newV4(v2) 中的 v4
+	Server         string            // 路由所属的根路由器名称，仅存在多个根路由器时设置
 }
 
 // HandlerInfo 处理函数信息
@@ -41,20 +70,55 @@ type HandlerInfo struct {
 	PackageName string            // 函数所在包名
 	PackagePath string            // 函数所在包路径
 	Package     *packages.Package // 函数所在包
+	Adapter     string            // 适配器名称，如 gin.WrapF（标准库处理函数经适配器注册时设置）
+	Anonymous   bool              // 是否为匿名函数
 }
 
 // NewAnalyzer 创建新的分析器实例
 func NewAnalyzer(dir string, proj *parser.Project, ext extractor.Extractor) *Analyzer {
 	// 使用现有的包信息创建响应解析引擎，避免重复加载包
+	start := time.Now()
 	responseParsingEngine := helper.NewResponseParsingEngine(proj.Packages)
+	// this.Data["json"] = v 形式的响应只在Beego控制器中识别
+	responseParsingEngine.SetBeego(ext.GetFrameworkName() == "beego")
 
-	return &Analyzer{
+	a := &Analyzer{
+		dir:                   dir,
 		project:               proj,
 		extractor:             ext,
 		routeCache:            make(map[string]bool),
 		routerGroupFunctions:  make(map[string]*models.RouterGroupFunction),
 		responseParsingEngine: responseParsingEngine,
 	}
+	a.recordTiming("preprocess", start, len(proj.Packages))
+	return a
+}
+
+// SetOptions 设置分析器配置
+func (a *Analyzer) SetOptions(options Options) {
+	a.options = options
+	a.responseParsingEngine.SetTypeMappings(options.TypeMappings)
+	a.responseParsingEngine.SetSchemaOverrides(options.SchemaOverrides)
+	a.responseParsingEngine.SetQueryTags(options.QueryTags)
+	for _, accessor := range a.responseParsingEngine.SetResponders(options.Responders) {
+		a.warnings = append(a.warnings, fmt.Sprintf("未找到响应器获取函数 %s", accessor))
+	}
+	a.gitHistory = nil
+	if options.GitSince {
+		if a.gitHistory = newGitHistory(a.dir); a.gitHistory == nil {
+			a.warnings = append(a.warnings, "项目目录不在git仓库中，已跳过接口引入日期 (since) 的标注")
+		}
+	}
+}
+
+// Timings 返回各分析阶段（全局预处理、索引路由分组函数、递归解析路由）的耗时及处理数量
+func (a *Analyzer) Timings() []models.PhaseTiming {
+	return a.timings
+}
+
+// recordTiming 记录从 start 开始的阶段耗时
+func (a *Analyzer) recordTiming(phase string, start time.Time, count int) {
+	a.timings = append(a.timings, models.PhaseTiming{Phase: phase, Duration: time.Since(start), Count: count})
 }
 
 // Analyze 执行主分析流程
@@ -72,7 +136,9 @@ func (a *Analyzer) Analyze() (*models.APIInfo, error) {
 
 	// 第一阶段：扫描并索引所有路由分组函数
 	log.Printf("[DEBUG] === 第一阶段：索引路由分组函数 ===\n")
+	start := time.Now()
 	a.routerGroupFunctions = a.extractor.FindRouterGroupFunctions(a.project.Packages)
+	a.recordTiming("index", start, len(a.routerGroupFunctions))
 	log.Printf("[DEBUG] 索引完成，找到 %d 个路由分组函数:\n", len(a.routerGroupFunctions))
 	for key := range a.routerGroupFunctions {
 		log.Printf("[DEBUG]   - %s\n", key)
@@ -80,8 +146,16 @@ func (a *Analyzer) Analyze() (*models.APIInfo, error) {
 
 	// 第二阶段：从根路由开始递归解析
 	log.Printf("[DEBUG] === 第二阶段：递归解析路由 ===\n")
+	start = time.Now()
 	rootRouters := a.extractor.FindRootRouters(a.project.Packages)
-	if len(rootRouters) == 0 {
+
+	// 对于通过包级函数注册路由的框架（如Beego），直接收集路由注册
+	var registrations []*models.RouteRegistration
+	if regExtractor, ok := a.extractor.(extractor.RouteRegistrationExtractor); ok {
+		registrations = regExtractor.FindRouteRegistrations(a.project.Packages)
+	}
+
+	if len(rootRouters) == 0 && len(registrations) == 0 {
 		return nil, &models.AnalysisError{
 			Context: "查找根路由器",
 			Reason:  fmt.Sprintf("未找到 %s 框架的根路由器", a.extractor.GetFrameworkName()),
@@ -90,7 +164,34 @@ func (a *Analyzer) Analyze() (*models.APIInfo, error) {
 
 	routes := make(map[string]models.RouteInfo)
 
+	for _, reg := range registrations {
+		if a.routeLimitHit {
+			break
+		}
+		if reg.FuncDecl == nil || reg.Package == nil {
+			continue
+		}
+		handlerInfo := &HandlerInfo{
+			FuncDecl:    reg.FuncDecl,
+			PackageName: reg.Package.Name,
+			PackagePath: reg.Package.PkgPath,
+			Package:     reg.Package,
+		}
+		if a.shouldSkipHandler(handlerInfo) {
+			continue
+		}
+		route := a.buildRouteInfo(handlerInfo, reg.Method, reg.Path)
+		if reg.HandlerName != "" {
+			route.Handler = reg.HandlerName
+		}
+		uniqueKey := routeUniqueKey(route)
+		routes[uniqueKey] = *route
+		a.countRoute()
+		log.Printf("[DEBUG] 添加注册路由: %s %s -> %s (包: %s)\n", route.Method, route.Path, route.Handler, route.PackagePath)
+	}
+
 	// 为每个根路由器开始递归解析
+	serverNames := rootRouterNames(rootRouters)
 	for _, rootRouter := range rootRouters {
 		log.Printf("[DEBUG] 开始分析根路由器: %s\n", rootRouter.Name())
 		context := &RouteContext{
@@ -98,6 +199,7 @@ func (a *Analyzer) Analyze() (*models.APIInfo, error) {
 			RouterObject:   rootRouter,
 			VisitedFuncs:   make(map[string]bool),
 			CallingPackage: nil, // 根路由器没有调用包
+			Server:         serverNames[rootRouter],
 		}
 
 		foundRoutes := a.analyzeRouterRecursively(context)
@@ -106,22 +208,111 @@ func (a *Analyzer) Analyze() (*models.APIInfo, error) {
 		}
 	}
 
+	if a.routeLimitHit {
+		return nil, &models.AnalysisError{
+			Context: "递归解析路由",
+			Reason:  fmt.Sprintf("路由数量超过上限 %d，已停止分析，请检查路由注册是否存在异常的递归或调高上限", a.options.MaxRoutes),
+		}
+	}
+
 	log.Printf("[DEBUG] 分析完成，总共找到 %d 个路由\n", len(routes))
 
-	// 将 map 转换为 slice
+	// 规范化路径后去重，并将 map 转换为 slice；按键的顺序遍历，重复路由保留的一项不随 map 的遍历顺序变化
+	keys := make([]string, 0, len(routes))
+	for key := range routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 	var routeList []models.RouteInfo
-	for _, route := range routes {
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		route := routes[key]
+		route.Path = normalizePath(route.Path)
+		uniqueKey := routeUniqueKey(&route)
+		if seen[uniqueKey] {
+			continue
+		}
+		seen[uniqueKey] = true
+		a.validatePathParams(&route)
 		routeList = append(routeList, route)
 	}
+	// 路由按固定顺序输出，多次分析（及 -page-size 分页）的结果一致
+	sortRoutes(routeList)
+	a.recordTiming("analyze", start, len(routeList))
 
+	sort.Strings(a.warnings)
 	return &models.APIInfo{
-		Routes: routeList,
+		Routes:   routeList,
+		Warnings: a.warnings,
 	}, nil
 }
 
-// analyzeRouterRecursively 递归解析路由器对象的使用
+// sortRoutes 按路径、方法、所属服务、处理函数及其包路径排序
+func sortRoutes(routes []models.RouteInfo) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := routes[i], routes[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Server != b.Server {
+			return a.Server < b.Server
+		}
+		if a.Handler != b.Handler {
+			return a.Handler < b.Handler
+		}
+		return a.PackagePath < b.PackagePath
+	})
+}
+
+// rootRouterNames 存在多个根路由器时为每个根路由器生成名称，用于区分不同服务注册的相同路由：
+// 默认使用变量或字段名，重名时加上包名，仍重名时追加序号；只有一个根路由器时返回空映射
+func rootRouterNames(rootRouters []types.Object) map[types.Object]string {
+	names := make(map[types.Object]string)
+	if len(rootRouters) < 2 {
+		return names
+	}
+
+	counts := make(map[string]int)
+	for _, rootRouter := range rootRouters {
+		counts[rootRouter.Name()]++
+	}
+	used := make(map[string]int)
+	for _, rootRouter := range rootRouters {
+		name := rootRouter.Name()
+		if counts[name] > 1 && rootRouter.Pkg() != nil {
+			name = rootRouter.Pkg().Name() + "." + name
+		}
+		used[name]++
+		if used[name] > 1 {
+			name = fmt.Sprintf("%s#%d", name, used[name])
+		}
+		names[rootRouter] = name
+	}
+	return names
+}
+
+// routeUniqueKey 路由的唯一键：根路由器 + 方法 + 路径 + 处理函数所在包和名称
+func routeUniqueKey(route *models.RouteInfo) string {
+	return fmt.Sprintf("%s:%s:%s:%s.%s", route.Server, route.Method, route.Path, route.PackagePath, route.Handler)
+}
+
+// countRoute 记录新发现的路由，数量超过 MaxRoutes 时标记超限
+func (a *Analyzer) countRoute() {
+	a.routeCount++
+	if a.options.MaxRoutes > 0 && a.routeCount > a.options.MaxRoutes {
+		a.routeLimitHit = true
+	}
+}
+
+// analyzeRouterRecursively 递归解析路由器对象的使用，路由数量超过上限后不再继续
 func (a *Analyzer) analyzeRouterRecursively(context *RouteContext) map[string]models.RouteInfo {
 	var routes []models.RouteInfo
+	if a.routeLimitHit {
+		return nil
+	}
 
 	log.Printf("[DEBUG] analyzeRouterRecursively: 分析路由器 %s，当前路径: %s\n",
 		context.RouterObject.Name(), context.ParentPath)
@@ -130,32 +321,22 @@ func (a *Analyzer) analyzeRouterRecursively(context *RouteContext) map[string]mo
 	for _, pkg := range a.project.Packages {
 		for _, file := range pkg.Syntax {
 			ast.Inspect(file, func(node ast.Node) bool {
+				if a.routeLimitHit {
+					return false
+				}
 				if callExpr, ok := node.(*ast.CallExpr); ok {
 					// 检查是否为对当前路由器对象的调用
 					if a.isCallOnRouter(callExpr, context.RouterObject, pkg.TypesInfo) {
-						// 检查是否为路由分组调用
-						if isGroup, pathSegment := a.extractor.IsRouteGroupCall(callExpr, pkg.TypesInfo); isGroup {
-							log.Printf("[DEBUG] 发现路由分组调用: %s\n", pathSegment)
-							newRoutes := a.handleRouteGroupCall(callExpr, context, pathSegment, pkg)
-							routes = append(routes, newRoutes...)
-						} else if isHTTP, method, pathSegment := a.extractor.IsHTTPMethodCall(callExpr, pkg.TypesInfo); isHTTP {
-							log.Printf("[DEBUG] 发现HTTP方法调用: %s %s\n", method, pathSegment)
-							route := a.handleHTTPMethodCall(callExpr, context, method, pathSegment, pkg.TypesInfo)
-							if route != nil {
-								routeKey := fmt.Sprintf("%s:%s:%s", route.Method, route.Path, route.Handler)
-								if !a.routeCache[routeKey] {
-									a.routeCache[routeKey] = true
-									routes = append(routes, *route)
-									log.Printf("[DEBUG] 添加路由: %s %s -> %s (包: %s)\n", route.Method, route.Path, route.Handler, route.PackagePath)
-								}
-							}
-						}
+						routes = append(routes, a.handleRouterCall(callExpr, context, pkg)...)
 					}
 
 					// 检查是否为路由分组函数调用
 					routerGroupRoutes := a.checkRouterGroupFunctionCall(callExpr, context, pkg)
 					routes = append(routes, routerGroupRoutes...)
 				}
+
+				// 检查路由器对象是否赋给了其他变量
+				routes = append(routes, a.handleRouterAlias(node, context, pkg)...)
 				return true
 			})
 		}
@@ -163,9 +344,9 @@ func (a *Analyzer) analyzeRouterRecursively(context *RouteContext) map[string]mo
 
 	ans := make(map[string]models.RouteInfo)
 	for _, route := range routes {
-		// 使用更唯一的Key: Method + Path + PackagePath + Handler
-		// 这样即使相同Handler处理不同路径也不会冲突
-		uniqueKey := fmt.Sprintf("%s:%s:%s.%s", route.Method, route.Path, route.PackagePath, route.Handler)
+		// 使用更唯一的Key: Server + Method + Path + PackagePath + Handler
+		// 这样即使相同Handler处理不同路径、或不同根路由器注册相同路由也不会冲突
+		uniqueKey := routeUniqueKey(&route)
 		ans[uniqueKey] = route
 	}
 
@@ -198,6 +379,9 @@ func (a *Analyzer) checkRouterGroupFunctionCall(callExpr *ast.CallExpr, context
 						RouterObject:   a.getRouterParameterObject(rgf),
 						VisitedFuncs:   a.copyVisitedFuncs(context.VisitedFuncs),
 						CallingPackage: pkg,
+						Middlewares:    context.Middlewares,
+						ReturnTarget:   a.findGroupResultObject(callExpr, pkg),
+						Server:         context.Server,
 					}
 					newContext.VisitedFuncs[funcKey] = true
 
@@ -212,6 +396,43 @@ func (a *Analyzer) checkRouterGroupFunctionCall(callExpr *ast.CallExpr, context
 	return routes
 }
 
+// handleGroupArgument 分组调用的结果作为参数传给路由分组函数时，以分组的路径和中间件解析函数内部的路由；
+// 分组结果须位于函数的路由器参数位置
+func (a *Analyzer) handleGroupArgument(outer, groupCall *ast.CallExpr, context *RouteContext, pkg *packages.Package) []models.RouteInfo {
+	funcKey := a.getFunctionCallKey(outer, pkg)
+	rgf, exists := a.routerGroupFunctions[funcKey]
+	if !exists {
+		log.Printf("[DEBUG] 分组结果传给了非路由分组函数: %s\n", types.ExprString(outer.Fun))
+		return nil
+	}
+	if context.VisitedFuncs[funcKey] {
+		log.Printf("[DEBUG] 检测到循环调用，跳过: %s\n", funcKey)
+		return nil
+	}
+	// RouterParamIdx 为参数列表中字段的索引，同一字段可声明多个参数，如 (a, b int, r *gin.RouterGroup)
+	argIdx := 0
+	for _, field := range rgf.FuncDecl.Type.Params.List[:rgf.RouterParamIdx] {
+		argIdx += max(len(field.Names), 1)
+	}
+	if argIdx >= len(outer.Args) || astutil.Unparen(outer.Args[argIdx]) != groupCall {
+		log.Printf("[DEBUG] 分组结果不在 %s 的路由器参数位置\n", funcKey)
+		return nil
+	}
+
+	log.Printf("[DEBUG] 分组结果作为参数传给路由分组函数: %s\n", funcKey)
+	newContext := &RouteContext{
+		ParentPath:     context.ParentPath,
+		RouterObject:   a.getRouterParameterObject(rgf),
+		VisitedFuncs:   a.copyVisitedFuncs(context.VisitedFuncs),
+		CallingPackage: pkg,
+		Middlewares:    context.Middlewares,
+		ReturnTarget:   a.findGroupResultObject(outer, pkg),
+		Server:         context.Server,
+	}
+	newContext.VisitedFuncs[funcKey] = true
+	return a.analyzeRouterGroupFunction(rgf, newContext)
+}
+
 // analyzeRouterGroupFunction 分析路由分组函数内部的路由定义
 func (a *Analyzer) analyzeRouterGroupFunction(rgf *models.RouterGroupFunction, context *RouteContext) []models.RouteInfo {
 	var routes []models.RouteInfo
@@ -224,29 +445,16 @@ func (a *Analyzer) analyzeRouterGroupFunction(rgf *models.RouterGroupFunction, c
 			if callExpr, ok := node.(*ast.CallExpr); ok {
 				// 检查是否为对路由器参数的调用
 				if a.isCallOnRouter(callExpr, context.RouterObject, rgf.Package.TypesInfo) {
-					// 检查是否为路由分组调用
-					if isGroup, pathSegment := a.extractor.IsRouteGroupCall(callExpr, rgf.Package.TypesInfo); isGroup {
-						log.Printf("[DEBUG] 在路由分组函数中发现子分组: %s\n", pathSegment)
-						newRoutes := a.handleRouteGroupCall(callExpr, context, pathSegment, rgf.Package)
-						routes = append(routes, newRoutes...)
-					} else if isHTTP, method, pathSegment := a.extractor.IsHTTPMethodCall(callExpr, rgf.Package.TypesInfo); isHTTP {
-						log.Printf("[DEBUG] 在路由分组函数中发现HTTP方法: %s %s\n", method, pathSegment)
-						route := a.handleHTTPMethodCall(callExpr, context, method, pathSegment, rgf.Package.TypesInfo)
-						if route != nil {
-							routeKey := fmt.Sprintf("%s:%s:%s", route.Method, route.Path, route.Handler)
-							if !a.routeCache[routeKey] {
-								a.routeCache[routeKey] = true
-								routes = append(routes, *route)
-								log.Printf("[DEBUG] 添加路由: %s %s -> %s\n", route.Method, route.Path, route.Handler)
-							}
-						}
-					}
+					routes = append(routes, a.handleRouterCall(callExpr, context, rgf.Package)...)
 				}
 
 				// 检查嵌套的路由分组函数调用
 				nestedRoutes := a.checkRouterGroupFunctionCall(callExpr, context, rgf.Package)
 				routes = append(routes, nestedRoutes...)
 			}
+
+			// 检查路由器参数是否赋给了其他变量或作为返回值
+			routes = append(routes, a.handleRouterAlias(node, context, rgf.Package)...)
 			return true
 		})
 	}
@@ -254,6 +462,34 @@ func (a *Analyzer) analyzeRouterGroupFunction(rgf *models.RouterGroupFunction, c
 	return routes
 }
 
+// handleRouterCall 处理路由器对象上的调用：路由分组、HTTP方法或按路由表注册
+func (a *Analyzer) handleRouterCall(callExpr *ast.CallExpr, context *RouteContext, pkg *packages.Package) []models.RouteInfo {
+	// 检查是否为路由分组调用
+	if isGroup, pathSegment := a.extractor.IsRouteGroupCall(callExpr, pkg.TypesInfo); isGroup {
+		log.Printf("[DEBUG] 发现路由分组调用: %s\n", pathSegment)
+		return a.handleRouteGroupCall(callExpr, context, pathSegment, pkg)
+	}
+
+	if isHTTP, method, pathSegment := a.extractor.IsHTTPMethodCall(callExpr, pkg.TypesInfo); isHTTP {
+		log.Printf("[DEBUG] 发现HTTP方法调用: %s %s\n", method, pathSegment)
+		route := a.handleHTTPMethodCall(callExpr, context, method, pathSegment, pkg.TypesInfo)
+		if route == nil {
+			return nil
+		}
+		route.Server = context.Server
+		routeKey := fmt.Sprintf("%s:%s:%s:%s", route.Server, route.Method, route.Path, route.Handler)
+		if a.routeCache[routeKey] {
+			return nil
+		}
+		a.routeCache[routeKey] = true
+		a.countRoute()
+		log.Printf("[DEBUG] 添加路由: %s %s -> %s (包: %s)\n", route.Method, route.Path, route.Handler, route.PackagePath)
+		return []models.RouteInfo{*route}
+	}
+
+	return a.handleRouteTableCall(callExpr, context, pkg.TypesInfo)
+}
+
 // handleRouteGroupCall 处理路由分组调用
 func (a *Analyzer) handleRouteGroupCall(callExpr *ast.CallExpr, context *RouteContext, pathSegment string, pkg *packages.Package) []models.RouteInfo {
 	var routes []models.RouteInfo
@@ -262,21 +498,44 @@ func (a *Analyzer) handleRouteGroupCall(callExpr *ast.CallExpr, context *RouteCo
 	newPath := a.combinePaths(context.ParentPath, pathSegment)
 	log.Printf("[DEBUG] handleRouteGroupCall: 新路径 %s\n", newPath)
 
-	// 查找分组调用的结果对象
-	groupObj := a.findGroupResultObject(callExpr, pkg)
-	if groupObj == nil {
-		log.Printf("[DEBUG] 未找到分组结果对象\n")
-		return routes
+	// 路径之后的参数为分组注册的中间件
+	var middlewareArgs []ast.Expr
+	if len(callExpr.Args) > 1 {
+		middlewareArgs = callExpr.Args[1:]
 	}
 
-	// 创建新的上下文继续递归
+	// 创建新的上下文继续递归，分组中的路由继承分组注册的中间件
 	newContext := &RouteContext{
 		ParentPath:     newPath,
-		RouterObject:   groupObj,
 		VisitedFuncs:   context.VisitedFuncs, // 共享访问记录
 		CallingPackage: pkg,
+		Middlewares:    appendMiddlewares(context.Middlewares, middlewareArgs, callExpr.Ellipsis.IsValid()),
+		ReturnTarget:   context.ReturnTarget,
+		Server:         context.Server,
 	}
 
+	// 查找分组调用的结果对象
+	groupObj := a.findGroupResultObject(callExpr, pkg)
+	if groupObj == nil {
+		// 链式调用：直接在分组结果上注册，如 r.Group("/a").GET(...) 或 r.Group("/a").Group("/b")
+		if chained := chainedCall(callExpr, pkg); chained != nil {
+			log.Printf("[DEBUG] 分组结果上的链式调用\n")
+			return a.handleRouterCall(chained, newContext, pkg)
+		}
+		// 分组结果直接作为路由分组函数的参数，如 registerOrders(v1.Group("/orders"))，以分组路径解析函数内部的路由
+		if outer := argumentOfCall(callExpr, pkg); outer != nil {
+			return a.handleGroupArgument(outer, callExpr, newContext, pkg)
+		}
+		// 路由分组函数直接返回分组结果，如 return g.Group("/v4")，继续解析调用处接收返回值的变量
+		if context.ReturnTarget == nil || !isReturnedValue(callExpr, pkg) {
+			log.Printf("[DEBUG] 未找到分组结果对象\n")
+			return routes
+		}
+		groupObj = context.ReturnTarget
+		newContext.ReturnTarget = nil
+	}
+	newContext.RouterObject = groupObj
+
 	nestedRoutes := a.analyzeRouterRecursively(newContext)
 	for _, route := range nestedRoutes {
 		routes = append(routes, route)
@@ -285,6 +544,35 @@ func (a *Analyzer) handleRouteGroupCall(callExpr *ast.CallExpr, context *RouteCo
 	return routes
 }
 
+// appendMiddlewares 在外层中间件之后追加分组调用中路径之后的中间件参数，返回新的切片
+// ellipsis 表示最后一个参数以 mws... 形式展开
+func appendMiddlewares(parent []string, args []ast.Expr, ellipsis bool) []string {
+	if len(args) == 0 {
+		return parent
+	}
+	middlewares := make([]string, 0, len(parent)+len(args))
+	middlewares = append(middlewares, parent...)
+	for i, arg := range args {
+		name := middlewareName(arg)
+		if ellipsis && i == len(args)-1 {
+			name += "..."
+		}
+		middlewares = append(middlewares, name)
+	}
+	return middlewares
+}
+
+// middlewareName 返回中间件表达式的名称：AuthMW、middleware.Auth，工厂调用 middleware.RateLimit(10) 取函数名
+func middlewareName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		return types.ExprString(e.Fun)
+	case *ast.FuncLit:
+		return "anonymous"
+	}
+	return types.ExprString(expr)
+}
+
 // handleHTTPMethodCall 处理HTTP方法调用
 func (a *Analyzer) handleHTTPMethodCall(callExpr *ast.CallExpr, context *RouteContext, method, pathSegment string, typeInfo *types.Info) *models.RouteInfo {
 	// 组合完整路径
@@ -295,15 +583,294 @@ func (a *Analyzer) handleHTTPMethodCall(callExpr *ast.CallExpr, context *RouteCo
 	handlerInfo := a.extractHandlerInfo(callExpr, typeInfo)
 	if handlerInfo == nil || handlerInfo.FuncDecl == nil {
 		log.Printf("[DEBUG] 未找到处理函数\n")
+		if len(callExpr.Args) == 0 {
+			return nil
+		}
+		route := a.unresolvedRoute(callExpr.Args[len(callExpr.Args)-1], method, fullPath, context, typeInfo)
+		route.Middlewares = appendMiddlewares(context.Middlewares, routeMiddlewareArgs(callExpr, typeInfo), false)
+		return route
+	}
+
+	if a.shouldSkipHandler(handlerInfo) {
 		return nil
 	}
 
+	// 最后一个参数为最终处理函数，之前的处理函数参数为路由级中间件，排在分组中间件之后
+	route := a.buildRouteInfo(handlerInfo, method, fullPath)
+	route.Middlewares = appendMiddlewares(context.Middlewares, routeMiddlewareArgs(callExpr, typeInfo), false)
+	return route
+}
+
+// routeMiddlewareArgs 路由注册调用中最终处理函数之前的中间件参数，如 r.GET("/x", mw1, mw2, handler) 中的 mw1、mw2；
+// 方法、路径等字符串参数不计入
+func routeMiddlewareArgs(callExpr *ast.CallExpr, typeInfo *types.Info) []ast.Expr {
+	if len(callExpr.Args) < 2 {
+		return nil
+	}
+	var args []ast.Expr
+	for _, arg := range callExpr.Args[:len(callExpr.Args)-1] {
+		if typ := typeInfo.TypeOf(arg); typ != nil {
+			if basic, ok := typ.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+				continue
+			}
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// handleRouteTableCall 处理循环中按路由表注册的路由（如 r.Handle(rt.Method, rt.Path, rt.Handler)）
+func (a *Analyzer) handleRouteTableCall(callExpr *ast.CallExpr, context *RouteContext, typeInfo *types.Info) []models.RouteInfo {
+	tableExtractor, ok := a.extractor.(extractor.RouteTableExtractor)
+	if !ok {
+		return nil
+	}
+
+	var routes []models.RouteInfo
+	for _, entry := range tableExtractor.ExtractRouteTable(callExpr, typeInfo) {
+		fullPath := a.combinePaths(context.ParentPath, entry.Path)
+
+		handlerInfo := a.extractHandlerInfoFromExpr(entry.Handler, entry.TypeInfo)
+		var route *models.RouteInfo
+		if handlerInfo == nil || handlerInfo.FuncDecl == nil {
+			log.Printf("[DEBUG] 路由表 %s %s 未找到处理函数\n", entry.Method, fullPath)
+			route = a.unresolvedRoute(entry.Handler, entry.Method, fullPath, context, entry.TypeInfo)
+		} else {
+			if a.shouldSkipHandler(handlerInfo) {
+				continue
+			}
+			route = a.buildRouteInfo(handlerInfo, entry.Method, fullPath)
+			route.Middlewares = context.Middlewares
+		}
+		route.Server = context.Server
+		routeKey := fmt.Sprintf("%s:%s:%s:%s", route.Server, route.Method, route.Path, route.Handler)
+		if !a.routeCache[routeKey] {
+			a.routeCache[routeKey] = true
+			a.countRoute()
+			routes = append(routes, *route)
+			log.Printf("[DEBUG] 添加路由表路由: %s %s -> %s\n", route.Method, route.Path, route.Handler)
+		}
+	}
+	return routes
+}
+
+// unresolvedRoute 处理函数无法解析时仍保留路由：以注册时的处理函数表达式作为占位的处理函数名，包为注册路由的包，
+// 并记录警告，避免文档中遗漏接口
+func (a *Analyzer) unresolvedRoute(handlerExpr ast.Expr, method, fullPath string, context *RouteContext, typeInfo *types.Info) *models.RouteInfo {
+	handler := types.ExprString(handlerExpr)
+	a.warnings = append(a.warnings, fmt.Sprintf("%s %s: 无法解析处理函数 %s", method, fullPath, handler))
+	route := &models.RouteInfo{
+		Method:            method,
+		Path:              fullPath,
+		Handler:           handler,
+		HandlerUnresolved: true,
+		Middlewares:       context.Middlewares,
+	}
+	for _, pkg := range a.project.Packages {
+		if pkg.TypesInfo == typeInfo {
+			route.PackageName = pkg.Name
+			route.PackagePath = pkg.PkgPath
+			break
+		}
+	}
+	return route
+}
+
+// relativeSourcePath 返回源文件相对项目根目录的路径（使用 / 分隔），不在项目目录下时返回原路径
+func (a *Analyzer) relativeSourcePath(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	root, err := filepath.Abs(a.dir)
+	if err != nil {
+		return filename
+	}
+	rel, err := filepath.Rel(root, filename)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filename
+	}
+	return filepath.ToSlash(rel)
+}
+
+// isDeprecated 检查处理函数的文档注释中是否有 "Deprecated:" 段落（Go 的弃用约定）
+func isDeprecated(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Doc == nil {
+		return false
+	}
+	for _, line := range strings.Split(funcDecl.Doc.Text(), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "Deprecated:") {
+			return true
+		}
+	}
+	return false
+}
+
+// responseDescription 返回处理函数文档注释中 "Response:" 后的成功响应说明，用于覆盖状态码的默认描述
+func responseDescription(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Doc == nil {
+		return ""
+	}
+	for _, line := range strings.Split(funcDecl.Doc.Text(), "\n") {
+		if desc, ok := strings.CutPrefix(strings.TrimSpace(line), "Response:"); ok {
+			return strings.TrimSpace(desc)
+		}
+	}
+	return ""
+}
+
+// docSummary 从处理函数的文档注释中提取摘要和描述：去掉开头的函数名后，第一句作为摘要，其余部分作为描述。
+// 注解行和 "Deprecated:"、"Response:" 说明不计入，没有文档注释时返回空字符串
+func docSummary(funcDecl *ast.FuncDecl) (string, string) {
+	if funcDecl.Doc == nil {
+		return "", ""
+	}
+	var lines []string
+	for _, line := range strings.Split(funcDecl.Doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "@") || strings.HasPrefix(line, "Deprecated:") || strings.HasPrefix(line, "Response:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	text := strings.TrimSpace(strings.Join(lines, "\n"))
+
+	// Go 文档约定以函数名开头，如 "GetUser 获取用户信息"
+	if rest, ok := strings.CutPrefix(text, funcDecl.Name.Name); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\n') {
+		text = strings.TrimSpace(rest)
+	}
+	if text == "" {
+		return "", ""
+	}
+
+	end := firstSentenceEnd(text)
+	return strings.Join(strings.Fields(text[:end]), " "), strings.TrimSpace(text[end:])
+}
+
+// firstSentenceEnd 返回第一句的结束位置：句末标点（英文标点后需跟空白）或第一段结尾
+func firstSentenceEnd(text string) int {
+	end := len(text)
+	if i := strings.Index(text, "\n\n"); i >= 0 {
+		end = i
+	}
+	for i, r := range text[:end] {
+		switch r {
+		case '。', '！', '？':
+			return i + utf8.RuneLen(r)
+		case '.', '!', '?':
+			if i+1 == end || text[i+1] == ' ' || text[i+1] == '\n' {
+				return i + 1
+			}
+		}
+	}
+	return end
+}
+
+// WebSocket 升级函数 (types.Func.FullName)
+var webSocketUpgradeFuncs = map[string]bool{
+	"(*github.com/gorilla/websocket.Upgrader).Upgrade":  true,
+	"(*github.com/fasthttp/websocket.Upgrader).Upgrade": true,
+	"nhooyr.io/websocket.Accept":                        true,
+	"github.com/coder/websocket.Accept":                 true,
+	"github.com/gobwas/ws.UpgradeHTTP":                  true,
+}
+
+// 重定向函数 (types.Func.FullName)
+var redirectFuncs = map[string]bool{
+	"(*github.com/gin-gonic/gin.Context).Redirect":                     true,
+	"net/http.Redirect":                                                true,
+	"(*github.com/kataras/iris/v12/context.Context).Redirect":          true,
+	"(github.com/kataras/iris/context.Context).Redirect":               true,
+	"(*github.com/beego/beego/v2/server/web.Controller).Redirect":      true,
+	"(*github.com/beego/beego/v2/server/web/context.Context).Redirect": true,
+	"(*github.com/astaxie/beego.Controller).Redirect":                  true,
+	"(*github.com/astaxie/beego/context.Context).Redirect":             true,
+}
+
+// maxWebSocketSearchDepth 查找 WebSocket 升级、重定向调用时跟进项目内函数调用的最大层数
+const maxWebSocketSearchDepth = 3
+
+// isWebSocketHandler 检查处理函数是否将连接升级为 WebSocket（如 upgrader.Upgrade(c.Writer, c.Request, nil)），
+// 升级调用可以在处理函数中，也可以在其调用的项目内函数中
+func (a *Analyzer) isWebSocketHandler(funcDecl *ast.FuncDecl, pkg *packages.Package, depth int) bool {
+	return a.callsFunc(funcDecl, pkg, webSocketUpgradeFuncs, depth)
+}
+
+// callsFunc 检查处理函数或其调用的项目内函数中是否调用了 funcs 中的函数
+func (a *Analyzer) callsFunc(funcDecl *ast.FuncDecl, pkg *packages.Package, funcs map[string]bool, depth int) bool {
+	if funcDecl == nil || funcDecl.Body == nil || pkg == nil || pkg.TypesInfo == nil || depth > maxWebSocketSearchDepth {
+		return false
+	}
+
+	found := false
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		callExpr, ok := node.(*ast.CallExpr)
+		if !ok || found {
+			return !found
+		}
+
+		var ident *ast.Ident
+		switch fun := callExpr.Fun.(type) {
+		case *ast.Ident:
+			ident = fun
+		case *ast.SelectorExpr:
+			ident = fun.Sel
+		default:
+			return true
+		}
+		funcObj, ok := pkg.TypesInfo.ObjectOf(ident).(*types.Func)
+		if !ok || funcObj.Pkg() == nil {
+			return true
+		}
+
+		if funcs[funcObj.FullName()] {
+			found = true
+		} else if calleeDecl, calleePkg := a.findFuncDecl(funcObj); calleeDecl != nil && calleeDecl != funcDecl {
+			found = a.callsFunc(calleeDecl, calleePkg, funcs, depth+1)
+		}
+		return !found
+	})
+	return found
+}
+
+// findFuncDecl 查找项目内函数（包括方法）的声明及其所在包
+func (a *Analyzer) findFuncDecl(funcObj *types.Func) (*ast.FuncDecl, *packages.Package) {
+	pkg := a.findPackageByPath(funcObj.Pkg().Path())
+	if pkg == nil || pkg.TypesInfo == nil {
+		return nil, nil
+	}
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			// 测试版本的包与普通版本的类型对象不同，按声明位置匹配
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && (pkg.TypesInfo.Defs[funcDecl.Name] == funcObj || funcDecl.Name.Pos() == funcObj.Pos()) {
+				return funcDecl, pkg
+			}
+		}
+	}
+	return nil, nil
+}
+
+// shouldSkipHandler 根据配置判断是否跳过处理函数（仅导出模式下跳过未导出函数和匿名函数）
+func (a *Analyzer) shouldSkipHandler(handlerInfo *HandlerInfo) bool {
+	if !a.options.ExportedOnly {
+		return false
+	}
+	if handlerInfo.Anonymous || !ast.IsExported(handlerInfo.FuncDecl.Name.Name) {
+		log.Printf("[DEBUG] 跳过未导出的处理函数: %s\n", handlerInfo.FuncDecl.Name.Name)
+		return true
+	}
+	return false
+}
+
+// buildRouteInfo 根据处理函数信息构建路由信息，并分析请求和响应参数
+func (a *Analyzer) buildRouteInfo(handlerInfo *HandlerInfo, method, fullPath string) *models.RouteInfo {
 	var startLine, endLine int
+	var sourceFile string
 	if handlerInfo.Package != nil && handlerInfo.Package.Fset != nil {
 		startPos := handlerInfo.Package.Fset.Position(handlerInfo.FuncDecl.Pos())
 		endPos := handlerInfo.Package.Fset.Position(handlerInfo.FuncDecl.End())
 		startLine = startPos.Line
 		endLine = endPos.Line
+		sourceFile = startPos.Filename
 	} else {
 		// 如果无法获取FileSet，使用默认值
 		startLine = 0
@@ -318,8 +885,22 @@ func (a *Analyzer) handleHTTPMethodCall(callExpr *ast.CallExpr, context *RouteCo
 		Handler:          handlerInfo.FuncDecl.Name.Name,
 		HandlerStartLine: startLine,
 		HandlerEndLine:   endLine,
+		HandlerAdapter:   handlerInfo.Adapter,
+		Deprecated:       isDeprecated(handlerInfo.FuncDecl),
 		Method:           method,
 		Path:             fullPath,
+
+		ResponseDescription: responseDescription(handlerInfo.FuncDecl),
+	}
+	routeInfo.Summary, routeInfo.Description = docSummary(handlerInfo.FuncDecl)
+	if a.options.SourceLocation {
+		routeInfo.HandlerFile = a.relativeSourcePath(sourceFile)
+	}
+	if a.gitHistory != nil && sourceFile != "" {
+		routeInfo.Since = a.gitHistory.since(sourceFile, startLine, endLine)
+	}
+	if a.isWebSocketHandler(handlerInfo.FuncDecl, handlerInfo.Package, 0) {
+		routeInfo.Kind = models.RouteKindWebSocket
 	}
 
 	// 使用 responseParsingEngine 分析 Handler 的请求和响应参数
@@ -331,35 +912,81 @@ func (a *Analyzer) handleHTTPMethodCall(callExpr *ast.CallExpr, context *RouteCo
 		if handlerAnalysisResult := a.analyzeHandlerWithResponseEngine(handlerInfo); handlerAnalysisResult != nil {
 			// 将分析结果集成到路由信息中
 			routeInfo.RequestParams = a.convertToModelRequestParams(handlerAnalysisResult.RequestParams)
-			routeInfo.ResponseSchema = a.convertToModelAPISchema(handlerAnalysisResult.Response)
+			// WebSocket 接口升级后通过连接收发消息，没有JSON响应体
+			if routeInfo.Kind != models.RouteKindWebSocket {
+				routeInfo.ResponseSchema = a.convertToModelAPISchema(handlerAnalysisResult.Response)
+				routeInfo.SuccessStatus = handlerAnalysisResult.SuccessStatus
+				routeInfo.Responses = a.convertToModelResponses(handlerAnalysisResult.StatusResponses)
+				routeInfo.MiddlewareRendered = handlerAnalysisResult.MiddlewareRendered
+			}
 			log.Printf("[DEBUG] 成功集成Handler参数分析结果: 请求参数%d个\n", len(handlerAnalysisResult.RequestParams))
 		}
 	}
+	// 没有响应体、只重定向到其他地址的接口（如 c.Redirect(302, "/login")）
+	if routeInfo.Kind == "" && routeInfo.ResponseSchema == nil && a.callsFunc(handlerInfo.FuncDecl, handlerInfo.Package, redirectFuncs, 0) {
+		routeInfo.Kind = models.RouteKindRedirect
+	}
+
+	// 注解在推断结果之后合并，冲突时以注解为准
+	if a.options.Annotations {
+		applyAnnotations(routeInfo, parseAnnotations(handlerInfo.FuncDecl))
+	}
 
 	return routeInfo
 }
 
+// convertToModelResponses 转换按状态码分组的响应结构
+func (a *Analyzer) convertToModelResponses(responses map[string]*helper.APISchema) map[string]*models.APISchema {
+	if len(responses) == 0 {
+		return nil
+	}
+	converted := make(map[string]*models.APISchema, len(responses))
+	for status, schema := range responses {
+		converted[status] = a.convertToModelAPISchema(schema)
+	}
+	return converted
+}
+
 // 辅助方法
 func (a *Analyzer) isCallOnRouter(callExpr *ast.CallExpr, targetRouter types.Object, typeInfo *types.Info) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			if obj := typeInfo.ObjectOf(ident); obj != nil {
-				return obj == targetRouter
-			}
+		if obj := routerExprObject(selExpr.X, typeInfo); obj != nil {
+			return obj == targetRouter
 		}
 	}
 	return false
 }
 
 func (a *Analyzer) isRouterArgument(arg ast.Expr, targetRouter types.Object, typeInfo *types.Info) bool {
-	if ident, ok := arg.(*ast.Ident); ok {
-		if obj := typeInfo.ObjectOf(ident); obj != nil {
-			return obj == targetRouter
-		}
+	if obj := routerExprObject(arg, typeInfo); obj != nil {
+		return obj == targetRouter
 	}
 	return false
 }
 
+// routerExprObject 返回路由器表达式引用的对象：变量 r，或结构体字段 s.router（返回字段对象）；
+// 取地址 &r 与嵌入字段 r.RouterGroup（如 &engine.RouterGroup）视为同一个路由器 r
+func routerExprObject(expr ast.Expr, typeInfo *types.Info) types.Object {
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		return typeInfo.ObjectOf(e)
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return routerExprObject(e.X, typeInfo)
+		}
+	case *ast.SelectorExpr:
+		if field, ok := typeInfo.ObjectOf(e.Sel).(*types.Var); ok && field.IsField() {
+			if field.Embedded() {
+				if obj := routerExprObject(e.X, typeInfo); obj != nil {
+					return obj
+				}
+			}
+			return field
+		}
+	}
+	return nil
+}
+
 func (a *Analyzer) getFunctionCallKey(callExpr *ast.CallExpr, pkg *packages.Package) string {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 		if ident, ok := selExpr.X.(*ast.Ident); ok {
@@ -374,8 +1001,8 @@ func (a *Analyzer) getFunctionCallKey(callExpr *ast.CallExpr, pkg *packages.Pack
 			}
 		}
 	} else if ident, ok := callExpr.Fun.(*ast.Ident); ok {
-		// 同包调用
-		return pkg.PkgPath + "+" + ident.Name
+		// 同包调用，或点导入 (import . "pkg") 包中的函数
+		return a.resolveIdentPackagePath(ident.Name, pkg) + "+" + ident.Name
 	}
 	return ""
 }
@@ -383,6 +1010,10 @@ func (a *Analyzer) getFunctionCallKey(callExpr *ast.CallExpr, pkg *packages.Pack
 func (a *Analyzer) resolvePackagePath(packageAlias string, currentPkg *packages.Package) string {
 	for _, file := range currentPkg.Syntax {
 		for _, imp := range file.Imports {
+			if imp.Name != nil && (imp.Name.Name == "." || imp.Name.Name == "_") {
+				// 点导入和匿名导入没有包限定符
+				continue
+			}
 			if imp.Name != nil && imp.Name.Name == packageAlias {
 				return strings.Trim(imp.Path.Value, "\"")
 			} else if imp.Name == nil {
@@ -397,6 +1028,30 @@ func (a *Analyzer) resolvePackagePath(packageAlias string, currentPkg *packages.
 	return ""
 }
 
+// resolveIdentPackagePath 解析无包限定符的标识符所在的包路径：
+// 优先当前包，其次依次检查点导入 (import . "pkg") 的包中是否声明了该标识符
+func (a *Analyzer) resolveIdentPackagePath(name string, currentPkg *packages.Package) string {
+	if currentPkg.Types == nil || currentPkg.Types.Scope().Lookup(name) != nil {
+		return currentPkg.PkgPath
+	}
+
+	for _, file := range currentPkg.Syntax {
+		for _, imp := range file.Imports {
+			if imp.Name == nil || imp.Name.Name != "." {
+				continue
+			}
+			importPath := strings.Trim(imp.Path.Value, "\"")
+			for _, importedPkg := range currentPkg.Types.Imports() {
+				if importedPkg.Path() == importPath && importedPkg.Scope().Lookup(name) != nil {
+					log.Printf("[DEBUG] resolveIdentPackagePath: %s 来自点导入的包 %s\n", name, importPath)
+					return importPath
+				}
+			}
+		}
+	}
+	return currentPkg.PkgPath
+}
+
 func (a *Analyzer) getRouterParameterObject(rgf *models.RouterGroupFunction) types.Object {
 	if rgf.FuncDecl.Type.Params != nil && len(rgf.FuncDecl.Type.Params.List) > rgf.RouterParamIdx {
 		param := rgf.FuncDecl.Type.Params.List[rgf.RouterParamIdx]
@@ -418,51 +1073,211 @@ func (a *Analyzer) copyVisitedFuncs(original map[string]bool) map[string]bool {
 	return copy
 }
 
+// findGroupResultObject 查找接收分组调用结果的变量或结构体字段，如 v1 := api.Group("/v1") 中的 v1。
+// 沿调用表达式所在的语法路径向上查找，只匹配直接以该调用（指针相同）为右值的赋值或声明，
+// 避免同一文件中出现相同的分组调用时绑定到错误的变量
 func (a *Analyzer) findGroupResultObject(callExpr *ast.CallExpr, pkg *packages.Package) types.Object {
-	// 在包的语法树中查找赋值语句
-	for _, file := range pkg.Syntax {
-		var foundObj types.Object
-		ast.Inspect(file, func(node ast.Node) bool {
-			if foundObj != nil {
-				return false
+	child, ancestors := exprAncestors(callExpr, pkg)
+	if len(ancestors) == 0 {
+		log.Printf("[DEBUG] findGroupResultObject: 分组调用不在包 %s 中\n", pkg.PkgPath)
+		return nil
+	}
+
+	switch n := ancestors[0].(type) {
+	case *ast.AssignStmt:
+		for i, rhs := range n.Rhs {
+			if rhs == child && i < len(n.Lhs) {
+				return assignTargetObject(n.Lhs[i], pkg)
+			}
+		}
+	case *ast.ValueSpec:
+		for i, value := range n.Values {
+			if value == child && i < len(n.Names) {
+				return groupVarObject(n.Names[i], pkg)
 			}
+		}
+	case *ast.KeyValueExpr:
+		// 结构体字面量中保存分组的字段，如 &Server{router: r.Group("/api")}
+		if key, ok := n.Key.(*ast.Ident); ok && n.Value == child {
+			if field, ok := pkg.TypesInfo.ObjectOf(key).(*types.Var); ok && field.IsField() {
+				return field
+			}
+		}
+	}
+	// 分组调用的结果没有直接赋给变量（如链式调用）
+	return nil
+}
 
-			if assignStmt, ok := node.(*ast.AssignStmt); ok {
-				for i, rhs := range assignStmt.Rhs {
-					if rhs == callExpr {
-						if i < len(assignStmt.Lhs) {
-							if ident, ok := assignStmt.Lhs[i].(*ast.Ident); ok {
-								if obj := pkg.TypesInfo.ObjectOf(ident); obj != nil {
-									foundObj = obj
-									return false
-								}
-							}
-						}
-					}
-				}
+// chainedCall 返回直接以调用结果为接收者的方法调用，如 r.Group("/a").GET(...) 中的 GET 调用
+func chainedCall(callExpr *ast.CallExpr, pkg *packages.Package) *ast.CallExpr {
+	child, ancestors := exprAncestors(callExpr, pkg)
+	if len(ancestors) < 2 {
+		return nil
+	}
+	selExpr, ok := ancestors[0].(*ast.SelectorExpr)
+	if !ok || selExpr.X != child {
+		return nil
+	}
+	if chained, ok := ancestors[1].(*ast.CallExpr); ok && chained.Fun == selExpr {
+		return chained
+	}
+	return nil
+}
+
+// argumentOfCall 返回直接以表达式为参数的函数调用，如 registerOrders(v1.Group("/orders")) 中的 registerOrders 调用
+func argumentOfCall(expr ast.Expr, pkg *packages.Package) *ast.CallExpr {
+	child, ancestors := exprAncestors(expr, pkg)
+	if len(ancestors) == 0 {
+		return nil
+	}
+	if outer, ok := ancestors[0].(*ast.CallExpr); ok {
+		for _, arg := range outer.Args {
+			if arg == child {
+				return outer
 			}
+		}
+	}
+	return nil
+}
 
-			if genDecl, ok := node.(*ast.GenDecl); ok {
-				for _, spec := range genDecl.Specs {
-					if valueSpec, ok := spec.(*ast.ValueSpec); ok {
-						for i, value := range valueSpec.Values {
-							if value == callExpr {
-								if i < len(valueSpec.Names) {
-									if obj := pkg.TypesInfo.ObjectOf(valueSpec.Names[i]); obj != nil {
-										foundObj = obj
-										return false
-									}
-								}
-							}
-						}
-					}
-				}
+// isReturnedValue 检查表达式是否直接作为函数的返回值，如 return g.Group("/v4")
+func isReturnedValue(expr ast.Expr, pkg *packages.Package) bool {
+	child, ancestors := exprAncestors(expr, pkg)
+	if len(ancestors) == 0 {
+		return false
+	}
+	if returnStmt, ok := ancestors[0].(*ast.ReturnStmt); ok {
+		for _, result := range returnStmt.Results {
+			if result == child {
+				return true
 			}
-			return true
-		})
+		}
+	}
+	return false
+}
+
+// exprAncestors 返回包含表达式的祖先节点（由内向外），跳过包裹表达式的括号；
+// child 为祖先节点中直接包含的子节点（表达式本身或最外层的括号表达式）
+func exprAncestors(expr ast.Expr, pkg *packages.Package) (ast.Node, []ast.Node) {
+	file := fileContaining(pkg, expr)
+	if file == nil {
+		return nil, nil
+	}
 
-		if foundObj != nil {
-			return foundObj
+	path, _ := astutil.PathEnclosingInterval(file, expr.Pos(), expr.End())
+	for i, node := range path {
+		if node != expr {
+			// 跳过表达式内部的节点
+			continue
+		}
+		child := node
+		ancestors := path[i+1:]
+		for len(ancestors) > 0 {
+			paren, ok := ancestors[0].(*ast.ParenExpr)
+			if !ok {
+				break
+			}
+			child, ancestors = paren, ancestors[1:]
+		}
+		return child, ancestors
+	}
+	return nil, nil
+}
+
+// handleRouterAlias 路由器对象赋给了其他变量（如 v6 := v5）或结构体字段（如 s.router = r），或作为路由分组函数的返回值（如 return g）时，
+// 以相同的路径和中间件继续解析接收的变量
+func (a *Analyzer) handleRouterAlias(node ast.Node, context *RouteContext, pkg *packages.Package) []models.RouteInfo {
+	var targets []types.Object
+	returned := false
+	switch n := node.(type) {
+	case *ast.AssignStmt:
+		for i, rhs := range n.Rhs {
+			if i < len(n.Lhs) && a.isRouterArgument(rhs, context.RouterObject, pkg.TypesInfo) {
+				targets = append(targets, assignTargetObject(n.Lhs[i], pkg))
+			}
+		}
+	case *ast.ValueSpec:
+		for i, value := range n.Values {
+			if i < len(n.Names) && a.isRouterArgument(value, context.RouterObject, pkg.TypesInfo) {
+				targets = append(targets, groupVarObject(n.Names[i], pkg))
+			}
+		}
+	case *ast.KeyValueExpr:
+		// 结构体字面量中保存路由器的字段，如 &Server{router: r}
+		if key, ok := n.Key.(*ast.Ident); ok && a.isRouterArgument(n.Value, context.RouterObject, pkg.TypesInfo) {
+			if field, ok := pkg.TypesInfo.ObjectOf(key).(*types.Var); ok && field.IsField() {
+				targets = append(targets, field)
+			}
+		}
+	case *ast.ReturnStmt:
+		for _, result := range n.Results {
+			if context.ReturnTarget != nil && a.isRouterArgument(result, context.RouterObject, pkg.TypesInfo) {
+				targets = append(targets, context.ReturnTarget)
+				returned = true
+			}
+		}
+	}
+
+	var routes []models.RouteInfo
+	for _, target := range targets {
+		if target == nil || target == context.RouterObject {
+			continue
+		}
+		// 防止变量之间互相赋值导致循环解析
+		aliasKey := fmt.Sprintf("alias:%s:%d", target.Name(), target.Pos())
+		if context.VisitedFuncs[aliasKey] {
+			continue
+		}
+		log.Printf("[DEBUG] 路由器 %s 赋给了变量 %s\n", context.RouterObject.Name(), target.Name())
+
+		newContext := *context
+		newContext.RouterObject = target
+		newContext.VisitedFuncs = a.copyVisitedFuncs(context.VisitedFuncs)
+		newContext.VisitedFuncs[aliasKey] = true
+		if returned {
+			newContext.ReturnTarget = nil
+		}
+		for _, route := range a.analyzeRouterRecursively(&newContext) {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// fileContaining 返回包中包含该节点的源文件
+func fileContaining(pkg *packages.Package, node ast.Node) *ast.File {
+	for _, file := range pkg.Syntax {
+		if file.Pos() <= node.Pos() && node.End() <= file.End() {
+			return file
+		}
+	}
+	return nil
+}
+
+// assignTargetObject 返回赋值左侧接收路由器的对象：变量，或结构体字段 s.router
+func assignTargetObject(lhs ast.Expr, pkg *packages.Package) types.Object {
+	switch target := lhs.(type) {
+	case *ast.Ident:
+		return groupVarObject(target, pkg)
+	case *ast.SelectorExpr:
+		return routerExprObject(target, pkg.TypesInfo)
+	}
+	return nil
+}
+
+// groupVarObject 返回标识符对应的变量对象：优先使用类型信息中的定义/引用记录，
+// 缺失时在标识符所在的作用域中按名称查找
+func groupVarObject(ident *ast.Ident, pkg *packages.Package) types.Object {
+	if ident.Name == "_" {
+		return nil
+	}
+	if obj := pkg.TypesInfo.ObjectOf(ident); obj != nil {
+		return obj
+	}
+	if scope := pkg.Types.Scope().Innermost(ident.Pos()); scope != nil {
+		if _, obj := scope.LookupParent(ident.Name, token.NoPos); obj != nil {
+			log.Printf("[DEBUG] groupVarObject: 通过作用域找到变量 %s\n", ident.Name)
+			return obj
 		}
 	}
 	return nil
@@ -486,25 +1301,59 @@ func (a *Analyzer) combinePaths(basePath, segment string) string {
 	return filepath.Join(basePath, segment)
 }
 
+// normalizePath 规范化路由路径：合并重复的斜杠，去掉末尾的斜杠（根路径除外），确保以斜杠开头
+// 如 "//api/" -> "/api"，"/api/v1/" -> "/api/v1"，"/" -> "/"
+func normalizePath(path string) string {
+	path = "/" + path
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
 // extractHandlerInfo 提取处理函数信息（包括包信息）
 func (a *Analyzer) extractHandlerInfo(callExpr *ast.CallExpr, typeInfo *types.Info) *HandlerInfo {
 	if len(callExpr.Args) == 0 {
 		return nil
 	}
 
-	lastArg := callExpr.Args[len(callExpr.Args)-1]
+	return a.extractHandlerInfoFromExpr(callExpr.Args[len(callExpr.Args)-1], typeInfo)
+}
 
+// extractHandlerInfoFromExpr 从处理函数表达式（函数名、包选择器、匿名函数、适配器调用）中提取处理函数信息
+func (a *Analyzer) extractHandlerInfoFromExpr(lastArg ast.Expr, typeInfo *types.Info) *HandlerInfo {
 	log.Printf("[DEBUG] extractHandlerInfo: 提取处理函数，参数类型: %T\n", lastArg)
 
+	// 0. 处理 gin.WrapF / gin.WrapH 适配的标准库处理函数
+	if adapterCall, ok := lastArg.(*ast.CallExpr); ok {
+		if adapter := a.ginAdapterName(adapterCall, typeInfo); adapter != "" {
+			log.Printf("[DEBUG] extractHandlerInfo: 发现适配器调用 %s\n", adapter)
+			handlerInfo := a.extractAdaptedHandlerInfo(adapterCall, adapter, typeInfo)
+			if handlerInfo != nil {
+				handlerInfo.Adapter = adapter
+			}
+			return handlerInfo
+		}
+	}
+
 	// 1. 处理标识符（本包中的函数）
 	if ident, ok := lastArg.(*ast.Ident); ok {
 		if obj := typeInfo.ObjectOf(ident); obj != nil {
 			log.Printf("[DEBUG] extractHandlerInfo: 通过标识符查找函数: %s\n", obj.Name())
 
-			// 获取函数所在的包信息
+			// 获取函数所在的包信息（点导入的函数所在包与调用方不同）
 			pkg := obj.Pkg()
 			if pkg != nil {
-				funcDecl := a.findFunctionDeclaration(obj.Name())
+				var funcDecl *ast.FuncDecl
+				if realPkg := a.findPackageByPath(pkg.Path()); realPkg != nil {
+					funcDecl = a.findFunctionDeclarationInPackage(realPkg, obj.Name())
+				}
+				if funcDecl == nil {
+					funcDecl = a.findFunctionDeclaration(obj.Name())
+				}
 				if funcDecl != nil {
 					return &HandlerInfo{
 						FuncDecl:    funcDecl,
@@ -517,7 +1366,24 @@ func (a *Analyzer) extractHandlerInfo(callExpr *ast.CallExpr, typeInfo *types.In
 		}
 	}
 
-	// 2. 处理选择器表达式（其他包中的函数）
+	// 2. 处理方法值（如 h.Get、ctrl.user.Get）：按接收者的类型确定具体的方法，避免匹配到同名的其他函数或方法
+	if selExpr, ok := lastArg.(*ast.SelectorExpr); ok {
+		if selection, ok := typeInfo.Selections[selExpr]; ok && selection.Kind() == types.MethodVal {
+			if funcObj, ok := selection.Obj().(*types.Func); ok {
+				if funcDecl, pkg := a.findFuncDecl(funcObj.Origin()); funcDecl != nil {
+					log.Printf("[DEBUG] extractHandlerInfo: 通过接收者类型找到方法: %s\n", funcObj.FullName())
+					return &HandlerInfo{
+						FuncDecl:    funcDecl,
+						PackageName: pkg.Name,
+						PackagePath: pkg.PkgPath,
+						Package:     pkg,
+					}
+				}
+			}
+		}
+	}
+
+	// 3. 处理选择器表达式（其他包中的函数）
 	if selExpr, ok := lastArg.(*ast.SelectorExpr); ok {
 		if ident, ok := selExpr.X.(*ast.Ident); ok {
 			packageName := ident.Name
@@ -580,7 +1446,7 @@ func (a *Analyzer) extractHandlerInfo(callExpr *ast.CallExpr, typeInfo *types.In
 		}
 	}
 
-	// 3. 处理匿名函数
+	// 4. 处理匿名函数
 	if funcLit, ok := lastArg.(*ast.FuncLit); ok {
 		// 对于匿名函数，使用当前包的信息
 		return &HandlerInfo{
@@ -592,9 +1458,156 @@ func (a *Analyzer) extractHandlerInfo(callExpr *ast.CallExpr, typeInfo *types.In
 			PackageName: "anonymous",
 			PackagePath: "anonymous",
 			Package:     nil,
+			Anonymous:   true,
+		}
+	}
+
+	// 5. 处理函数工厂调用（如 MakeHandler(dep)）：分析工厂函数返回的闭包
+	if factoryCall, ok := lastArg.(*ast.CallExpr); ok {
+		return a.extractFactoryHandlerInfo(factoryCall, typeInfo)
+	}
+
+	return nil
+}
+
+// extractFactoryHandlerInfo 处理函数工厂（返回 gin.HandlerFunc 等闭包的函数，常用于依赖注入）：
+// 在工厂函数的 return 语句中查找匿名函数，以工厂函数名作为处理函数名分析闭包的函数体
+func (a *Analyzer) extractFactoryHandlerInfo(callExpr *ast.CallExpr, typeInfo *types.Info) *HandlerInfo {
+	var funcObj *types.Func
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		funcObj, _ = typeInfo.ObjectOf(fun).(*types.Func)
+	case *ast.SelectorExpr:
+		funcObj, _ = typeInfo.ObjectOf(fun.Sel).(*types.Func)
+	}
+	if funcObj == nil || funcObj.Pkg() == nil {
+		return nil
+	}
+	factoryDecl, pkg := a.findFuncDecl(funcObj.Origin())
+	if factoryDecl == nil || factoryDecl.Body == nil {
+		return nil
+	}
+
+	funcLit := returnedFuncLit(factoryDecl.Body, pkg.TypesInfo)
+	if funcLit == nil {
+		log.Printf("[DEBUG] extractHandlerInfo: 函数 %s 未返回匿名函数，不是处理函数工厂\n", funcObj.Name())
+		return nil
+	}
+	log.Printf("[DEBUG] extractHandlerInfo: 通过处理函数工厂 %s 找到闭包\n", funcObj.FullName())
+	return &HandlerInfo{
+		FuncDecl: &ast.FuncDecl{
+			Doc:  factoryDecl.Doc,
+			Name: &ast.Ident{NamePos: funcLit.Pos(), Name: factoryDecl.Name.Name},
+			Type: funcLit.Type,
+			Body: funcLit.Body,
+		},
+		PackageName: pkg.Name,
+		PackagePath: pkg.PkgPath,
+		Package:     pkg,
+	}
+}
+
+// returnedFuncLit 函数体中 return 语句返回的匿名函数，支持 return func(c *gin.Context) {...}
+// 及 return gin.HandlerFunc(func(c *gin.Context) {...})；不查找嵌套匿名函数内部的 return 语句
+func returnedFuncLit(body *ast.BlockStmt, typeInfo *types.Info) *ast.FuncLit {
+	var found *ast.FuncLit
+	ast.Inspect(body, func(node ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != 1 {
+				return false
+			}
+			result := n.Results[0]
+			// 类型转换，如 gin.HandlerFunc(func...)
+			if conv, ok := result.(*ast.CallExpr); ok && len(conv.Args) == 1 {
+				if tv, ok := typeInfo.Types[conv.Fun]; ok && tv.IsType() {
+					result = conv.Args[0]
+				}
+			}
+			found, _ = result.(*ast.FuncLit)
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// ginAdapterName 如果调用为 gin.WrapF / gin.WrapH，返回适配器名称
+func (a *Analyzer) ginAdapterName(callExpr *ast.CallExpr, typeInfo *types.Info) string {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || (selExpr.Sel.Name != "WrapF" && selExpr.Sel.Name != "WrapH") {
+		return ""
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if pkgName, ok := typeInfo.ObjectOf(ident).(*types.PkgName); ok && helper.IsGinPackagePath(pkgName.Imported().Path()) {
+		return "gin." + selExpr.Sel.Name
+	}
+	return ""
+}
+
+// extractAdaptedHandlerInfo 解析被适配器包装的标准库处理函数
+// gin.WrapF(fn) 解析 fn；gin.WrapH(h) 解析 http.HandlerFunc(fn) 转换中的 fn，或 h 类型的 ServeHTTP 方法
+func (a *Analyzer) extractAdaptedHandlerInfo(adapterCall *ast.CallExpr, adapter string, typeInfo *types.Info) *HandlerInfo {
+	if len(adapterCall.Args) != 1 {
+		return nil
+	}
+	wrapped := adapterCall.Args[0]
+
+	if adapter == "gin.WrapH" {
+		// http.HandlerFunc(fn) 类型转换
+		if convCall, ok := wrapped.(*ast.CallExpr); ok && len(convCall.Args) == 1 {
+			if tv, ok := typeInfo.Types[convCall.Fun]; ok && tv.IsType() {
+				return a.extractHandlerInfo(&ast.CallExpr{Args: convCall.Args}, typeInfo)
+			}
+		}
+
+		// 实现了 http.Handler 的类型，使用其 ServeHTTP 方法
+		if handlerType := typeInfo.TypeOf(wrapped); handlerType != nil {
+			if handlerInfo := a.findServeHTTPMethod(handlerType); handlerInfo != nil {
+				return handlerInfo
+			}
 		}
 	}
 
+	// 函数标识符或选择器，复用普通处理函数的查找逻辑
+	return a.extractHandlerInfo(&ast.CallExpr{Args: []ast.Expr{wrapped}}, typeInfo)
+}
+
+// findServeHTTPMethod 查找类型的 ServeHTTP 方法声明
+func (a *Analyzer) findServeHTTPMethod(typ types.Type) *HandlerInfo {
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, "ServeHTTP")
+	method, ok := obj.(*types.Func)
+	if !ok || method.Pkg() == nil {
+		return nil
+	}
+
+	pkg := a.findPackageByPath(method.Pkg().Path())
+	if pkg == nil {
+		return nil
+	}
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv != nil {
+				if pkg.TypesInfo.ObjectOf(funcDecl.Name) == method {
+					return &HandlerInfo{
+						FuncDecl:    funcDecl,
+						PackageName: pkg.Name,
+						PackagePath: pkg.PkgPath,
+						Package:     pkg,
+					}
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -659,8 +1672,20 @@ func (a *Analyzer) hasGinContextParameter(funcDecl *ast.FuncDecl) bool {
 		return false
 	}
 
+	typeInfo := a.findTypeInfoForFunc(funcDecl)
+
 	for _, param := range funcDecl.Type.Params.List {
 		if len(param.Names) > 0 {
+			// 优先通过类型信息判断，支持别名导入
+			if typeInfo != nil {
+				if paramType := typeInfo.TypeOf(param.Type); paramType != nil {
+					if helper.IsGinContextType(paramType) {
+						return true
+					}
+					continue
+				}
+			}
+
 			// 检查参数类型是否为gin.Context
 			if starExpr, ok := param.Type.(*ast.StarExpr); ok {
 				if selExpr, ok := starExpr.X.(*ast.SelectorExpr); ok {
@@ -676,11 +1701,25 @@ func (a *Analyzer) hasGinContextParameter(funcDecl *ast.FuncDecl) bool {
 	return false
 }
 
+// findTypeInfoForFunc 查找函数声明所在包的类型信息
+func (a *Analyzer) findTypeInfoForFunc(funcDecl *ast.FuncDecl) *types.Info {
+	for _, pkg := range a.project.Packages {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		if _, ok := pkg.TypesInfo.Defs[funcDecl.Name]; ok {
+			return pkg.TypesInfo
+		}
+	}
+	return nil
+}
+
 func (a *Analyzer) findFunctionDeclarationInPackage(pkg *packages.Package, functionName string) *ast.FuncDecl {
 	for _, file := range pkg.Syntax {
 		for _, decl := range file.Decls {
 			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
-				if funcDecl.Name.Name == functionName {
+				// 只匹配包级函数，跳过同名方法
+				if funcDecl.Recv == nil && funcDecl.Name.Name == functionName {
 					return funcDecl
 				}
 			}
@@ -730,6 +1769,7 @@ func (a *Analyzer) convertToModelRequestParams(helperParams []helper.RequestPara
 			ParamName:   helperParam.ParamName,
 			IsRequired:  helperParam.IsRequired,
 			Source:      helperParam.Source,
+			ContentType: helperParam.ContentType,
 			ParamSchema: a.convertToModelAPISchema(helperParam.ParamSchema),
 		}
 		modelParams = append(modelParams, modelParam)
@@ -748,6 +1788,17 @@ func (a *Analyzer) convertToModelAPISchema(helperSchema *helper.APISchema) *mode
 		Type:        helperSchema.Type,
 		Description: helperSchema.Description,
 		JSONTag:     helperSchema.JSONTag,
+		FormTag:     helperSchema.FormTag,
+		Package:     helperSchema.Package,
+		Format:      helperSchema.Format,
+		ContentType: helperSchema.ContentType,
+		Nullable:    helperSchema.Nullable,
+		Required:    helperSchema.Required,
+		ReadOnly:    helperSchema.ReadOnly,
+		WriteOnly:   helperSchema.WriteOnly,
+		Default:     helperSchema.Default,
+		Example:     helperSchema.Example,
+		Map:         helperSchema.Map,
 	}
 
 	// 转换Properties
@@ -763,6 +1814,11 @@ func (a *Analyzer) convertToModelAPISchema(helperSchema *helper.APISchema) *mode
 		modelSchema.Items = a.convertToModelAPISchema(helperSchema.Items)
 	}
 
+	// 转换OneOf
+	for _, candidate := range helperSchema.OneOf {
+		modelSchema.OneOf = append(modelSchema.OneOf, a.convertToModelAPISchema(candidate))
+	}
+
 	return modelSchema
 }
 
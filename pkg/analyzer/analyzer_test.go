@@ -0,0 +1,687 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/exporter"
+	"github.com/YogeLiu/api-tool/pkg/extractor"
+	"github.com/YogeLiu/api-tool/pkg/models"
+	"github.com/YogeLiu/api-tool/pkg/parser"
+)
+
+func TestMain(m *testing.M) {
+	// 分析过程输出大量调试日志，测试中丢弃
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+var (
+	fixtureMu       sync.Mutex
+	fixtureProjects = make(map[string]*parser.Project)
+)
+
+// loadFixture 解析 testdata 下的示例项目，同一项目只解析一次；示例项目属于本模块，依赖从模块缓存加载而不是 vendor 目录
+func loadFixture(t *testing.T, name string) *parser.Project {
+	t.Helper()
+	return loadFixtureWithOptions(t, name, parser.Options{})
+}
+
+// loadFixtureWithOptions 按解析配置（如包含测试文件、构建标签）解析示例项目，同一项目和配置只解析一次
+func loadFixtureWithOptions(t *testing.T, name string, options parser.Options) *parser.Project {
+	t.Helper()
+	fixtureMu.Lock()
+	defer fixtureMu.Unlock()
+	key := fmt.Sprintf("%s %+v", name, options)
+	if proj, ok := fixtureProjects[key]; ok {
+		return proj
+	}
+	options.Env = append(options.Env, "GOFLAGS=-mod=mod")
+	proj, err := parser.ParseProjectWithOptions(filepath.Join("testdata", name), options)
+	if err != nil {
+		t.Fatalf("解析示例项目 %s 失败: %v", name, err)
+	}
+	fixtureProjects[key] = proj
+	return proj
+}
+
+// analyzeFixture 按配置分析 testdata 下的示例项目
+func analyzeFixture(t *testing.T, name string, options Options) *models.APIInfo {
+	t.Helper()
+	return analyzeProject(t, name, loadFixture(t, name), options)
+}
+
+// analyzeProject 按配置分析已解析的示例项目
+func analyzeProject(t *testing.T, name string, proj *parser.Project, options Options) *models.APIInfo {
+	t.Helper()
+	framework, err := extractor.DetectFramework(proj)
+	if err != nil {
+		t.Fatalf("检测框架失败: %v", err)
+	}
+	ext, err := extractor.CreateExtractor(framework, proj)
+	if err != nil {
+		t.Fatalf("创建提取器失败: %v", err)
+	}
+	a := NewAnalyzer(filepath.Join("testdata", name), proj, ext)
+	a.SetOptions(options)
+	info, err := a.Analyze()
+	if err != nil {
+		t.Fatalf("分析示例项目 %s 失败: %v", name, err)
+	}
+	return info
+}
+
+// findRoute 按方法和路径查找路由，找不到时测试失败
+func findRoute(t *testing.T, info *models.APIInfo, method, path string) models.RouteInfo {
+	t.Helper()
+	for _, route := range info.Routes {
+		if route.Method == method && route.Path == path {
+			return route
+		}
+	}
+	var found []string
+	for _, route := range info.Routes {
+		found = append(found, route.Method+" "+route.Path)
+	}
+	t.Fatalf("未找到路由 %s %s，已有路由: %v", method, path, found)
+	return models.RouteInfo{}
+}
+
+// hasRoute 检查分析结果中是否有指定方法和路径的路由
+func hasRoute(info *models.APIInfo, method, path string) bool {
+	for _, route := range info.Routes {
+		if route.Method == method && route.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// property 按JSON名称查找属性，找不到时测试失败
+func property(t *testing.T, schema *models.APISchema, name string) *models.APISchema {
+	t.Helper()
+	if schema == nil {
+		t.Fatalf("schema 为空，无法查找属性 %s", name)
+	}
+	for key, prop := range schema.Properties {
+		if key == name || (prop != nil && prop.JSONTag == name) {
+			return prop
+		}
+	}
+	t.Fatalf("schema %s 中没有属性 %s", schema.Type, name)
+	return nil
+}
+
+// swaggerDoc 按默认配置导出Swagger文档，但不解包响应封装，成功响应直接引用处理函数返回的结构
+func swaggerDoc(info *models.APIInfo) *exporter.SwaggerDoc {
+	options := exporter.DefaultOptions()
+	options.EnvelopeField = ""
+	return swaggerDocWithOptions(info, options)
+}
+
+// swaggerDocWithOptions 按指定的导出配置生成Swagger文档
+func swaggerDocWithOptions(info *models.APIInfo, options exporter.Options) *exporter.SwaggerDoc {
+	e := exporter.NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+	e.SetOptions(options)
+	return e.Generate(info)
+}
+
+// componentSchema 返回操作成功响应引用的 components.schemas 名称和定义，未引用 schema 时测试失败
+func componentSchema(t *testing.T, doc *exporter.SwaggerDoc, path string) (string, map[string]interface{}) {
+	t.Helper()
+	swaggerPath, ok := doc.Paths[path]
+	if !ok || swaggerPath.Get == nil {
+		t.Fatalf("Swagger文档中没有操作 GET %s", path)
+	}
+	ref, _ := swaggerPath.Get.Responses["200"].Content["application/json"].Schema["$ref"].(string)
+	if ref == "" {
+		t.Fatalf("GET %s 的成功响应没有引用 schema", path)
+	}
+	name := strings.TrimPrefix(ref, "#/components/schemas/")
+	schemas, _ := doc.Components["schemas"].(map[string]interface{})
+	schema, _ := schemas[name].(map[string]interface{})
+	return name, schema
+}
+
+// swaggerOperation 将分析结果导出为Swagger文档，返回指定路径和方法的操作
+func swaggerOperation(t *testing.T, info *models.APIInfo, method, path string) *exporter.SwaggerOperation {
+	t.Helper()
+	doc := exporter.NewSwaggerExporter("fixture", "1.0.0", "", "", true).Generate(info)
+	swaggerPath, ok := doc.Paths[path]
+	if !ok {
+		t.Fatalf("Swagger文档中没有路径 %s", path)
+	}
+	operation := map[string]*exporter.SwaggerOperation{
+		"GET":    swaggerPath.Get,
+		"POST":   swaggerPath.Post,
+		"PUT":    swaggerPath.Put,
+		"DELETE": swaggerPath.Delete,
+		"PATCH":  swaggerPath.Patch,
+	}[method]
+	if operation == nil {
+		t.Fatalf("Swagger文档中没有操作 %s %s", method, path)
+	}
+	return operation
+}
+
+func TestExportedOnlySkipsUnexportedHandlers(t *testing.T) {
+	all := analyzeFixture(t, "ginapp", Options{})
+	exported := analyzeFixture(t, "ginapp", Options{ExportedOnly: true})
+
+	for _, path := range []string{"/exportedonly/users", "/exportedonly/debug", "/exportedonly/ping"} {
+		if !hasRoute(all, "GET", path) {
+			t.Errorf("默认应包含路由 GET %s", path)
+		}
+	}
+	if !hasRoute(exported, "GET", "/exportedonly/users") {
+		t.Errorf("仅导出模式应保留导出的处理函数 ListUsers")
+	}
+	if hasRoute(exported, "GET", "/exportedonly/debug") {
+		t.Errorf("仅导出模式应跳过未导出的处理函数 debugVars")
+	}
+	if hasRoute(exported, "GET", "/exportedonly/ping") {
+		t.Errorf("仅导出模式应跳过匿名处理函数")
+	}
+}
+
+func TestGinDynamicRoutePaths(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	// fmt.Sprintf 的格式参数替换为占位符，常量拼接直接求值
+	findRoute(t, info, "GET", "/sprintfpath/items/{param}")
+	findRoute(t, info, "GET", "/sprintfpath/items/detail")
+}
+
+func TestDeprecatedHandler(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	if route := findRoute(t, info, "GET", "/deprecated/v1/user"); !route.Deprecated {
+		t.Errorf("文档注释包含 Deprecated: 的处理函数应标记为废弃")
+	}
+	if route := findRoute(t, info, "GET", "/deprecated/v2/user"); route.Deprecated {
+		t.Errorf("未标记 Deprecated: 的处理函数不应标记为废弃")
+	}
+	if !swaggerOperation(t, info, "GET", "/deprecated/v1/user").Deprecated {
+		t.Errorf("Swagger 操作应输出 deprecated: true")
+	}
+	if swaggerOperation(t, info, "GET", "/deprecated/v2/user").Deprecated {
+		t.Errorf("未废弃的 Swagger 操作不应输出 deprecated")
+	}
+}
+
+func TestDeclarativeRouteTable(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	list := findRoute(t, info, "GET", "/routetable/orders")
+	if list.Handler != "ListOrders" {
+		t.Errorf("GET 路由的处理函数应为 ListOrders，实际为 %s", list.Handler)
+	}
+	if list.ResponseSchema == nil || list.ResponseSchema.Type != "array" {
+		t.Errorf("ListOrders 的响应应为 Order 数组，实际为 %+v", list.ResponseSchema)
+	}
+
+	create := findRoute(t, info, "POST", "/routetable/orders")
+	if create.Handler != "CreateOrder" {
+		t.Errorf("POST 路由的处理函数应为 CreateOrder，实际为 %s", create.Handler)
+	}
+	// 按位置初始化的元素同样展开
+	findRoute(t, info, "DELETE", "/routetable/orders/:id")
+}
+
+func TestNormalizePath(t *testing.T) {
+	for path, want := range map[string]string{
+		"//api/":     "/api",
+		"/api/v1/":   "/api/v1",
+		"/":          "/",
+		"":           "/",
+		"api//v1":    "/api/v1",
+		"/users/:id": "/users/:id",
+	} {
+		if got := normalizePath(path); got != want {
+			t.Errorf("normalizePath(%q) 应为 %q，实际为 %q", path, want, got)
+		}
+	}
+}
+
+func TestRoutePathsNormalized(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	findRoute(t, info, "GET", "/normalize")
+	findRoute(t, info, "GET", "/normalize/api")
+	for _, route := range info.Routes {
+		if route.Path != normalizePath(route.Path) {
+			t.Errorf("路由路径 %s 未规范化", route.Path)
+		}
+	}
+}
+
+func TestDotImportedHandler(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	list := findRoute(t, info, "GET", "/dotimport/items")
+	if list.Handler != "ListItems" || list.PackageName != "handlers" {
+		t.Errorf("点导入的处理函数应解析为 handlers.ListItems，实际为 %s.%s", list.PackageName, list.Handler)
+	}
+	if list.ResponseSchema == nil || list.ResponseSchema.Items == nil || list.ResponseSchema.Items.Type != "Item" {
+		t.Errorf("ListItems 的响应应为 Item 数组，实际为 %+v", list.ResponseSchema)
+	}
+
+	item := findRoute(t, info, "GET", "/dotimport/item")
+	if item.ResponseSchema == nil || item.ResponseSchema.Type != "Item" {
+		t.Fatalf("点导入包中的类型应正常解析，实际为 %+v", item.ResponseSchema)
+	}
+	property(t, item.ResponseSchema, "name")
+}
+
+func TestBlockScopedGroupVariables(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for path, handler := range map[string]string{
+		"/blockscope/v1/users":       "ListV1",
+		"/blockscope/v1/admin/users": "AdminUsers",
+		"/blockscope/v1/ping":        "Ping",
+		"/blockscope/v2/users":       "ListV2",
+	} {
+		if route := findRoute(t, info, "GET", path); route.Handler != handler {
+			t.Errorf("GET %s 的处理函数应为 %s，实际为 %s", path, handler, route.Handler)
+		}
+	}
+	// 同名分组变量不应绑定到其他代码块的分组而产生多余的路由
+	var paths []string
+	for _, route := range info.Routes {
+		if strings.HasPrefix(route.Path, "/blockscope/") {
+			paths = append(paths, route.Path)
+		}
+	}
+	if len(paths) != 4 {
+		t.Errorf("blockscope 应有4个路由，实际为 %v", paths)
+	}
+}
+
+func TestIncludeTestFiles(t *testing.T) {
+	// 默认不加载 _test.go 文件
+	info := analyzeFixture(t, "testsapp", Options{})
+	findRoute(t, info, "GET", "/ping")
+	if hasRoute(info, "GET", "/echo") {
+		t.Errorf("默认不应包含测试文件中注册的路由")
+	}
+
+	withTests := analyzeProject(t, "testsapp", loadFixtureWithOptions(t, "testsapp", parser.Options{IncludeTests: true}), Options{})
+	echo := findRoute(t, withTests, "GET", "/echo")
+	if echo.ResponseSchema == nil || echo.ResponseSchema.Type != "Echo" {
+		t.Errorf("测试文件中注册的路由应正常分析，实际响应为 %+v", echo.ResponseSchema)
+	}
+	// 测试版本的包与普通版本不重复分析
+	count := 0
+	for _, route := range withTests.Routes {
+		if route.Method == "GET" && route.Path == "/ping" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("GET /ping 应只出现一次，实际出现 %d 次", count)
+	}
+}
+
+func TestMethodHandlersResolvedByReceiver(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for path, want := range map[string]string{"/receivers/user": "User", "/receivers/order": "Order"} {
+		route := findRoute(t, info, "GET", path)
+		if route.ResponseSchema == nil || route.ResponseSchema.Type != want {
+			t.Errorf("%s 应解析接收者对应的 Get 方法，响应为 %s，实际为 %+v", path, want, route.ResponseSchema)
+		}
+	}
+	if free := findRoute(t, info, "GET", "/receivers/free"); free.ResponseSchema != nil && free.ResponseSchema.Type != "string" {
+		t.Errorf("普通函数 Get 不应解析为方法，实际响应为 %+v", free.ResponseSchema)
+	}
+}
+
+func TestGroupMiddlewareArguments(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for path, want := range map[string][]string{
+		"/groupmw/health":            nil,
+		"/groupmw/admin/users":       {"AuthMW"},
+		"/groupmw/admin/audit/users": {"AuthMW", "AuditMW"},
+	} {
+		route := findRoute(t, info, "GET", path)
+		if strings.Join(route.Middlewares, ",") != strings.Join(want, ",") {
+			t.Errorf("%s 的中间件应为 %v，实际为 %v", path, want, route.Middlewares)
+		}
+	}
+}
+
+func TestInlineRouteMiddlewares(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	// 路由注册时最终处理函数之前的参数为中间件，排在分组中间件之后
+	route := findRoute(t, info, "POST", "/groupmw/admin/users")
+	if got := strings.Join(route.Middlewares, ","); got != "AuthMW,RateLimitMW,AuditMW" {
+		t.Errorf("中间件应为分组的 AuthMW 加路由的 RateLimitMW、AuditMW，实际为 %v", route.Middlewares)
+	}
+	if route.Handler != "CreateUser" {
+		t.Fatalf("最后一个参数应作为处理函数，实际为 %s", route.Handler)
+	}
+	property(t, requestParam(t, route, "body", "request_body").ParamSchema, "name")
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "User" {
+		t.Errorf("响应应按最终处理函数解析为 User，实际为 %+v", route.ResponseSchema)
+	}
+
+	// Handle 的方法和路径参数不计入中间件
+	if cache := findRoute(t, info, "DELETE", "/groupmw/cache"); strings.Join(cache.Middlewares, ",") != "AuthMW" {
+		t.Errorf("DELETE /groupmw/cache 的中间件应为 AuthMW，实际为 %v", cache.Middlewares)
+	}
+}
+
+func TestMultiLevelNestedGroups(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for _, path := range []string{
+		// 分组变量逐级赋值
+		"/nestedgroup/v1/admin/stats",
+		// 分组变量传给其他函数后继续链式分组
+		"/nestedgroup/v1/users/:id/profile",
+		// 以 &engine.RouterGroup 传参，函数内链式 .Group().Group()
+		"/nestedgroup/v2/deep/x",
+		// 分组调用的结果直接作为参数，如 registerOrders(v1.Group("/orders"))
+		"/nestedgroup/v1/orders/:id",
+	} {
+		if !hasRoute(info, "GET", path) {
+			t.Errorf("应解析出多级嵌套分组中的路由 GET %s", path)
+		}
+	}
+}
+
+func TestBuildConstrainedRouteFiles(t *testing.T) {
+	// 默认构建环境下不加载 prod 标签和 windows 平台的路由文件
+	info := analyzeFixture(t, "tagsapp", Options{})
+	findRoute(t, info, "GET", "/ping")
+	if hasRoute(info, "GET", "/metrics") || hasRoute(info, "GET", "/service") {
+		t.Errorf("默认不应包含带构建约束的路由，实际为 %+v", info.Routes)
+	}
+
+	tagged := analyzeProject(t, "tagsapp", loadFixtureWithOptions(t, "tagsapp", parser.Options{BuildFlags: []string{"-tags=prod"}}), Options{})
+	if metrics := findRoute(t, tagged, "GET", "/metrics"); metrics.Handler != "Metrics" {
+		t.Errorf("开启 prod 标签后应解析 prod.go 中的路由，实际为 %+v", metrics)
+	}
+
+	windows := analyzeProject(t, "tagsapp", loadFixtureWithOptions(t, "tagsapp", parser.Options{Env: []string{"GOOS=windows", "GOARCH=amd64"}}), Options{})
+	findRoute(t, windows, "GET", "/service")
+	if hasRoute(windows, "GET", "/metrics") {
+		t.Errorf("只设置 GOOS 时不应包含 prod 标签的路由")
+	}
+}
+
+func TestUnresolvedHandlerKeepsRoute(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	route := findRoute(t, info, "GET", "/unresolved/dynamic")
+	if !route.HandlerUnresolved || route.Handler != `handlers["list"]` {
+		t.Errorf("无法解析的处理函数应以注册表达式占位，实际为 %q (unresolved=%v)", route.Handler, route.HandlerUnresolved)
+	}
+	if !strings.HasSuffix(route.PackagePath, "/unresolved") {
+		t.Errorf("占位路由的包应为注册路由的包，实际为 %s", route.PackagePath)
+	}
+	if findRoute(t, info, "GET", "/unresolved/list").HandlerUnresolved {
+		t.Errorf("可以解析的处理函数不应标记为无法解析")
+	}
+
+	found := false
+	for _, warning := range info.Warnings {
+		if strings.Contains(warning, "GET /unresolved/dynamic") && strings.Contains(warning, `handlers["list"]`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("应记录无法解析处理函数的警告，实际为 %v", info.Warnings)
+	}
+
+	if operation := swaggerOperation(t, info, "GET", "/unresolved/dynamic"); !strings.Contains(operation.Description, "无法解析处理函数") {
+		t.Errorf("Swagger 操作应提示处理函数无法解析，实际描述为 %q", operation.Description)
+	}
+}
+
+func TestDocSummary(t *testing.T) {
+	tests := []struct {
+		doc, summary, description string
+	}{
+		{"// GetUser 获取用户信息", "获取用户信息", ""},
+		{"// GetUser returns the user. It reads the id\n// from the path.", "returns the user.", "It reads the id\nfrom the path."},
+		{"// GetUser 获取用户。\n// @Summary 注解\n// Deprecated: 使用 v2", "获取用户。", ""},
+		{"// GetUserByID 按ID查询", "GetUserByID 按ID查询", ""},
+		{"// GetUser", "", ""},
+	}
+	for _, tt := range tests {
+		src := "package p\n\n" + tt.doc + "\nfunc GetUser() {}\n"
+		file, err := goparser.ParseFile(token.NewFileSet(), "p.go", src, goparser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		summary, description := docSummary(file.Decls[0].(*ast.FuncDecl))
+		if summary != tt.summary || description != tt.description {
+			t.Errorf("docSummary(%q) = %q, %q，应为 %q, %q", tt.doc, summary, description, tt.summary, tt.description)
+		}
+	}
+}
+
+func TestMultiLineHandlerDoc(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	operation := swaggerOperation(t, info, "GET", "/docsummary/orders")
+	if operation.Summary != "分页查询订单列表。" {
+		t.Errorf("摘要应为文档注释的第一句，实际为 %q", operation.Summary)
+	}
+	// Swagger 描述在文档注释之后附加处理函数信息
+	if want := "按创建时间倒序返回，\n每页最多 100 条。\n\n已取消的订单不会返回。"; !strings.HasPrefix(operation.Description, want+"\n") {
+		t.Errorf("描述应以第一句之后的部分开头，实际为 %q", operation.Description)
+	}
+
+	// 没有文档注释时使用生成的摘要
+	if summary := swaggerOperation(t, info, "GET", "/docsummary/undocumented").Summary; summary != "GET /docsummary/undocumented" {
+		t.Errorf("没有文档注释时摘要应为 GET /docsummary/undocumented，实际为 %q", summary)
+	}
+}
+
+func TestStructFieldRouter(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for path, handler := range map[string]string{
+		// 结构体字面量中保存的 gin.IRouter 字段，在方法中注册
+		"/fieldrouter/health": "Health",
+		"/fieldrouter/users":  "ListUsers",
+		// 赋值给 gin.IRoutes 字段后注册
+		"/fieldrouter/api/users": "ListUsers",
+	} {
+		if route := findRoute(t, info, "GET", path); route.Handler != handler {
+			t.Errorf("GET %s 的处理函数应为 %s，实际为 %s", path, handler, route.Handler)
+		}
+	}
+}
+
+func TestHandlerSourceLocation(t *testing.T) {
+	route := findRoute(t, analyzeFixture(t, "ginapp", Options{SourceLocation: true}), "GET", "/docsummary/orders")
+	if route.HandlerFile != "docsummary/docsummary.go" {
+		t.Errorf("处理函数源文件应为相对项目根目录的 docsummary/docsummary.go，实际为 %q", route.HandlerFile)
+	}
+	if route.HandlerStartLine != 11 || route.HandlerEndLine != 13 {
+		t.Errorf("处理函数行号应为 11-13，实际为 %d-%d", route.HandlerStartLine, route.HandlerEndLine)
+	}
+	data, err := json.Marshal(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"handler_file":"docsummary/docsummary.go"`) {
+		t.Errorf("JSON 输出应包含 handler_file，实际为 %s", data)
+	}
+
+	// 默认不输出源文件
+	if plain := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/docsummary/orders"); plain.HandlerFile != "" {
+		t.Errorf("未开启时不应设置处理函数源文件，实际为 %q", plain.HandlerFile)
+	}
+}
+
+func TestMultipleRootRouters(t *testing.T) {
+	info := analyzeFixture(t, "twoengines", Options{})
+
+	servers := make(map[string]string)
+	for _, route := range info.Routes {
+		if route.Path == "/health" {
+			servers[route.Server] = route.Handler
+		}
+	}
+	if len(servers) != 2 || servers["api"] != "Health" || servers["admin"] != "Health" {
+		t.Fatalf("两个服务的同名路由都应保留并标记所属服务，实际为 %v", servers)
+	}
+	if users := findRoute(t, info, "GET", "/users"); users.Server != "api" {
+		t.Errorf("/users 应属于 api 服务，实际为 %q", users.Server)
+	}
+	if stats := findRoute(t, info, "GET", "/stats"); stats.Server != "admin" {
+		t.Errorf("/stats 应属于 admin 服务，实际为 %q", stats.Server)
+	}
+}
+
+func TestDedupIdenticalAnonymousSchemas(t *testing.T) {
+	info := &models.APIInfo{}
+	for _, route := range analyzeFixture(t, "ginapp", Options{}).Routes {
+		if strings.HasPrefix(route.Path, "/dedupanon/") {
+			info.Routes = append(info.Routes, route)
+		}
+	}
+	options := exporter.DefaultOptions()
+	options.EnvelopeField = ""
+
+	doc := swaggerDocWithOptions(info, options)
+	order, _ := componentSchema(t, doc, "/dedupanon/order")
+	user, _ := componentSchema(t, doc, "/dedupanon/user")
+	if order == user {
+		t.Fatalf("未开启去重时两个匿名结构体应各自生成组件，实际都为 %s", order)
+	}
+
+	options.DedupSchemas = true
+	deduped := swaggerDocWithOptions(info, options)
+	order, schema := componentSchema(t, deduped, "/dedupanon/order")
+	if user, _ := componentSchema(t, deduped, "/dedupanon/user"); user != order {
+		t.Errorf("结构相同的匿名结构体应合并为同一组件，实际为 %s 与 %s", order, user)
+	}
+	if properties, _ := schema["properties"].(map[string]interface{}); properties["message"] == nil {
+		t.Errorf("合并后的组件应保留字段 message，实际为 %#v", schema)
+	}
+	if version, _ := componentSchema(t, deduped, "/dedupanon/version"); version == order {
+		t.Errorf("结构不同的匿名结构体不应合并")
+	}
+
+	before, _ := doc.Components["schemas"].(map[string]interface{})
+	after, _ := deduped.Components["schemas"].(map[string]interface{})
+	if len(after) != len(before)-1 {
+		t.Errorf("去重后组件数应由 %d 减少为 %d，实际为 %d", len(before), len(before)-1, len(after))
+	}
+}
+
+func TestHandlerFactoryClosure(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	route := findRoute(t, info, "GET", "/factory/product")
+	if route.Handler != "MakeGetProduct" || route.HandlerUnresolved {
+		t.Fatalf("处理函数应为工厂函数 MakeGetProduct，实际为 %q (unresolved=%v)", route.Handler, route.HandlerUnresolved)
+	}
+	requestParam(t, route, "query", "keyword")
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Product" {
+		t.Fatalf("响应应按闭包中的 c.JSON 解析为 Product，实际为 %+v", route.ResponseSchema)
+	}
+	property(t, route.ResponseSchema, "title")
+	if _, ok := route.Responses["400"]; !ok {
+		t.Errorf("应记录闭包中的 400 错误响应，实际为 %v", route.Responses)
+	}
+
+	// 返回 gin.HandlerFunc(func...) 类型转换的工厂
+	if health := findRoute(t, info, "GET", "/factory/health"); health.Handler != "MakeHealth" || health.HandlerUnresolved {
+		t.Errorf("处理函数应为工厂函数 MakeHealth，实际为 %q (unresolved=%v)", health.Handler, health.HandlerUnresolved)
+	}
+}
+
+func TestPathParamValidation(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	var warnings []string
+	for _, warning := range info.Warnings {
+		if strings.Contains(warning, "/pathcheck/") {
+			warnings = append(warnings, warning)
+		}
+	}
+	sort.Strings(warnings)
+	want := []string{
+		"GET /pathcheck/shops/:shop/items/:category: 路径参数 shop 未被处理函数读取",
+		"GET /pathcheck/users/:id: 处理函数读取的路径参数 uid 不在路由路径中",
+		"GET /pathcheck/users/:id: 路径参数 id 未被处理函数读取",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("路径参数校验的警告应为\n%s\n实际为\n%s", strings.Join(want, "\n"), strings.Join(warnings, "\n"))
+	}
+
+	// 路径中不存在的参数不作为请求参数输出
+	for _, param := range findRoute(t, info, "GET", "/pathcheck/users/:id").RequestParams {
+		if param.ParamName == "uid" {
+			t.Errorf("路径中不存在的参数 uid 应被去除，实际为 %+v", param)
+		}
+	}
+	requestParam(t, findRoute(t, info, "GET", "/pathcheck/orders/:id"), "path", "id")
+}
+
+func TestMaxRoutes(t *testing.T) {
+	proj := loadFixture(t, "twoengines")
+	analyze := func(maxRoutes int) (*models.APIInfo, error) {
+		ext, err := extractor.CreateExtractor("gin", proj)
+		if err != nil {
+			t.Fatalf("创建提取器失败: %v", err)
+		}
+		a := NewAnalyzer(filepath.Join("testdata", "twoengines"), proj, ext)
+		a.SetOptions(Options{MaxRoutes: maxRoutes})
+		return a.Analyze()
+	}
+
+	// 示例项目共 4 个路由，上限恰好为 4 时正常完成
+	if info, err := analyze(4); err != nil || len(info.Routes) != 4 {
+		t.Fatalf("路由数量未超过上限时应正常分析，实际路由 %v，错误 %v", info, err)
+	}
+
+	_, err := analyze(2)
+	if err == nil || !strings.Contains(err.Error(), "路由数量超过上限 2") {
+		t.Errorf("路由数量超过上限时应返回错误，实际为 %v", err)
+	}
+}
+
+func TestPhaseTimings(t *testing.T) {
+	proj := loadFixture(t, "ginapp")
+	ext, err := extractor.CreateExtractor("gin", proj)
+	if err != nil {
+		t.Fatalf("创建提取器失败: %v", err)
+	}
+	a := NewAnalyzer(filepath.Join("testdata", "ginapp"), proj, ext)
+	if _, err := a.Analyze(); err != nil {
+		t.Fatalf("分析示例项目失败: %v", err)
+	}
+
+	// -timing 输出的全局预处理、索引路由分组函数、递归解析路由三个阶段依次记录，处理数量均大于0
+	var phases []string
+	for _, timing := range a.Timings() {
+		phases = append(phases, timing.Phase)
+		if timing.Count <= 0 {
+			t.Errorf("阶段 %s 的处理数量应大于0，实际为 %d", timing.Phase, timing.Count)
+		}
+	}
+	if got := strings.Join(phases, ","); got != "preprocess,index,analyze" {
+		t.Errorf("记录的阶段应为 preprocess,index,analyze，实际为 %s", got)
+	}
+}
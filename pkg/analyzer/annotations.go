@@ -0,0 +1,255 @@
+package analyzer
+
+import (
+	"go/ast"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// handlerAnnotations 处理函数文档注释中的 swaggo 风格注解
+type handlerAnnotations struct {
+	Summary       string
+	Description   string
+	Params        []paramAnnotation
+	SuccessStatus int
+	SuccessDesc   string
+}
+
+// paramAnnotation @Param 注解: @Param name in type required "comment"
+type paramAnnotation struct {
+	Name     string
+	In       string // query, path, header, body, formData
+	Type     string
+	Required bool
+	Comment  string
+}
+
+// swaggo 参数类型到schema类型的映射，其他类型（如 dto.CreateReq）视为对象
+var annotationParamTypes = map[string]string{
+	"string":  "string",
+	"integer": "integer",
+	"int":     "integer",
+	"number":  "number",
+	"float":   "number",
+	"boolean": "boolean",
+	"bool":    "boolean",
+	"array":   "array",
+	"object":  "object",
+	"file":    "file",
+}
+
+// parseAnnotations 解析处理函数文档注释中的 @Summary、@Description、@Param、@Success 注解，没有注解时返回nil
+func parseAnnotations(funcDecl *ast.FuncDecl) *handlerAnnotations {
+	if funcDecl.Doc == nil {
+		return nil
+	}
+
+	var annotations handlerAnnotations
+	found := false
+	var descriptions []string
+	for _, line := range strings.Split(funcDecl.Doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		name, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(name) {
+		case "@summary":
+			annotations.Summary = value
+		case "@description":
+			// 多行 @Description 按顺序拼接
+			descriptions = append(descriptions, value)
+		case "@param":
+			param, ok := parseParamAnnotation(value)
+			if !ok {
+				log.Printf("[DEBUG] 无法解析 @Param 注解: %s\n", value)
+				continue
+			}
+			annotations.Params = append(annotations.Params, param)
+		case "@success":
+			fields := strings.Fields(value)
+			if len(fields) == 0 {
+				continue
+			}
+			if status, err := strconv.Atoi(fields[0]); err == nil {
+				annotations.SuccessStatus = status
+			}
+			annotations.SuccessDesc = quotedComment(value)
+		default:
+			continue
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	annotations.Description = strings.Join(descriptions, "\n")
+	return &annotations
+}
+
+// parseParamAnnotation 解析 @Param 注解的值部分，如 id path int true "用户ID"
+func parseParamAnnotation(value string) (paramAnnotation, bool) {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return paramAnnotation{}, false
+	}
+	required, err := strconv.ParseBool(fields[3])
+	if err != nil {
+		return paramAnnotation{}, false
+	}
+	return paramAnnotation{
+		Name:     fields[0],
+		In:       fields[1],
+		Type:     fields[2],
+		Required: required,
+		Comment:  quotedComment(value),
+	}, true
+}
+
+// quotedComment 返回注解值中双引号包裹的说明文字
+func quotedComment(value string) string {
+	start := strings.Index(value, `"`)
+	if start < 0 {
+		return ""
+	}
+	end := strings.LastIndex(value, `"`)
+	if end <= start {
+		return ""
+	}
+	return value[start+1 : end]
+}
+
+// applyAnnotations 将注解合并到推断出的路由信息中：摘要、描述和参数以注解为准，
+// 成功状态码和响应说明仅在无法推断时使用注解
+func applyAnnotations(route *models.RouteInfo, annotations *handlerAnnotations) {
+	if annotations == nil {
+		return
+	}
+	if annotations.Summary != "" {
+		route.Summary = annotations.Summary
+	}
+	if annotations.Description != "" {
+		route.Description = annotations.Description
+	}
+	if route.SuccessStatus == 0 {
+		route.SuccessStatus = annotations.SuccessStatus
+	}
+	if route.ResponseDescription == "" {
+		route.ResponseDescription = annotations.SuccessDesc
+	}
+
+	for _, annotation := range annotations.Params {
+		switch annotation.In {
+		case "body":
+			applyBodyAnnotation(route, annotation)
+		case "formData":
+			applyFormAnnotation(route, annotation)
+		case "query", "path", "header":
+			applyParamAnnotation(route, annotation)
+		default:
+			log.Printf("[DEBUG] 不支持的 @Param 位置: %s\n", annotation.In)
+		}
+	}
+}
+
+// applyParamAnnotation 合并查询、路径、请求头参数，推断结果中不存在的参数按注解补充
+func applyParamAnnotation(route *models.RouteInfo, annotation paramAnnotation) {
+	for i := range route.RequestParams {
+		param := &route.RequestParams[i]
+		if param.ParamType != annotation.In || param.ParamName != annotation.Name {
+			continue
+		}
+		param.IsRequired = annotation.Required
+		param.ParamSchema = annotatedSchema(param.ParamSchema, annotation)
+		return
+	}
+	route.RequestParams = append(route.RequestParams, models.RequestParamInfo{
+		ParamType:   annotation.In,
+		ParamName:   annotation.Name,
+		ParamSchema: annotatedSchema(nil, annotation),
+		IsRequired:  annotation.Required,
+		Source:      "@Param",
+	})
+}
+
+// applyBodyAnnotation 合并请求体参数：请求体结构以推断结果为准，注解提供是否必需和说明
+func applyBodyAnnotation(route *models.RouteInfo, annotation paramAnnotation) {
+	for i := range route.RequestParams {
+		param := &route.RequestParams[i]
+		if param.ParamType != "body" || isFormBody(param.ContentType) {
+			continue
+		}
+		param.IsRequired = annotation.Required
+		if annotation.Comment != "" && param.ParamSchema != nil {
+			schema := *param.ParamSchema
+			schema.Description = annotation.Comment
+			param.ParamSchema = &schema
+		}
+		return
+	}
+	route.RequestParams = append(route.RequestParams, models.RequestParamInfo{
+		ParamType:   "body",
+		ParamName:   annotation.Name,
+		ParamSchema: annotatedSchema(nil, annotation),
+		IsRequired:  annotation.Required,
+		Source:      "@Param",
+		ContentType: "application/json",
+	})
+}
+
+// applyFormAnnotation 为表单请求体中同名字段补充说明，字段不存在时忽略
+func applyFormAnnotation(route *models.RouteInfo, annotation paramAnnotation) {
+	for i := range route.RequestParams {
+		param := &route.RequestParams[i]
+		if param.ParamType != "body" || !isFormBody(param.ContentType) || param.ParamSchema == nil {
+			continue
+		}
+		for key, prop := range param.ParamSchema.Properties {
+			if prop == nil || (key != annotation.Name && prop.FormTag != annotation.Name && prop.JSONTag != annotation.Name) {
+				continue
+			}
+			schema := *param.ParamSchema
+			schema.Properties = make(map[string]*models.APISchema, len(param.ParamSchema.Properties))
+			for k, v := range param.ParamSchema.Properties {
+				schema.Properties[k] = v
+			}
+			schema.Properties[key] = annotatedSchema(prop, annotation)
+			param.ParamSchema = &schema
+			return
+		}
+	}
+	log.Printf("[DEBUG] 表单字段 %s 不在推断的请求体中，已忽略 @Param 注解\n", annotation.Name)
+}
+
+// annotatedSchema 按注解更新参数结构（复制一份，避免修改共享的结构）：
+// 注解类型为基本类型时覆盖推断的类型，说明文字覆盖推断的描述
+func annotatedSchema(schema *models.APISchema, annotation paramAnnotation) *models.APISchema {
+	var annotated models.APISchema
+	if schema != nil {
+		annotated = *schema
+	}
+	if typ, ok := annotationParamTypes[annotation.Type]; ok {
+		if typ == "file" {
+			annotated.Type, annotated.Format = "string", "binary"
+		} else if typ != annotated.Type {
+			annotated.Type, annotated.Format = typ, ""
+			annotated.Properties, annotated.Items = nil, nil
+		}
+	} else if annotated.Type == "" {
+		annotated.Type = "object"
+	}
+	if annotation.Comment != "" {
+		annotated.Description = annotation.Comment
+	}
+	return &annotated
+}
+
+// isFormBody 请求体是否为表单格式
+func isFormBody(contentType string) bool {
+	return contentType == "application/x-www-form-urlencoded" || contentType == "multipart/form-data"
+}
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	src := `package p
+
+// Handler 处理函数
+// @Summary 摘要
+// @Description 第一行
+// @Description 第二行
+// @Param id path int true "用户ID"
+// @Param bad path
+// @Success 201 {object} User "已创建"
+func Handler() {}
+
+// Plain 没有注解
+func Plain() {}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations := parseAnnotations(file.Decls[0].(*ast.FuncDecl))
+	if annotations == nil {
+		t.Fatal("应解析出注解")
+	}
+	if annotations.Summary != "摘要" || annotations.Description != "第一行\n第二行" {
+		t.Errorf("摘要或描述不正确: %+v", annotations)
+	}
+	if annotations.SuccessStatus != 201 || annotations.SuccessDesc != "已创建" {
+		t.Errorf("@Success 解析不正确: %+v", annotations)
+	}
+	// 格式错误的 @Param 被忽略
+	if len(annotations.Params) != 1 {
+		t.Fatalf("应解析出 1 个 @Param，实际为 %+v", annotations.Params)
+	}
+	want := paramAnnotation{Name: "id", In: "path", Type: "int", Required: true, Comment: "用户ID"}
+	if annotations.Params[0] != want {
+		t.Errorf("@Param 应为 %+v，实际为 %+v", want, annotations.Params[0])
+	}
+
+	if plain := parseAnnotations(file.Decls[1].(*ast.FuncDecl)); plain != nil {
+		t.Errorf("没有注解时应返回 nil，实际为 %+v", plain)
+	}
+}
+
+func TestAnnotationsMergedIntoRoute(t *testing.T) {
+	route := findRoute(t, analyzeFixture(t, "ginapp", Options{Annotations: true}), "GET", "/annotated/users/:id")
+	if route.Summary != "查询用户详情" || route.Description != "按ID查询用户" {
+		t.Errorf("摘要和描述应以注解为准，实际为 %q / %q", route.Summary, route.Description)
+	}
+
+	id := requestParam(t, route, "path", "id")
+	if id.ParamSchema == nil || id.ParamSchema.Type != "integer" || id.ParamSchema.Description != "用户ID" {
+		t.Errorf("路径参数 id 应按注解改为 integer 并带说明，实际为 %+v", id.ParamSchema)
+	}
+	verbose := requestParam(t, route, "query", "verbose")
+	if verbose.Source != "@Param" || verbose.IsRequired || verbose.ParamSchema.Type != "boolean" {
+		t.Errorf("推断结果中没有的 verbose 应按注解补充，实际为 %+v", verbose)
+	}
+	// 推断出的参数保留
+	requestParam(t, route, "query", "lang")
+
+	// 未开启时使用文档注释的摘要，不合并注解
+	plain := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/annotated/users/:id")
+	if plain.Summary != "获取用户" {
+		t.Errorf("未开启注解时摘要应取自文档注释，实际为 %q", plain.Summary)
+	}
+	for _, param := range plain.RequestParams {
+		if param.Source == "@Param" {
+			t.Errorf("未开启注解时不应合并 @Param: %+v", param)
+		}
+	}
+}
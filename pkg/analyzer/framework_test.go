@@ -0,0 +1,129 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+func TestBeegoControllerDataJSONResponse(t *testing.T) {
+	info := analyzeFixture(t, "beegoapp", Options{})
+	route := findRoute(t, info, "GET", "/user")
+
+	if route.Handler != "UserController.Get" {
+		t.Errorf("处理函数应为 UserController.Get，实际为 %s", route.Handler)
+	}
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "User" {
+		t.Fatalf("this.Data[\"json\"] 赋值的 User 应作为响应，实际为 %+v", route.ResponseSchema)
+	}
+	property(t, route.ResponseSchema, "name")
+}
+
+func TestDataJSONAssignmentIgnoredOutsideBeego(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/beegodata/status")
+
+	schema := route.ResponseSchema
+	if schema == nil || len(schema.OneOf) != 0 || schema.Type == "User" {
+		t.Fatalf("gin 处理函数中 store.Data[\"json\"] 的赋值不应作为响应，实际为 %+v", schema)
+	}
+	property(t, schema, "ok")
+}
+
+func TestWebSocketUpgradeRoute(t *testing.T) {
+	info := analyzeFixture(t, "wsapp", Options{})
+
+	chat := findRoute(t, info, "GET", "/ws/chat")
+	if chat.Kind != models.RouteKindWebSocket {
+		t.Errorf("调用 Upgrader.Upgrade 的路由应标记为 websocket，实际为 %q", chat.Kind)
+	}
+	if chat.ResponseSchema != nil {
+		t.Errorf("WebSocket 路由不应分析响应体，实际为 %+v", chat.ResponseSchema)
+	}
+	if !swaggerOperation(t, info, "GET", "/ws/chat").WebSocket {
+		t.Errorf("Swagger 操作应输出 x-websocket: true")
+	}
+
+	if ping := findRoute(t, info, "GET", "/ping"); ping.Kind != "" {
+		t.Errorf("普通路由不应标记类型，实际为 %q", ping.Kind)
+	}
+}
+
+func TestRedirectRoute(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	// c.Redirect 及项目内函数中的 http.Redirect
+	for _, path := range []string{"/redirect/login", "/redirect/legacy"} {
+		if route := findRoute(t, info, "GET", path); route.Kind != models.RouteKindRedirect || route.ResponseSchema != nil {
+			t.Errorf("%s 只重定向，应标记为 redirect，实际为 %q %+v", path, route.Kind, route.ResponseSchema)
+		}
+	}
+	// 成功时返回JSON的接口不算只重定向
+	if callback := findRoute(t, info, "GET", "/redirect/callback"); callback.Kind != "" || callback.ResponseSchema == nil {
+		t.Errorf("有JSON响应的接口不应标记为 redirect，实际为 %q %+v", callback.Kind, callback.ResponseSchema)
+	}
+}
+
+func TestReplacedGinModulePath(t *testing.T) {
+	// Gin 模块经 replace 改写为 example.com/mirror/github.com/gin-gonic/gin
+	info := analyzeFixture(t, "forkginapp", Options{})
+
+	route := findRoute(t, info, "GET", "/api/users/:id")
+	if route.Handler != "GetUser" {
+		t.Errorf("处理函数应为 GetUser，实际为 %s", route.Handler)
+	}
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "User" {
+		t.Fatalf("响应应为 User，实际为 %+v", route.ResponseSchema)
+	}
+	property(t, route.ResponseSchema, "name")
+	requestParam(t, route, "path", "id")
+}
+
+func TestIrisRequestParams(t *testing.T) {
+	info := analyzeFixture(t, "irisapp", Options{})
+
+	// ctx.ReadJSON 的请求体与 gin 一样按 json 标签、必填和注释解析
+	body := requestParam(t, findRoute(t, info, "POST", "/users"), "body", "request_body")
+	if body.ParamSchema == nil || body.ParamSchema.Type != "CreateUserReq" || body.ContentType != "application/json" {
+		t.Fatalf("请求体应为 JSON 格式的 CreateUserReq，实际为 %s %+v", body.ContentType, body.ParamSchema)
+	}
+	name := property(t, body.ParamSchema, "name")
+	if !name.Required || name.Description != "用户名" {
+		t.Errorf("name 应为必填并带有注释，实际为 %+v", name)
+	}
+	property(t, body.ParamSchema, "email")
+
+	// ctx.ReadQuery 按 url 标签展开，ctx.URLParam* 为单个查询参数
+	list := findRoute(t, info, "GET", "/users")
+	for name, typ := range map[string]string{"page": "integer", "sort": "string", "keyword": "string", "limit": "integer"} {
+		if param := requestParam(t, list, "query", name); param.ParamSchema == nil || param.ParamSchema.Type != typ {
+			t.Errorf("查询参数 %s 应为 %s，实际为 %+v", name, typ, param.ParamSchema)
+		}
+	}
+	if page := requestParam(t, list, "query", "page"); page.ParamSchema.Default != int64(1) {
+		t.Errorf("查询参数 page 的默认值应为 1，实际为 %#v", page.ParamSchema.Default)
+	}
+}
+
+func TestIrisResponseWrapper(t *testing.T) {
+	info := analyzeFixture(t, "irisapp", Options{})
+
+	route := findRoute(t, info, "POST", "/users")
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Response" {
+		t.Fatalf("响应应为封装函数 RespondOK 中的 Response，实际为 %+v", route.ResponseSchema)
+	}
+	data := property(t, route.ResponseSchema, "data")
+	if data.Type != "User" {
+		t.Fatalf("data 应展开为传入 RespondOK 的 User，实际为 %+v", data)
+	}
+	property(t, data, "name")
+
+	if users := property(t, findRoute(t, info, "GET", "/users").ResponseSchema, "data"); users.Type != "array" || users.Items == nil || users.Items.Type != "User" {
+		t.Errorf("GET /users 的 data 应为 User 数组，实际为 %+v", users)
+	}
+
+	// 直接调用 ctx.JSON(v) 时数据为第一个参数
+	if user := findRoute(t, info, "GET", "/user"); user.ResponseSchema == nil || user.ResponseSchema.Type != "User" {
+		t.Errorf("ctx.JSON(User{}) 的响应应为 User，实际为 %+v", user.ResponseSchema)
+	}
+}
@@ -0,0 +1,212 @@
+package analyzer
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sinceDateLayout 路由 since 日期的格式
+const sinceDateLayout = "2006-01-02"
+
+// gitHistory 通过 git log -L 查询处理函数的引入时间，同一行范围只查询一次
+type gitHistory struct {
+	root   string                                                     // 仓库根目录
+	log    func(file string, startLine, endLine int) ([]int64, error) // 返回修改过该行范围（沿历史追踪行的移动）的各提交的作者时间（Unix秒），file 相对仓库根目录，行号按 HEAD 中的版本
+	diff   func(file string) ([]diffHunk, error)                      // 返回工作区文件相对 HEAD 的修改，file 相对仓库根目录
+	hunks  map[string][]diffHunk                                      // 源文件（相对仓库根目录） -> 未提交的修改，每个文件只执行一次 git diff
+	ranges map[string]string                                          // "文件:起始行,结束行" -> since 日期
+}
+
+// diffHunk git diff -U0 的修改块：HEAD 中从 oldStart 开始的 oldCount 行替换为工作区中从 newStart 开始的 newCount 行
+type diffHunk struct {
+	oldStart, oldCount int
+	newStart, newCount int
+}
+
+// newGitHistory 创建项目目录所在仓库的git历史查询，目录不在git仓库中或没有安装git时返回nil
+func newGitHistory(dir string) *gitHistory {
+	output, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		log.Printf("[DEBUG] 目录 %s 不在git仓库中: %v\n", dir, err)
+		return nil
+	}
+	history := &gitHistory{
+		root:   strings.TrimSpace(string(output)),
+		hunks:  make(map[string][]diffHunk),
+		ranges: make(map[string]string),
+	}
+	history.log = history.gitLog
+	history.diff = history.gitDiff
+	return history
+}
+
+// since 处理函数（工作区中的起止行号）的引入日期：最早修改过这些行的提交的作者日期，即函数首次提交的时间，
+// 之后函数体被整体改写也不影响。文件有未提交的修改时先按 git diff 将行号换算为 HEAD 中的行号，
+// 范围首尾新增的行不计入；函数整体未提交、文件不在仓库中或 git 命令失败时返回空字符串
+func (h *gitHistory) since(file string, startLine, endLine int) string {
+	key := fmt.Sprintf("%s:%d,%d", file, startLine, endLine)
+	if since, ok := h.ranges[key]; ok {
+		return since
+	}
+	since := h.lookupSince(file, startLine, endLine)
+	h.ranges[key] = since
+	return since
+}
+
+func (h *gitHistory) lookupSince(file string, startLine, endLine int) string {
+	relPath, ok := h.repoPath(file)
+	if !ok {
+		return ""
+	}
+	hunks, ok := h.hunks[relPath]
+	if !ok {
+		var err error
+		if hunks, err = h.diff(relPath); err != nil {
+			// 无法换算行号时不标注，避免取到其他代码的日期
+			log.Printf("[DEBUG] git diff %s 失败: %v\n", relPath, err)
+			return ""
+		}
+		h.hunks[relPath] = hunks
+	}
+	headStart, headEnd, ok := headLineRange(hunks, startLine, endLine)
+	if !ok {
+		return ""
+	}
+
+	times, err := h.log(relPath, headStart, headEnd)
+	if err != nil {
+		log.Printf("[DEBUG] git log -L %d,%d:%s 失败: %v\n", headStart, headEnd, relPath, err)
+	}
+	var earliest int64
+	for _, t := range times {
+		if t > 0 && (earliest == 0 || t < earliest) {
+			earliest = t
+		}
+	}
+	if earliest == 0 {
+		return ""
+	}
+	return time.Unix(earliest, 0).UTC().Format(sinceDateLayout)
+}
+
+// repoPath 源文件相对仓库根目录的路径（解析符号链接后比较，源文件路径可能经过链接）
+func (h *gitHistory) repoPath(file string) (string, bool) {
+	root, err := filepath.EvalSymlinks(h.root)
+	if err != nil {
+		return "", false
+	}
+	resolved, err := filepath.EvalSymlinks(file)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// headLineRange 将工作区的行范围换算为 HEAD 中的行范围：跳过范围首尾未提交（新增或修改）的行，
+// 范围内没有已提交的行时返回false
+func headLineRange(hunks []diffHunk, startLine, endLine int) (int, int, bool) {
+	for ; startLine <= endLine; startLine++ {
+		if _, ok := headLine(hunks, startLine); ok {
+			break
+		}
+	}
+	for ; endLine >= startLine; endLine-- {
+		if _, ok := headLine(hunks, endLine); ok {
+			break
+		}
+	}
+	if startLine > endLine {
+		return 0, 0, false
+	}
+	headStart, _ := headLine(hunks, startLine)
+	headEnd, _ := headLine(hunks, endLine)
+	return headStart, headEnd, true
+}
+
+// headLine 工作区中的行在 HEAD 中的行号，该行未提交（位于修改块中）时返回false
+func headLine(hunks []diffHunk, line int) (int, bool) {
+	offset := 0
+	for _, hunk := range hunks {
+		if hunk.newCount != 0 {
+			if line < hunk.newStart {
+				break
+			}
+			if line < hunk.newStart+hunk.newCount {
+				return 0, false
+			}
+		} else if line <= hunk.newStart {
+			// 只删除行的修改块位于工作区第 newStart 行之后
+			break
+		}
+		offset += hunk.oldCount - hunk.newCount
+	}
+	return line + offset, true
+}
+
+// gitLog 执行 git log -L，只输出修改过该行范围的各提交的作者时间
+func (h *gitHistory) gitLog(file string, startLine, endLine int) ([]int64, error) {
+	lineRange := fmt.Sprintf("%d,%d:%s", startLine, endLine, file)
+	output, err := exec.Command("git", "-C", h.root, "log", "-L", lineRange, "--format=%at", "--no-patch").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseAuthorTimes(output), nil
+}
+
+// gitDiff 执行 git diff -U0 HEAD，解析工作区文件相对 HEAD 的修改块；文件没有修改时返回空
+func (h *gitHistory) gitDiff(file string) ([]diffHunk, error) {
+	output, err := exec.Command("git", "-C", h.root, "diff", "--no-ext-diff", "-U0", "HEAD", "--", file).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseDiffHunks(output), nil
+}
+
+// parseAuthorTimes 解析每行一个的作者时间（--format=%at），忽略空行
+func parseAuthorTimes(output []byte) []int64 {
+	var times []int64
+	for _, line := range strings.Fields(string(output)) {
+		if t, err := strconv.ParseInt(line, 10, 64); err == nil {
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
+// hunkHeaderPattern 修改块的头部，如 "@@ -12,3 +12,5 @@"，省略行数时为1
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parseDiffHunks 解析 git diff 输出中的修改块头部
+func parseDiffHunks(output []byte) []diffHunk {
+	hunks := []diffHunk{}
+	count := func(value string) int {
+		if value == "" {
+			return 1
+		}
+		n, _ := strconv.Atoi(value)
+		return n
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		match := hunkHeaderPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		oldStart, _ := strconv.Atoi(match[1])
+		newStart, _ := strconv.Atoi(match[3])
+		hunks = append(hunks, diffHunk{
+			oldStart: oldStart, oldCount: count(match[2]),
+			newStart: newStart, newCount: count(match[4]),
+		})
+	}
+	return hunks
+}
@@ -0,0 +1,172 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAuthorTimes(t *testing.T) {
+	output := "1704067200\n\n1700000000\nnot-a-time\n"
+	if got := parseAuthorTimes([]byte(output)); !reflect.DeepEqual(got, []int64{1704067200, 1700000000}) {
+		t.Errorf("应解析每行的作者时间并忽略空行，实际为 %v", got)
+	}
+}
+
+func TestParseDiffHunks(t *testing.T) {
+	output := strings.Join([]string{
+		"diff --git a/handler.go b/handler.go",
+		"--- a/handler.go",
+		"+++ b/handler.go",
+		"@@ -2,0 +3,2 @@ package app",
+		"+// 新增的注释",
+		"+",
+		"@@ -10 +12 @@ func GetUser() {",
+		"-\treturn 1",
+		"+\treturn 2",
+		"@@ -20,3 +21,0 @@",
+	}, "\n")
+	want := []diffHunk{{2, 0, 3, 2}, {10, 1, 12, 1}, {20, 3, 21, 0}}
+	if got := parseDiffHunks([]byte(output)); !reflect.DeepEqual(got, want) {
+		t.Errorf("修改块应为 %v，实际为 %v", want, got)
+	}
+}
+
+func TestHeadLineRange(t *testing.T) {
+	tests := []struct {
+		name             string
+		hunks            []diffHunk
+		start, end       int
+		wantStart, wantE int
+		ok               bool
+	}{
+		{"没有修改", nil, 5, 9, 5, 9, true},
+		{"上方新增两行", []diffHunk{{2, 0, 3, 2}}, 7, 11, 5, 9, true},
+		{"上方删除三行", []diffHunk{{2, 3, 1, 0}}, 5, 9, 8, 12, true},
+		{"范围内修改一行", []diffHunk{{7, 1, 7, 1}}, 5, 9, 5, 9, true},
+		{"首尾是新增的行", []diffHunk{{4, 0, 5, 1}, {8, 0, 10, 1}}, 5, 10, 5, 8, true},
+		{"下方的修改不影响", []diffHunk{{20, 0, 20, 5}}, 5, 9, 5, 9, true},
+		{"整个函数未提交", []diffHunk{{30, 0, 31, 6}}, 31, 36, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := headLineRange(tt.hunks, tt.start, tt.end)
+			if start != tt.wantStart || end != tt.wantE || ok != tt.ok {
+				t.Errorf("headLineRange(%d, %d) = %d, %d, %v，应为 %d, %d, %v",
+					tt.start, tt.end, start, end, ok, tt.wantStart, tt.wantE, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGitHistorySince(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"handler.go", "dirty.go", "empty.go", "broken.go"} {
+		if err := os.WriteFile(filepath.Join(root, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var logged []string
+	history := &gitHistory{
+		root:   root,
+		hunks:  make(map[string][]diffHunk),
+		ranges: make(map[string]string),
+		diff: func(file string) ([]diffHunk, error) {
+			if file == "dirty.go" {
+				return []diffHunk{{0, 0, 1, 3}}, nil // 文件开头新增了三行
+			}
+			return nil, nil
+		},
+		log: func(file string, startLine, endLine int) ([]int64, error) {
+			logged = append(logged, fmt.Sprintf("%s:%d,%d", file, startLine, endLine))
+			switch file {
+			case "broken.go":
+				return nil, errors.New("no such path in HEAD")
+			case "empty.go":
+				return nil, nil
+			}
+			// 2024-01-01、2023-11-14（git log 从新到旧输出）
+			return []int64{1704067200, 1700000000}, nil
+		},
+	}
+
+	// 取修改过该范围的最早提交的日期
+	if got := history.since(filepath.Join(root, "handler.go"), 1, 3); got != "2023-11-14" {
+		t.Errorf("应取最早提交的日期 2023-11-14，实际为 %q", got)
+	}
+	history.since(filepath.Join(root, "handler.go"), 1, 3)
+	// 未提交的修改使行号偏移时按 HEAD 中的行号查询
+	history.since(filepath.Join(root, "dirty.go"), 5, 8)
+	if want := []string{"handler.go:1,3", "dirty.go:2,5"}; !reflect.DeepEqual(logged, want) {
+		t.Errorf("应以相对仓库根目录的路径和 HEAD 中的行号查询一次，实际为 %v", logged)
+	}
+	if got := history.since(filepath.Join(root, "dirty.go"), 2, 3); got != "" {
+		t.Errorf("范围内的行均未提交时应为空，实际为 %q", got)
+	}
+	if got := history.since(filepath.Join(root, "empty.go"), 1, 2); got != "" {
+		t.Errorf("没有提交时应为空，实际为 %q", got)
+	}
+	if got := history.since(filepath.Join(root, "broken.go"), 1, 2); got != "" {
+		t.Errorf("git log 失败时应为空，实际为 %q", got)
+	}
+	if got := history.since(filepath.Join(t.TempDir(), "outside.go"), 1, 2); got != "" {
+		t.Errorf("仓库外的文件应为空，实际为 %q", got)
+	}
+}
+
+func TestGitHistorySinceWorkingTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("未安装git")
+	}
+	dir := t.TempDir()
+	run := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com", "GIT_COMMITTER_DATE="+date)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v 失败: %v\n%s", args, err, output)
+		}
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "handler.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("2020-03-01T00:00:00Z", "init", "-q")
+	write("package app\n\nfunc GetUser() string {\n\treturn \"v1\"\n}\n")
+	run("2020-03-01T00:00:00Z", "add", "handler.go")
+	run("2020-03-01T00:00:00Z", "commit", "-q", "-m", "add GetUser")
+	// 之后改写了函数的每一行，git blame 只能看到最后一次修改
+	write("package app\n\nfunc GetUser() (string, error) {\n\treturn \"v2\", nil\n}\n")
+	run("2024-06-01T00:00:00Z", "commit", "-q", "-am", "rewrite GetUser")
+	// 未提交的修改：上方新增了函数，文件比已提交的版本更长
+	write("package app\n\nfunc Ping() string {\n\treturn \"pong\"\n}\n\nfunc GetUser() (string, error) {\n\treturn \"v2\", nil\n}\n")
+
+	// 通过符号链接访问项目目录，源文件路径与 git 输出的仓库根目录不同
+	link := filepath.Join(t.TempDir(), "project")
+	if err := os.Symlink(dir, link); err != nil {
+		t.Skipf("无法创建符号链接: %v", err)
+	}
+	history := newGitHistory(link)
+	if history == nil {
+		t.Fatal("临时仓库应能查询git历史")
+	}
+	file := filepath.Join(link, "handler.go")
+	if got := history.since(file, 7, 9); got != "2020-03-01" {
+		t.Errorf("since 应为函数首次提交的日期 2020-03-01，实际为 %q", got)
+	}
+	if got := history.since(file, 3, 5); got != "" {
+		t.Errorf("未提交的函数不应标注 since，实际为 %q", got)
+	}
+}
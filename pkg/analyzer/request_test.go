@@ -0,0 +1,234 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// requestParam 按位置和名称查找请求参数，找不到时测试失败
+func requestParam(t *testing.T, route models.RouteInfo, paramType, name string) models.RequestParamInfo {
+	t.Helper()
+	for _, param := range route.RequestParams {
+		if param.ParamType == paramType && param.ParamName == name {
+			return param
+		}
+	}
+	t.Fatalf("路由 %s %s 没有 %s 参数 %s，已有参数: %+v", route.Method, route.Path, paramType, name, route.RequestParams)
+	return models.RequestParamInfo{}
+}
+
+func TestAliasedGinImport(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/aliasimport/item")
+
+	if route.Handler != "GetItem" {
+		t.Errorf("处理函数应为 GetItem，实际为 %s", route.Handler)
+	}
+	requestParam(t, route, "query", "q")
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Item" {
+		t.Fatalf("响应应为 Item，实际为 %+v", route.ResponseSchema)
+	}
+}
+
+func TestSliceBindRequestBody(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "POST", "/slicebind/batch")
+
+	body := requestParam(t, route, "body", "request_body").ParamSchema
+	if body == nil || body.Type != "array" || body.Items == nil || body.Items.Type != "CreateReq" {
+		t.Fatalf("请求体应为 CreateReq 数组，实际为 %+v", body)
+	}
+
+	operation := swaggerOperation(t, info, "POST", "/slicebind/batch")
+	if operation.RequestBody == nil {
+		t.Fatal("Swagger 操作缺少 requestBody")
+	}
+	schema := operation.RequestBody.Content["application/json"].Schema
+	items, _ := schema["items"].(map[string]interface{})
+	if schema["type"] != "array" || items["$ref"] != "#/components/schemas/CreateReq" {
+		t.Errorf("requestBody 应为 {type: array, items: {$ref: CreateReq}}，实际为 %v", schema)
+	}
+}
+
+func TestShouldBindFormContentType(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "POST", "/formbind/login")
+
+	body := requestParam(t, route, "body", "request_body")
+	if body.ContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("只有 form 标签的结构体应按表单绑定，实际为 %q", body.ContentType)
+	}
+
+	requestBody := swaggerOperation(t, info, "POST", "/formbind/login").RequestBody
+	if requestBody == nil {
+		t.Fatal("Swagger 操作缺少 requestBody")
+	}
+	if _, ok := requestBody.Content["application/x-www-form-urlencoded"]; !ok || len(requestBody.Content) != 1 {
+		t.Errorf("requestBody 应只使用表单格式，实际为 %+v", requestBody.Content)
+	}
+}
+
+func TestPathParamAccessors(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	byName := requestParam(t, findRoute(t, info, "GET", "/pathparams/users/:id"), "path", "id")
+	if byName.Source != "c.Params.ByName" || !byName.IsRequired {
+		t.Errorf("c.Params.ByName 读取的路径参数应为必填，来源为 c.Params.ByName，实际为 %+v", byName)
+	}
+
+	// 参数名为常量 orderKey = "orderID"
+	constKey := requestParam(t, findRoute(t, info, "GET", "/pathparams/orders/:orderID"), "path", "orderID")
+	if constKey.Source != "c.Param" {
+		t.Errorf("常量键的路径参数来源应为 c.Param，实际为 %q", constKey.Source)
+	}
+
+	// 路由中不存在的参数应被去除
+	item := findRoute(t, info, "GET", "/pathparams/items/:id")
+	requestParam(t, item, "path", "id")
+	for _, param := range item.RequestParams {
+		if param.ParamName == "missing" {
+			t.Errorf("路由路径中没有的参数 missing 不应输出: %+v", param)
+		}
+	}
+}
+
+func TestEmbeddedPaginationInRequestBody(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	body := requestParam(t, findRoute(t, info, "POST", "/embedreq/users"), "body", "request_body").ParamSchema
+	if body == nil || body.Type != "ListUsersRequest" {
+		t.Fatalf("请求体应为 ListUsersRequest，实际为 %+v", body)
+	}
+	// 匿名嵌入的 Pagination 与 *Filter 的字段提升到外层
+	for _, name := range []string{"page", "keyword", "status"} {
+		property(t, body, name)
+	}
+	if _, ok := body.Properties["Pagination"]; ok {
+		t.Errorf("匿名嵌入的结构体不应作为单独的字段: %+v", body.Properties)
+	}
+	if size := property(t, body, "size"); size.Type != "string" {
+		t.Errorf("同名字段应以外层的 string 类型为准，实际为 %s", size.Type)
+	}
+
+	// 带JSON名称的嵌入结构体按普通字段嵌套
+	search := requestParam(t, findRoute(t, info, "POST", "/embedreq/search"), "body", "request_body").ParamSchema
+	pagination := property(t, search, "pagination")
+	property(t, pagination, "page")
+	if _, ok := search.Properties["Page"]; ok {
+		t.Errorf("带JSON名称的嵌入结构体不应提升字段: %+v", search.Properties)
+	}
+}
+
+func TestRequiredBodyFields(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	body := requestParam(t, findRoute(t, info, "POST", "/requiredbody/users"), "body", "request_body").ParamSchema
+
+	for name, required := range map[string]bool{"name": true, "email": true, "age": false} {
+		if prop := property(t, body, name); prop.Required != required {
+			t.Errorf("字段 %s 的必填标记应为 %v，实际为 %v", name, required, prop.Required)
+		}
+	}
+}
+
+func TestBindingRequiredQueryAndURIParams(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	search := findRoute(t, info, "GET", "/querybind/search")
+	for name, required := range map[string]bool{"q": true, "page": false, "Sort": false} {
+		if param := requestParam(t, search, "query", name); param.IsRequired != required {
+			t.Errorf("查询参数 %s 的必填应为 %v，实际为 %v", name, required, param.IsRequired)
+		}
+	}
+
+	item := findRoute(t, info, "GET", "/querybind/items/:id/:rev")
+	for name, required := range map[string]bool{"id": true, "rev": false} {
+		if param := requestParam(t, item, "path", name); param.IsRequired != required {
+			t.Errorf("uri 参数 %s 的必填应为 %v，实际为 %v", name, required, param.IsRequired)
+		}
+	}
+}
+
+func TestDefaultTag(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/defaulttag/list")
+
+	for name, want := range map[string]interface{}{
+		"page_size": int64(10),
+		"ratio":     0.5,
+		"active":    true,
+		"order":     "desc",
+	} {
+		if got := requestParam(t, route, "query", name).ParamSchema.Default; got != want {
+			t.Errorf("查询参数 %s 的默认值应为 %#v，实际为 %#v", name, want, got)
+		}
+	}
+	if got := requestParam(t, route, "query", "keyword").ParamSchema.Default; got != nil {
+		t.Errorf("没有 default 标签的字段不应有默认值，实际为 %#v", got)
+	}
+
+	body := requestParam(t, findRoute(t, info, "POST", "/defaulttag/export"), "body", "request_body")
+	if got := property(t, body.ParamSchema, "limit").Default; got != int64(100) {
+		t.Errorf("请求体字段 limit 的默认值应为 100，实际为 %#v", got)
+	}
+
+	for _, param := range swaggerOperation(t, info, "GET", "/defaulttag/list").Parameters {
+		if param.Name == "page_size" {
+			if got := param.Schema["default"]; got != int64(10) {
+				t.Errorf("Swagger 参数 page_size 的 default 应为 10，实际为 %#v", got)
+			}
+			return
+		}
+	}
+	t.Errorf("Swagger 中缺少参数 page_size")
+}
+
+func TestShouldBindBodyWith(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	body := requestParam(t, findRoute(t, info, "POST", "/bodywith/create"), "body", "request_body")
+	if body.ParamSchema == nil || body.ParamSchema.Type != "BodyReq" || body.ContentType != "application/json" {
+		t.Fatalf("请求体应为 JSON 格式的 BodyReq，实际为 %s %+v", body.ContentType, body.ParamSchema)
+	}
+	property(t, body.ParamSchema, "name")
+
+	legacy := requestParam(t, findRoute(t, info, "POST", "/bodywith/legacy"), "body", "request_body")
+	if legacy.ParamSchema == nil || legacy.ParamSchema.Type != "LegacyReq" || legacy.ContentType != "application/json" {
+		t.Errorf("ShouldBindBodyWithJSON 的请求体应为 JSON 格式的 LegacyReq，实际为 %s %+v", legacy.ContentType, legacy.ParamSchema)
+	}
+
+	// 按不同绑定器多次绑定时，每种格式都出现在 Swagger 请求体中
+	requestBody := swaggerOperation(t, info, "POST", "/bodywith/import").RequestBody
+	if requestBody == nil {
+		t.Fatalf("POST /bodywith/import 应有请求体")
+	}
+	for _, contentType := range []string{"application/json", "application/x-yaml"} {
+		if _, ok := requestBody.Content[contentType]; !ok {
+			t.Errorf("请求体应包含 %s，实际为 %v", contentType, requestBody.Content)
+		}
+	}
+}
+
+func TestQueryTags(t *testing.T) {
+	queryNames := func(options Options) []string {
+		var names []string
+		for _, param := range findRoute(t, analyzeFixture(t, "ginapp", options), "GET", "/querytag/search").RequestParams {
+			if param.ParamType == "query" {
+				names = append(names, param.ParamName)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	// 默认按 gin 的 form 标签命名，没有标签的字段使用字段名
+	if got := strings.Join(queryNames(Options{}), ","); got != "PageSize,internal,keyword,sort" {
+		t.Errorf("默认应按 form 标签命名查询参数，实际为 %s", got)
+	}
+	// 优先使用 query 标签，没有时回退到 form 标签；query:"-" 的字段被忽略
+	if got := strings.Join(queryNames(Options{QueryTags: []string{"query", "form"}}), ","); got != "kw,page_size,sort" {
+		t.Errorf("应优先按 query 标签命名查询参数，实际为 %s", got)
+	}
+}
@@ -0,0 +1,941 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/helper"
+	"github.com/YogeLiu/api-tool/pkg/exporter"
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+func TestInterfaceFieldWithSingleImplementation(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/ifaceimpl/notifier")
+
+	notifier := property(t, route.ResponseSchema, "notifier")
+	if notifier.Type != "EmailNotifier" {
+		t.Fatalf("唯一实现应展开为 EmailNotifier，实际为 %s", notifier.Type)
+	}
+	property(t, notifier, "address")
+	if len(notifier.OneOf) != 0 {
+		t.Errorf("唯一实现不应生成 oneOf: %+v", notifier.OneOf)
+	}
+}
+
+func TestInterfaceFieldWithMultipleImplementations(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/ifaceimpl/shape")
+
+	shape := property(t, route.ResponseSchema, "shape")
+	if len(shape.OneOf) != 2 {
+		t.Fatalf("两个实现应生成两个 oneOf 候选，实际为 %d", len(shape.OneOf))
+	}
+	names := map[string]bool{}
+	for _, candidate := range shape.OneOf {
+		names[candidate.Type] = true
+	}
+	if !names["Circle"] || !names["Square"] {
+		t.Errorf("oneOf 候选应为 Circle 和 Square，实际为 %v", names)
+	}
+}
+
+func TestRecursiveInterfaceImplementationDepth(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	node := property(t, findRoute(t, info, "GET", "/ifaceimpl/tree").ResponseSchema, "root")
+
+	// 每层 Tree 经过结构体字段和接口实现两层递归，最多展开 maxDepth/2 层
+	levels := 0
+	for node != nil && node.Type == "Tree" {
+		levels++
+		node = node.Properties["Child"]
+	}
+	if levels == 0 || levels > 5 {
+		t.Errorf("递归的接口实现应展开 1 到 5 层，实际为 %d 层", levels)
+	}
+	if node == nil || node.Description != "max depth reached" {
+		t.Errorf("超过深度限制后应停止展开，实际为 %+v", node)
+	}
+}
+
+func TestFieldCommentsBecomeDescriptions(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/fieldcomment/profile")
+
+	for name, want := range map[string]string{"id": "用户ID", "nickname": "昵称，可重复", "age": ""} {
+		if got := property(t, route.ResponseSchema, name).Description; got != want {
+			t.Errorf("字段 %s 的描述应为 %q，实际为 %q", name, want, got)
+		}
+	}
+}
+
+func TestWrapFStdlibHandler(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/wrapf/health")
+
+	if route.HandlerAdapter != "gin.WrapF" {
+		t.Errorf("应标记适配器 gin.WrapF，实际为 %q", route.HandlerAdapter)
+	}
+	if route.Handler != "healthHandler" {
+		t.Errorf("处理函数应为被适配的 healthHandler，实际为 %s", route.Handler)
+	}
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Health" {
+		t.Fatalf("json.NewEncoder(w).Encode 的 Health 应作为响应，实际为 %+v", route.ResponseSchema)
+	}
+}
+
+func TestTypeAliasFields(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/typealias/user")
+
+	if id := property(t, route.ResponseSchema, "id"); id.Type != "integer" {
+		t.Errorf("type UserID = int64 应解析为 integer，实际为 %q", id.Type)
+	}
+	extra := property(t, route.ResponseSchema, "extra")
+	if extra.Type != "object" || len(extra.Properties) != 0 {
+		t.Errorf("type JSONMap = map[string]interface{} 应解析为任意对象，实际为 %+v", extra)
+	}
+	// 别名声明的结构体字面量类型同样读取字段注释
+	address := property(t, route.ResponseSchema, "address")
+	if city := property(t, address, "city"); city.Type != "string" || city.Description != "城市" {
+		t.Errorf("type Address = struct{...} 的字段应保留类型和注释，实际为 %+v", city)
+	}
+}
+
+func TestNamedMapStaysOpenInStrictSchemas(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/namedmap/pod")
+
+	labels := property(t, route.ResponseSchema, "labels")
+	if labels.Type != "Labels" || !labels.Map {
+		t.Fatalf("type Labels map[string]string 应标记为 map，实际为 %+v", labels)
+	}
+
+	options := exporter.DefaultOptions()
+	options.EnvelopeField = ""
+	options.StrictSchemas = true
+	doc := swaggerDocWithOptions(info, options)
+	_, pod := componentSchema(t, doc, "/namedmap/pod")
+	if pod["additionalProperties"] != false {
+		t.Errorf("字段确定的 Pod 应设置 additionalProperties: false，实际为 %v", pod)
+	}
+	schemas, _ := doc.Components["schemas"].(map[string]interface{})
+	for name, schema := range schemas {
+		if strings.HasPrefix(name, "Labels") {
+			if closed, ok := schema.(map[string]interface{})["additionalProperties"]; ok && closed == false {
+				t.Errorf("命名的 map 类型 %s 不应设置 additionalProperties: false", name)
+			}
+		}
+	}
+}
+
+func TestRawMessageIsFreeForm(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/rawjson/event")
+
+	for _, name := range []string{"payload", "extra"} {
+		field := property(t, route.ResponseSchema, name)
+		if field.Type != "any" || field.Items != nil || len(field.Properties) != 0 {
+			t.Errorf("json.RawMessage 字段 %s 应为任意JSON，实际为 %+v", name, field)
+		}
+	}
+}
+
+func TestGinJSONRenderMethods(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for _, path := range []string{"/jsonrender/indented", "/jsonrender/pure", "/jsonrender/ascii"} {
+		route := findRoute(t, info, "GET", path)
+		if route.ResponseSchema == nil || route.ResponseSchema.Type != "Item" {
+			t.Errorf("%s 的响应应为 Item，实际为 %+v", path, route.ResponseSchema)
+			continue
+		}
+		property(t, route.ResponseSchema, "name")
+	}
+
+	secure := findRoute(t, info, "GET", "/jsonrender/secure").ResponseSchema
+	if secure == nil || secure.Type != "array" || secure.Items == nil || secure.Items.Type != "Item" {
+		t.Errorf("c.SecureJSON 的响应应为 Item 数组，实际为 %+v", secure)
+	}
+}
+
+func TestJSONPResponse(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for _, path := range []string{"/jsonrender/jsonp", "/jsonrender/jsonp-render"} {
+		schema := findRoute(t, info, "GET", path).ResponseSchema
+		if schema == nil || schema.Type != "Item" {
+			t.Errorf("%s 的响应应为 Item，实际为 %+v", path, schema)
+			continue
+		}
+		property(t, schema, "name")
+		if schema.ContentType != "application/javascript" {
+			t.Errorf("%s 的内容类型应为 application/javascript，实际为 %q", path, schema.ContentType)
+		}
+	}
+
+	content := swaggerOperation(t, info, "GET", "/jsonrender/jsonp").Responses["200"].Content
+	if _, ok := content["application/javascript"]; !ok || len(content) != 1 {
+		t.Errorf("Swagger 中 JSONP 响应应只使用 application/javascript，实际为 %v", content)
+	}
+}
+
+func TestSameNamedTypesInDifferentPackages(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	account := findRoute(t, info, "GET", "/samename/account").ResponseSchema
+	profile := findRoute(t, info, "GET", "/samename/profile").ResponseSchema
+	if account == nil || profile == nil || account.Package == profile.Package {
+		t.Fatalf("同名类型应记录各自的包路径，实际为 %+v 与 %+v", account, profile)
+	}
+
+	// 不解包响应封装，直接比较两个接口引用的 schema
+	doc := swaggerDoc(info)
+	for path, field := range map[string]string{"/samename/account": "username", "/samename/profile": "nickname"} {
+		name, schema := componentSchema(t, doc, path)
+		properties, _ := schema["properties"].(map[string]interface{})
+		if _, ok := properties[field]; !ok {
+			t.Errorf("%s 应引用包含 %s 字段的 schema，实际引用 %s: %#v", path, field, name, schema)
+		}
+	}
+	if _, ok := doc.Components["schemas"].(map[string]interface{})["UserInfo"]; ok {
+		t.Errorf("同名类型不应共用 UserInfo 这个 schema 名称")
+	}
+}
+
+func TestAnonymousStructResponse(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/anonstruct/health")
+
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "object" {
+		t.Fatalf("匿名结构体响应应为 object，实际为 %+v", route.ResponseSchema)
+	}
+	// 没有JSON标签的字段使用字段名
+	if field := property(t, route.ResponseSchema, "OK"); field.Type != "boolean" {
+		t.Errorf("字段 OK 应为 boolean，实际为 %q", field.Type)
+	}
+	if version := property(t, route.ResponseSchema, "version"); version.Type != "string" {
+		t.Errorf("字段 version 应为 string，实际为 %q", version.Type)
+	}
+
+	// 导出时为匿名结构体生成合成的 schema 名称
+	name, schema := componentSchema(t, swaggerDoc(info), "/anonstruct/health")
+	if name != "AnonstructHealthResponse" {
+		t.Errorf("匿名结构体应按包名和处理函数生成 schema 名称，实际为 %s", name)
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, key := range []string{"OK", "version"} {
+		if _, ok := properties[key]; !ok {
+			t.Errorf("schema %s 缺少字段 %s: %#v", name, key, schema)
+		}
+	}
+}
+
+func TestSuccessBranchesWithDifferentShapes(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/wrapperstatus/search")
+
+	data := property(t, route.ResponseSchema, "data")
+	if len(data.OneOf) != 2 {
+		t.Fatalf("两个成功分支返回不同结构时 data 应为 OneOf，实际为 %+v", data)
+	}
+	if data.OneOf[0].Type != "array" || data.OneOf[1].Type != "User" {
+		t.Errorf("data 的候选结构应为字符串数组和 User，实际为 %s 与 %s", data.OneOf[0].Type, data.OneOf[1].Type)
+	}
+
+	swaggerData := swaggerOperation(t, info, "GET", "/wrapperstatus/search").
+		Responses["200"].Content["application/json"].Schema["properties"].(map[string]interface{})["data"].(map[string]interface{})
+	if oneOf, _ := swaggerData["oneOf"].([]interface{}); len(oneOf) != 2 {
+		t.Errorf("Swagger 中 data 应为包含两个候选的 oneOf，实际为 %#v", swaggerData)
+	}
+}
+
+func TestErrorWrapperNotMergedIntoSuccess(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	// Fail 内部使用常量状态码 400，Abort 的状态码由调用处传入 404
+	for path, errorStatus := range map[string]string{"/wrapperstatus/user/:id": "400", "/wrapperstatus/profile/:id": "404"} {
+		route := findRoute(t, info, "GET", path)
+		if data := property(t, route.ResponseSchema, "data"); data.Type != "User" || len(data.OneOf) != 0 {
+			t.Errorf("%s 的成功响应 data 应为 User，不应合并错误封装函数的响应，实际为 %+v", path, data)
+		}
+		if route.SuccessStatus != 200 {
+			t.Errorf("%s 的成功状态码应为 200，实际为 %d", path, route.SuccessStatus)
+		}
+		if _, ok := route.Responses[errorStatus]; !ok {
+			t.Errorf("%s 应记录状态码 %s 的错误响应，实际为 %v", path, errorStatus, route.Responses)
+		}
+	}
+}
+
+func TestRenderHelperMethod(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/renderhelper/order")
+
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "envelope" {
+		t.Fatalf("响应应为辅助方法组装的 envelope，实际为 %+v", route.ResponseSchema)
+	}
+	property(t, route.ResponseSchema, "code")
+	data := property(t, route.ResponseSchema, "data")
+	if data.Type != "Order" {
+		t.Fatalf("data 应展开为处理函数传入的 Order，实际为 %+v", data)
+	}
+	property(t, data, "total")
+}
+
+func TestGinHValueWrapperCall(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/hwrapper/user")
+
+	if trace := property(t, route.ResponseSchema, "trace"); trace.Type != "string" {
+		t.Errorf("trace 应为 string，实际为 %q", trace.Type)
+	}
+	data := property(t, route.ResponseSchema, "data")
+	if data.Type != "Result" {
+		t.Fatalf("data 应为封装函数返回的 Result，实际为 %+v", data)
+	}
+	inner := property(t, data, "data")
+	if inner.Type != "User" {
+		t.Fatalf("Result.data 应展开为传入的 User，实际为 %+v", inner)
+	}
+	property(t, inner, "name")
+}
+
+func TestModelPackageSchemaNames(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	options := exporter.DefaultOptions()
+	options.EnvelopeField = ""
+	options.ModelPackages = []string{"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/samename/account"}
+	doc := swaggerDocWithOptions(info, options)
+
+	// 模型包中的类型名原样作为schema名称，其他包的同名类型仍带包名前缀
+	if name, _ := componentSchema(t, doc, "/samename/account"); name != "UserInfo" {
+		t.Errorf("模型包 account 中的 UserInfo 应原样作为schema名称，实际为 %s", name)
+	}
+	if name, _ := componentSchema(t, doc, "/samename/profile"); name != "ProfileUserInfo" {
+		t.Errorf("profile 包中的 UserInfo 应带包名前缀，实际为 %s", name)
+	}
+}
+
+func TestBinaryResponses(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	avatar := findRoute(t, info, "GET", "/binaryresp/avatar").ResponseSchema
+	if avatar == nil || avatar.Format != "binary" || avatar.ContentType != "image/png" {
+		t.Fatalf("c.Data 应记录 image/png 的二进制响应，实际为 %+v", avatar)
+	}
+	content := swaggerOperation(t, info, "GET", "/binaryresp/avatar").Responses["200"].Content
+	media, ok := content["image/png"]
+	if !ok || len(content) != 1 {
+		t.Fatalf("Swagger 响应应只使用 image/png，实际为 %+v", content)
+	}
+	if media.Schema["format"] != "binary" || media.Schema["properties"] != nil {
+		t.Errorf("二进制响应不应生成JSON封装，实际为 %#v", media.Schema)
+	}
+
+	report := findRoute(t, info, "GET", "/binaryresp/report").ResponseSchema
+	if report == nil || report.Format != "binary" {
+		t.Errorf("c.File 应记录二进制响应，实际为 %+v", report)
+	}
+}
+
+func TestCreatedStatusDescription(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "POST", "/created/items")
+	if route.SuccessStatus != 201 {
+		t.Fatalf("成功状态码应为 201，实际为 %d", route.SuccessStatus)
+	}
+
+	responses := swaggerOperation(t, info, "POST", "/created/items").Responses
+	created, ok := responses["201"]
+	if !ok {
+		t.Fatalf("Swagger 应输出 201 响应，实际为 %v", responses)
+	}
+	if created.Description != "Created" {
+		t.Errorf("201 响应的描述应为 Created，实际为 %q", created.Description)
+	}
+	if _, ok := responses["200"]; ok {
+		t.Errorf("成功状态码为 201 时不应再输出 200 响应")
+	}
+}
+
+func TestCustomRenderer(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	export := findRoute(t, info, "GET", "/customrender/export")
+	if export.ResponseSchema == nil || export.ResponseSchema.Type != "Report" {
+		t.Fatalf("自定义渲染器的 Data 字段应作为响应，实际为 %+v", export.ResponseSchema)
+	}
+	if export.ResponseSchema.ContentType != "*/*" {
+		t.Errorf("自定义渲染器的内容类型应标记为未知 (*/*)，实际为 %q", export.ResponseSchema.ContentType)
+	}
+	property(t, export.ResponseSchema, "title")
+
+	report := findRoute(t, info, "GET", "/customrender/report")
+	if report.ResponseSchema == nil || report.ResponseSchema.Type != "Report" || report.ResponseSchema.ContentType != "" {
+		t.Errorf("gin 内置的 render.JSON 应按JSON响应处理，实际为 %+v", report.ResponseSchema)
+	}
+	if report.SuccessStatus != 202 {
+		t.Errorf("c.Render 的状态码应为 202，实际为 %d", report.SuccessStatus)
+	}
+}
+
+func TestPointerAndNestedSliceShapes(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	schema := findRoute(t, info, "GET", "/shapes").ResponseSchema
+
+	pointers := property(t, schema, "pointers")
+	if pointers.Type != "array" || pointers.Items == nil || pointers.Items.Type != "User" {
+		t.Fatalf("[]*User 应为元素类型为 User 的数组，实际为 %+v", pointers)
+	}
+	if !pointers.Items.Nullable || pointers.Nullable {
+		t.Errorf("[]*User 的元素应可为 null，数组本身不应可为 null")
+	}
+	property(t, pointers.Items, "name")
+
+	slicePtr := property(t, schema, "slice_ptr")
+	if slicePtr.Type != "array" || slicePtr.Items == nil || slicePtr.Items.Type != "User" {
+		t.Fatalf("*[]User 应为元素类型为 User 的数组，实际为 %+v", slicePtr)
+	}
+	if !slicePtr.Nullable || slicePtr.Items.Nullable {
+		t.Errorf("*[]User 的数组本身应可为 null，元素不应可为 null")
+	}
+
+	matrix := property(t, schema, "matrix")
+	if matrix.Type != "array" || matrix.Items == nil || matrix.Items.Type != "array" {
+		t.Fatalf("[][]User 应为数组的数组，实际为 %+v", matrix)
+	}
+	if matrix.Items.Items == nil || matrix.Items.Items.Type != "User" {
+		t.Fatalf("[][]User 的内层元素类型应为 User，实际为 %+v", matrix.Items.Items)
+	}
+	property(t, matrix.Items.Items, "name")
+}
+
+func TestCustomScalarTypeMapping(t *testing.T) {
+	mappings, err := helper.ParseTypeMappings([]string{
+		"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/typemap.Email=string/email",
+	})
+	if err != nil {
+		t.Fatalf("解析类型映射失败: %v", err)
+	}
+	info := analyzeFixture(t, "ginapp", Options{TypeMappings: mappings})
+	schema := findRoute(t, info, "GET", "/typemap/contact").ResponseSchema
+
+	if email := property(t, schema, "email"); email.Type != "string" || email.Format != "email" {
+		t.Errorf("Email 应映射为 string/email，实际为 %s/%s", email.Type, email.Format)
+	}
+	// 未配置映射的类型不受影响
+	if balance := property(t, schema, "balance"); balance.Type != "Money" || balance.Format != "" {
+		t.Errorf("未配置映射的 Money 应保持原有解析结果，实际为 %s/%s", balance.Type, balance.Format)
+	}
+
+	if _, err := helper.ParseTypeMappings([]string{"Email=uuid"}); err == nil {
+		t.Errorf("不受支持的映射应返回错误")
+	}
+}
+
+func TestSwitchCaseResponses(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/switchresp/orders/:id")
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Order" || route.SuccessStatus != 200 {
+		t.Fatalf("成功响应应为 200 Order，实际为 %d %+v", route.SuccessStatus, route.ResponseSchema)
+	}
+	if _, ok := route.Responses["404"]; !ok {
+		t.Errorf("应保留 switch 分支中的 404 响应，实际为 %v", route.Responses)
+	}
+
+	// 每个响应调用带有所在 switch 分支的信息
+	checkResponseCalls(t, handlerAnalysis(t, "switchresp", "GetOrder"), []responseCall{
+		{200, "switch", `c.Query("state"): case "found"`, false},
+		{404, "switch", `c.Query("state"): default`, true},
+	})
+}
+
+// handlerAnalysis 直接用响应解析引擎分析 ginapp 中某个包（按包路径后缀）的处理函数
+func handlerAnalysis(t *testing.T, pkgName, funcName string) *helper.HandlerAnalysisResult {
+	t.Helper()
+	proj := loadFixture(t, "ginapp")
+	for _, pkg := range proj.Packages {
+		if !strings.HasSuffix(pkg.PkgPath, "/"+pkgName) {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Name.Name == funcName {
+					return helper.NewResponseParsingEngine(proj.Packages).AnalyzeHandlerComplete(funcDecl, pkg)
+				}
+			}
+		}
+	}
+	t.Fatalf("ginapp 中没有处理函数 %s.%s", pkgName, funcName)
+	return nil
+}
+
+// responseCall 期望的响应调用：状态码及所在分支
+type responseCall struct {
+	status    int
+	branch    string
+	condition string
+	isError   bool
+}
+
+// checkResponseCalls 按顺序比较处理函数中的响应调用
+func checkResponseCalls(t *testing.T, result *helper.HandlerAnalysisResult, want []responseCall) {
+	t.Helper()
+	if result == nil || len(result.Responses) != len(want) {
+		t.Fatalf("应收集到 %d 个响应调用，实际为 %+v", len(want), result)
+	}
+	for i, w := range want {
+		call := result.Responses[i]
+		if call.StatusCode != w.status || call.Branch == nil || call.Branch.Type != w.branch {
+			t.Errorf("第 %d 个响应应为 %s 分支中的 %d，实际为 %d %+v", i+1, w.branch, w.status, call.StatusCode, call.Branch)
+			continue
+		}
+		if call.Branch.Condition != w.condition || call.Branch.IsErrorPath != w.isError {
+			t.Errorf("第 %d 个响应的分支条件应为 %q (错误分支: %v)，实际为 %+v", i+1, w.condition, w.isError, call.Branch)
+		}
+	}
+}
+
+func TestGinHIndexAssignments(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	stats := findRoute(t, info, "GET", "/mapindex/stats").ResponseSchema
+	if stats == nil {
+		t.Fatal("缺少响应结构")
+	}
+	// 字面量中的键、索引赋值的键（含常量键和分支中的赋值）都应计入
+	for name, typ := range map[string]string{"code": "integer", "data": "Item", "total": "integer", "debug": "string"} {
+		if prop := property(t, stats, name); prop.Type != typ {
+			t.Errorf("键 %s 的类型应为 %s，实际为 %s", name, typ, prop.Type)
+		}
+	}
+
+	nested := property(t, findRoute(t, info, "GET", "/mapindex/nested").ResponseSchema, "data")
+	if count := property(t, nested, "count"); count.Type != "integer" {
+		t.Errorf("嵌套 map 变量中索引赋值的键 count 应为 integer，实际为 %s", count.Type)
+	}
+
+	items := findRoute(t, info, "GET", "/mapindex/items").ResponseSchema
+	if items == nil || items.Type != "array" || items.Items == nil || items.Items.Type != "Item" {
+		t.Errorf("append 构造的切片响应应为 Item 数组，实际为 %+v", items)
+	}
+}
+
+func TestConditionalGinHKeysOptional(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	stats := findRoute(t, info, "GET", "/mapindex/stats").ResponseSchema
+	profile := findRoute(t, info, "GET", "/mapindex/profile").ResponseSchema
+	for _, tt := range []struct {
+		schema   *models.APISchema
+		key      string
+		required bool
+	}{
+		{stats, "code", true},
+		{stats, "data", true},
+		{stats, "total", true},
+		{stats, "debug", false}, // if 分支中添加
+		{profile, "id", true},   // 字面量中已有，分支中的赋值不改变必有性
+		{profile, "perms", false},
+		{profile, "last_tag", false},
+		{profile, "name", true}, // 分支之后一定会执行的赋值
+	} {
+		if prop := property(t, tt.schema, tt.key); prop.Required != tt.required {
+			t.Errorf("%s 键 %s 的必有标记应为 %v，实际为 %v", tt.schema.Type, tt.key, tt.required, prop.Required)
+		}
+	}
+}
+
+func TestPackageLevelVarResponse(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	config := findRoute(t, info, "GET", "/pkgvar/config").ResponseSchema
+	property(t, config, "name")
+	if data := property(t, config, "data"); data.Type != "Settings" {
+		t.Errorf("interface{} 字段应按包级变量的初始化字面量细化为 Settings，实际为 %s", data.Type)
+	} else {
+		property(t, data, "theme")
+	}
+
+	ptr := findRoute(t, info, "GET", "/pkgvar/config-ptr").ResponseSchema
+	if data := property(t, ptr, "data"); data.Type != "array" || data.Items == nil || data.Items.Type != "Settings" {
+		t.Errorf("以 &Config{...} 初始化的包级变量应细化为 Settings 数组，实际为 %+v", data)
+	}
+
+	// 其他包中的包级变量
+	defaults := findRoute(t, info, "GET", "/pkgvar/defaults").ResponseSchema
+	if data := property(t, defaults, "data"); data.Type != "Limits" {
+		t.Errorf("其他包的包级变量应按初始化字面量细化为 Limits，实际为 %s", data.Type)
+	} else {
+		property(t, data, "max_items")
+	}
+}
+
+func TestStatusKeyedResponses(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/multistatus/users/:id")
+
+	ok, bad := route.Responses["200"], route.Responses["400"]
+	if ok == nil || ok.Type != "User" || bad == nil || bad.Type != "ErrorResponse" {
+		t.Fatalf("应按状态码记录 200 User 与 400 ErrorResponse，实际为 %+v", route.Responses)
+	}
+	// ResponseSchema 保持为成功响应
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "User" {
+		t.Errorf("ResponseSchema 应为 200 响应 User，实际为 %+v", route.ResponseSchema)
+	}
+
+	data, err := json.Marshal(route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output struct {
+		Responses map[string]struct {
+			Type string `json:"type"`
+		} `json:"responses"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatal(err)
+	}
+	if output.Responses["200"].Type != "User" || output.Responses["400"].Type != "ErrorResponse" {
+		t.Errorf("JSON 输出应包含按状态码的 responses，实际为 %s", data)
+	}
+}
+
+func TestDeferredResponses(t *testing.T) {
+	// 延迟调用排在其他响应之后，按注册的逆序执行；延迟闭包中保留 if 的条件
+	checkResponseCalls(t, handlerAnalysis(t, "deferresp", "Process"), []responseCall{
+		{400, "if", `c.Query("id") == ""`, true},
+		{202, "defer", "", false},
+		{500, "defer", "err != nil", true},
+	})
+}
+
+func TestReadOnlyWriteOnlyFields(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	schema := findRoute(t, info, "GET", "/readonly/account").ResponseSchema
+	if id := property(t, schema, "id"); !id.ReadOnly || id.WriteOnly {
+		t.Errorf("readonly:\"true\" 的字段应只读，实际为 %+v", id)
+	}
+	if password := property(t, schema, "password"); !password.WriteOnly || password.ReadOnly {
+		t.Errorf("writeonly:\"true\" 的字段应只写，实际为 %+v", password)
+	}
+
+	_, account := componentSchema(t, swaggerDoc(info), "/readonly/account")
+	properties, _ := account["properties"].(map[string]interface{})
+	id, _ := properties["id"].(map[string]interface{})
+	if id["readOnly"] != true || id["type"] != "integer" {
+		t.Errorf("id 应输出 readOnly: true，实际为 %#v", id)
+	}
+	password, _ := properties["password"].(map[string]interface{})
+	if password["writeOnly"] != true {
+		t.Errorf("password 应输出 writeOnly: true，实际为 %#v", password)
+	}
+	if name, _ := properties["name"].(map[string]interface{}); name["readOnly"] != nil || name["writeOnly"] != nil {
+		t.Errorf("普通字段不应标记只读或只写，实际为 %#v", name)
+	}
+	// 引用其他 schema 的只读字段包装在 allOf 中
+	audit, _ := properties["audit"].(map[string]interface{})
+	if allOf, _ := audit["allOf"].([]interface{}); len(allOf) != 1 || audit["readOnly"] != true {
+		t.Errorf("只读的结构体字段应为带 readOnly 的 allOf 引用，实际为 %#v", audit)
+	}
+}
+
+func TestServiceCallResultResponse(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	for path, typ := range map[string]string{
+		// (User, error) 的第一个结果
+		"/svcresult/users/:id": "User",
+		// 声明返回接口的服务方法按 return 语句中的具体类型解析
+		"/svcresult/profiles/:id": "Profile",
+		// 返回值来自另一个返回接口的调用
+		"/svcresult/lookup/:id": "Profile",
+	} {
+		schema := findRoute(t, info, "GET", path).ResponseSchema
+		if schema == nil || schema.Type != typ {
+			t.Errorf("GET %s 的响应应为 %s，实际为 %+v", path, typ, schema)
+		}
+	}
+	property(t, findRoute(t, info, "GET", "/svcresult/profiles/:id").ResponseSchema, "bio")
+}
+
+func TestInterfaceReturningWrapper(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	// Success 声明返回 Responder，按 return 语句构造的 *Envelope 建模，Data 细化为参数类型
+	user := findRoute(t, info, "GET", "/ifacewrap/user").ResponseSchema
+	if user == nil || user.Type != "Envelope" {
+		t.Fatalf("响应应为具体的封装类型 Envelope，实际为 %+v", user)
+	}
+	property(t, user, "code")
+	if data := property(t, user, "data"); data.Type != "User" {
+		t.Errorf("data 应为 User，实际为 %s", data.Type)
+	}
+
+	// 不同分支构造不同封装类型时合并为 oneOf
+	result := findRoute(t, info, "GET", "/ifacewrap/result").ResponseSchema
+	if result == nil || len(result.OneOf) != 2 {
+		t.Fatalf("应为 ErrorEnvelope 与 Envelope 的 oneOf，实际为 %+v", result)
+	}
+	if result.OneOf[0].Type != "ErrorEnvelope" || result.OneOf[1].Type != "Envelope" {
+		t.Errorf("oneOf 应依次为 ErrorEnvelope、Envelope，实际为 %s、%s", result.OneOf[0].Type, result.OneOf[1].Type)
+	}
+}
+
+func TestInterfaceResponderMethod(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/responder/item")
+
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Body" {
+		t.Fatalf("响应器接口方法应解析为具体实现返回的 Body，实际为 %+v", route.ResponseSchema)
+	}
+	data := property(t, route.ResponseSchema, "data")
+	if data.Type != "Item" {
+		t.Fatalf("data 应展开为处理函数传入的 Item，实际为 %+v", data)
+	}
+	property(t, data, "sku")
+}
+
+func TestContextResponder(t *testing.T) {
+	responders, err := helper.ParseResponders([]string{
+		"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/ctxresp.FromCtx:OK=0,Fail=1/400",
+		"example.com/missing.FromCtx:OK=0",
+	})
+	if err != nil {
+		t.Fatalf("解析响应器配置失败: %v", err)
+	}
+	info := analyzeFixture(t, "ginapp", Options{Responders: responders})
+	route := findRoute(t, info, "GET", "/ctxresp/shipment")
+
+	// 响应器接口在项目中没有实现，resp.OK(data) 按响应封装函数注入默认响应结构的 data 字段
+	data := property(t, route.ResponseSchema, "data")
+	if data.Type != "Shipment" {
+		t.Fatalf("data 应展开为 Shipment，实际为 %+v", data)
+	}
+	property(t, data, "tracking_no")
+	// resp.Fail 配置了状态码 400，记录为错误响应
+	if _, ok := route.Responses["400"]; !ok {
+		t.Errorf("应记录 Fail 的 400 错误响应，实际为 %v", route.Responses)
+	}
+
+	found := false
+	for _, warning := range info.Warnings {
+		found = found || strings.Contains(warning, "example.com/missing.FromCtx")
+	}
+	if !found {
+		t.Errorf("未找到的响应器获取函数应记录警告，实际为 %v", info.Warnings)
+	}
+
+	// 未配置时无法识别响应器方法调用
+	if plain := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/ctxresp/shipment"); plain.ResponseSchema != nil {
+		t.Errorf("未配置响应器时不应推断出响应，实际为 %+v", plain.ResponseSchema)
+	}
+}
+
+func TestParseRespondersErrors(t *testing.T) {
+	for _, spec := range []string{
+		"FromCtx:OK=0",                    // 缺少包路径
+		"example.com/resp.FromCtx",        // 缺少方法
+		"example.com/resp.FromCtx:OK",     // 缺少数据参数索引
+		"example.com/resp.FromCtx:OK=-1",  // 索引为负数
+		"example.com/resp.FromCtx:OK=0/9", // 状态码无效
+	} {
+		if _, err := helper.ParseResponders([]string{spec}); err == nil {
+			t.Errorf("%s 应返回错误", spec)
+		}
+	}
+}
+
+func TestNilWrapperData(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	// 带类型的 nil 按指向的类型展开
+	for _, path := range []string{"/nildata/converted", "/nildata/declared"} {
+		data := property(t, findRoute(t, info, "GET", path).ResponseSchema, "data")
+		if data.Type != "User" {
+			t.Errorf("%s 的 data 应解析为 User，实际为 %+v", path, data)
+			continue
+		}
+		property(t, data, "name")
+	}
+
+	// 无类型的 nil 保持 any
+	if data := property(t, findRoute(t, info, "GET", "/nildata/untyped").ResponseSchema, "data"); data.Type != "any" {
+		t.Errorf("无类型 nil 的 data 应保持 any，实际为 %+v", data)
+	}
+}
+
+func TestContextSetRenderedByMiddleware(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+	route := findRoute(t, info, "GET", "/ctxset/order")
+
+	if !route.MiddlewareRendered {
+		t.Errorf("只调用 c.Set 的处理函数应标记为由中间件渲染")
+	}
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Order" {
+		t.Fatalf("响应应为 c.Set(\"data\") 存储的 Order，实际为 %+v", route.ResponseSchema)
+	}
+	property(t, route.ResponseSchema, "title")
+
+	if op := swaggerOperation(t, info, "GET", "/ctxset/order"); !strings.Contains(op.Description, "中间件渲染") {
+		t.Errorf("Swagger 描述应说明响应由中间件渲染，实际为 %q", op.Description)
+	}
+	if findRoute(t, info, "GET", "/hwrapper/user").MiddlewareRendered {
+		t.Errorf("直接渲染响应的处理函数不应标记为由中间件渲染")
+	}
+}
+
+func TestGinHValueCrossPackageCall(t *testing.T) {
+	info := analyzeFixture(t, "ginapp", Options{})
+
+	data := property(t, findRoute(t, info, "GET", "/crosscall/user").ResponseSchema, "data")
+	if data.Type != "Result" {
+		t.Fatalf("data 应为其他包函数返回的 Result，实际为 %+v", data)
+	}
+	if inner := property(t, data, "data"); inner.Type != "User" {
+		t.Errorf("Result.data 应展开为传入的 User，实际为 %+v", inner)
+	} else {
+		property(t, inner, "name")
+	}
+
+	// 嵌套结构体中的 interface{} 字段同样按函数内的赋值展开
+	page := property(t, findRoute(t, info, "GET", "/crosscall/users").ResponseSchema, "data")
+	items := property(t, property(t, page, "meta"), "data")
+	if items.Type != "array" || items.Items == nil || items.Items.Type != "User" {
+		t.Errorf("Page.meta.data 应展开为 User 数组，实际为 %+v", items)
+	}
+}
+
+func TestSchemaOverrides(t *testing.T) {
+	overrides, err := helper.LoadSchemaOverrides(filepath.Join("testdata", "schema_overrides.json"))
+	if err != nil {
+		t.Fatalf("加载schema覆盖文件失败: %v", err)
+	}
+
+	// 未覆盖时按结构体定义推断，与 MarshalJSON 的实际输出不符
+	plain := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/customjson/invoice")
+	if total := property(t, plain.ResponseSchema, "total"); total.Properties["amount"] != nil {
+		t.Fatalf("未覆盖时 Money 应按结构体定义推断，实际为 %+v", total)
+	}
+
+	route := findRoute(t, analyzeFixture(t, "ginapp", Options{SchemaOverrides: overrides}), "GET", "/customjson/invoice")
+	total := property(t, route.ResponseSchema, "total")
+	if total.Description != "金额" || total.JSONTag != "total" {
+		t.Errorf("total 应使用手写的 Money schema 并保留字段标签，实际为 %+v", total)
+	}
+	if amount := property(t, total, "amount"); amount.Type != "string" {
+		t.Errorf("amount 应为 string，实际为 %q", amount.Type)
+	}
+	items := property(t, route.ResponseSchema, "items")
+	if items.Items == nil || items.Items.Description != "金额" {
+		t.Fatalf("items 的元素应使用手写的 Money schema，实际为 %+v", items.Items)
+	}
+	property(t, items.Items, "currency")
+
+	// 每次使用的是副本，修改不影响覆盖配置
+	if overrides["github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/customjson.Money"].JSONTag != "" {
+		t.Errorf("不应修改加载的schema覆盖")
+	}
+}
+
+func TestLoadSchemaOverridesErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"invalid.json": `{`,
+		"nopkg.json":   `{"Money": {"type": "string"}}`,
+		"notype.json":  `{"example.com/pkg.Money": {"description": "金额"}}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := helper.LoadSchemaOverrides(path); err == nil {
+			t.Errorf("%s 应返回错误", name)
+		}
+	}
+}
+
+func TestEnumConstResponseValues(t *testing.T) {
+	route := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/enumconst/account")
+
+	// 枚举常量按底层类型解析，常量值作为示例
+	status := property(t, route.ResponseSchema, "status")
+	if status.Type != "string" || status.Example != "active" {
+		t.Errorf("status 应为 string 且示例为 active，实际为 %+v", status)
+	}
+	level := property(t, route.ResponseSchema, "level")
+	if level.Type != "integer" || level.Example != int64(3) {
+		t.Errorf("level 应为 integer 且示例为 3，实际为 %+v", level)
+	}
+	// 普通字面量不设置示例
+	if name := property(t, route.ResponseSchema, "name"); name.Example != nil {
+		t.Errorf("字面量不应设置示例，实际为 %v", name.Example)
+	}
+
+	_, schema := componentSchema(t, swaggerDoc(&models.APIInfo{Routes: []models.RouteInfo{route}}), "/enumconst/account")
+	properties, _ := schema["properties"].(map[string]interface{})
+	swaggerStatus, _ := properties["status"].(map[string]interface{})
+	if swaggerStatus["example"] != "active" {
+		t.Errorf("Swagger 的 status 应带有示例 active，实际为 %v", swaggerStatus)
+	}
+}
+
+func TestSkipUnsupportedJSONFields(t *testing.T) {
+	schema := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/unsupported/job").ResponseSchema
+	if schema == nil || schema.Type != "Job" {
+		t.Fatalf("响应应为 Job，实际为 %+v", schema)
+	}
+
+	// chan、func、complex 及其指针类型的字段不会出现在JSON输出中
+	var keys []string
+	for _, prop := range schema.Properties {
+		keys = append(keys, prop.JSONTag)
+	}
+	sort.Strings(keys)
+	if got := strings.Join(keys, ","); got != "id,status,timeout" {
+		t.Errorf("应只保留可序列化的字段 id,status,timeout，实际为 %s", got)
+	}
+}
+
+func TestPositionalStructLiteral(t *testing.T) {
+	route := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/positional/coupon")
+	if route.ResponseSchema == nil || route.ResponseSchema.Type != "Envelope" {
+		t.Fatalf("响应应为 Envelope，实际为 %+v", route.ResponseSchema)
+	}
+
+	// Envelope{0, Coupon{}} 按声明顺序对应字段，interface{} 的 data 细化为 Coupon
+	data := property(t, route.ResponseSchema, "data")
+	if data.Type != "Coupon" {
+		t.Fatalf("data 应细化为 Coupon，实际为 %+v", data)
+	}
+	property(t, data, "discount")
+}
+
+func TestNestedGinH(t *testing.T) {
+	schema := findRoute(t, analyzeFixture(t, "ginapp", Options{}), "GET", "/nestedh/dashboard").ResponseSchema
+
+	// 两层嵌套的 gin.H 逐层展开为对象
+	stats := property(t, schema, "stats")
+	if stats.Type != "object" {
+		t.Fatalf("stats 应展开为对象，实际为 %+v", stats)
+	}
+	property(t, stats, "region")
+	visits := property(t, stats, "visits")
+	if total := property(t, visits, "total"); total.Type != "integer" {
+		t.Errorf("stats.visits.total 应为 integer，实际为 %q", total.Type)
+	}
+	property(t, visits, "today")
+
+	// []gin.H 合并各元素的键作为元素结构
+	widgets := property(t, schema, "widgets")
+	if widgets.Type != "array" || widgets.Items == nil {
+		t.Fatalf("widgets 应为数组，实际为 %+v", widgets)
+	}
+	property(t, widgets.Items, "id")
+	property(t, widgets.Items, "title")
+}
@@ -0,0 +1,18 @@
+// Package controllers Beego控制器：通过 this.Data["json"] = v 设置响应
+package controllers
+
+import "github.com/beego/beego/v2/server/web"
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserController struct {
+	web.Controller
+}
+
+func (this *UserController) Get() {
+	this.Data["json"] = User{}
+	this.ServeJSON()
+}
@@ -0,0 +1,18 @@
+// Package web 测试用的Beego替身，只包含路由注册和控制器用到的声明
+package web
+
+type ControllerInterface interface{}
+
+type Controller struct {
+	Data map[interface{}]interface{}
+}
+
+func (c *Controller) ServeJSON() {}
+
+type HttpServer struct{}
+
+func Router(rootpath string, c ControllerInterface, mappingMethods ...string) *HttpServer {
+	return &HttpServer{}
+}
+
+func Run(params ...string) {}
@@ -0,0 +1,7 @@
+module example.com/beegoapp
+
+go 1.20
+
+require github.com/beego/beego/v2 v2.0.0
+
+replace github.com/beego/beego/v2 => ./fakebeego
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"example.com/beegoapp/controllers"
+	"github.com/beego/beego/v2/server/web"
+)
+
+func main() {
+	web.Router("/user", &controllers.UserController{})
+	web.Run()
+}
@@ -0,0 +1,28 @@
+// Package gin 测试用的Gin替身（模块路径经镜像改写），只包含路由注册和处理函数用到的声明
+package gin
+
+type H map[string]any
+
+type Context struct{}
+
+func (c *Context) Param(key string) string { return "" }
+
+func (c *Context) JSON(code int, obj any) {}
+
+type HandlerFunc func(*Context)
+
+type RouterGroup struct{}
+
+func (group *RouterGroup) Group(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group
+}
+
+func (group *RouterGroup) GET(relativePath string, handlers ...HandlerFunc) {}
+
+type Engine struct {
+	RouterGroup
+}
+
+func New() *Engine { return &Engine{} }
+
+func (engine *Engine) Run(addr ...string) error { return nil }
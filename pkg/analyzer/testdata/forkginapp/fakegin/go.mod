@@ -0,0 +1,3 @@
+module example.com/mirror/github.com/gin-gonic/gin
+
+go 1.20
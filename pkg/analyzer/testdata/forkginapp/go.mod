@@ -0,0 +1,7 @@
+module example.com/forkginapp
+
+go 1.20
+
+require example.com/mirror/github.com/gin-gonic/gin v0.0.0
+
+replace example.com/mirror/github.com/gin-gonic/gin => ./fakegin
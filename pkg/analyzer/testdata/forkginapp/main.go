@@ -0,0 +1,20 @@
+// Package main 依赖经 replace 改写路径的Gin模块的示例项目
+package main
+
+import "example.com/mirror/github.com/gin-gonic/gin"
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, User{ID: c.Param("id")})
+}
+
+func main() {
+	r := gin.New()
+	api := r.Group("/api")
+	api.GET("/users/:id", GetUser)
+	r.Run()
+}
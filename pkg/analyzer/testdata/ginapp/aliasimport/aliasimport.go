@@ -0,0 +1,19 @@
+// Package aliasimport 以别名导入 gin
+package aliasimport
+
+import g "github.com/gin-gonic/gin"
+
+type Item struct {
+	Name string `json:"name"`
+}
+
+func GetItem(c *g.Context) {
+	_ = c.Query("q")
+	c.JSON(200, Item{})
+}
+
+// Register 注册路由
+func Register(r *g.Engine) {
+	grp := r.Group("/aliasimport")
+	grp.GET("/item", GetItem)
+}
@@ -0,0 +1,26 @@
+// Package annotated 带 swaggo 注解的处理函数
+package annotated
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	Name string `json:"name"`
+}
+
+// GetUser 获取用户
+//
+// @Summary 查询用户详情
+// @Description 按ID查询用户
+// @Param id path int true "用户ID"
+// @Param verbose query bool false "是否返回详细信息"
+// @Success 200 {object} User "用户信息"
+func GetUser(c *gin.Context) {
+	_ = c.Query("lang")
+	c.JSON(200, User{Name: c.Param("id")})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/annotated")
+	g.GET("/users/:id", GetUser)
+}
@@ -0,0 +1,17 @@
+// Package anonstruct 直接返回匿名结构体的处理函数
+package anonstruct
+
+import "github.com/gin-gonic/gin"
+
+func Health(c *gin.Context) {
+	c.JSON(200, struct {
+		OK      bool
+		Version string `json:"version"`
+	}{true, "1.0"})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/anonstruct")
+	g.GET("/health", Health)
+}
@@ -0,0 +1,24 @@
+// Package beegodata gin 处理函数中名为 Data 的 map 字段的 ["json"] 赋值不是响应
+package beegodata
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	ID int `json:"id"`
+}
+
+type cache struct {
+	Data map[string]interface{}
+}
+
+func GetStatus(c *gin.Context) {
+	store := cache{Data: map[string]interface{}{}}
+	store.Data["json"] = User{}
+	c.JSON(200, gin.H{"ok": true})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/beegodata")
+	g.GET("/status", GetStatus)
+}
@@ -0,0 +1,20 @@
+// Package binaryresp c.Data 与 c.File 返回二进制和文件内容
+package binaryresp
+
+import "github.com/gin-gonic/gin"
+
+func Avatar(c *gin.Context) {
+	var png []byte
+	c.Data(200, "image/png", png)
+}
+
+func Report(c *gin.Context) {
+	c.File("./report.pdf")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/binaryresp")
+	g.GET("/avatar", Avatar)
+	g.GET("/report", Report)
+}
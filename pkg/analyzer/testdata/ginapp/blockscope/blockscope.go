@@ -0,0 +1,39 @@
+// Package blockscope 代码块中同名的分组变量
+package blockscope
+
+import "github.com/gin-gonic/gin"
+
+func ListV1(c *gin.Context) {
+	c.JSON(200, []string{})
+}
+
+func ListV2(c *gin.Context) {
+	c.JSON(200, []int{})
+}
+
+func AdminUsers(c *gin.Context) {
+	c.JSON(200, map[string]int{})
+}
+
+func Ping(c *gin.Context) {
+	c.String(200, "pong")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/blockscope")
+	{
+		v := g.Group("/v1")
+		v.GET("/users", ListV1)
+		{
+			// 内层代码块中的 v 遮蔽外层的 v
+			v := v.Group("/admin")
+			v.GET("/users", AdminUsers)
+		}
+		v.GET("/ping", Ping)
+	}
+	{
+		v := g.Group("/v2")
+		v.GET("/users", ListV2)
+	}
+}
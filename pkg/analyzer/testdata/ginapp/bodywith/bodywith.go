@@ -0,0 +1,49 @@
+// Package bodywith c.ShouldBindBodyWith 缓存请求体，可多次绑定
+package bodywith
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type BodyReq struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type LegacyReq struct {
+	Title string `json:"title"`
+}
+
+func Create(c *gin.Context) {
+	var req BodyReq
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return
+	}
+	c.String(200, req.Name)
+}
+
+func Import(c *gin.Context) {
+	var req BodyReq
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		if err := c.ShouldBindBodyWith(&req, binding.YAML); err != nil {
+			return
+		}
+	}
+	c.String(200, req.Name)
+}
+
+func Legacy(c *gin.Context) {
+	var req LegacyReq
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		return
+	}
+	c.String(200, req.Title)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/bodywith")
+	g.POST("/create", Create)
+	g.POST("/import", Import)
+	g.POST("/legacy", Legacy)
+}
@@ -0,0 +1,22 @@
+// Package created 返回 201 状态码的创建接口
+package created
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Item struct {
+	ID int `json:"id"`
+}
+
+func CreateItem(c *gin.Context) {
+	c.JSON(http.StatusCreated, Item{ID: 1})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/created")
+	g.POST("/items", CreateItem)
+}
@@ -0,0 +1,27 @@
+// Package builder 构造响应数据的公共函数
+package builder
+
+type Result struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+type Meta struct {
+	Total int         `json:"total"`
+	Data  interface{} `json:"data"`
+}
+
+type Page struct {
+	Page int  `json:"page"`
+	Meta Meta `json:"meta"`
+}
+
+// NewResult 构造成功结果
+func NewResult(data interface{}) Result {
+	return Result{Code: 0, Data: data}
+}
+
+// NewPage 构造分页结果
+func NewPage(items interface{}) Page {
+	return Page{Page: 1, Meta: Meta{Data: items}}
+}
@@ -0,0 +1,27 @@
+// Package crosscall gin.H 的值为其他包中构造响应数据的函数调用
+package crosscall
+
+import (
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/crosscall/builder"
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, gin.H{"data": builder.NewResult(User{})})
+}
+
+func ListUsers(c *gin.Context) {
+	c.JSON(200, gin.H{"data": builder.NewPage([]User{})})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/crosscall")
+	g.GET("/user", GetUser)
+	g.GET("/users", ListUsers)
+}
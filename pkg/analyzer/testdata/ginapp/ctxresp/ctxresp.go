@@ -0,0 +1,38 @@
+// Package ctxresp 通过上下文中存放的响应器返回响应，响应器由外部中间件注入，项目中没有实现
+package ctxresp
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Resp 请求级响应器
+type Resp interface {
+	OK(data interface{})
+	Fail(code int, err error)
+}
+
+// FromCtx 获取上下文中的响应器
+func FromCtx(c *gin.Context) Resp {
+	return c.MustGet("resp").(Resp)
+}
+
+type Shipment struct {
+	TrackingNo string `json:"tracking_no"`
+	Carrier    string `json:"carrier"`
+}
+
+func GetShipment(c *gin.Context) {
+	resp := FromCtx(c)
+	if c.Query("id") == "" {
+		resp.Fail(1, errors.New("missing id"))
+		return
+	}
+	resp.OK(Shipment{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	r.GET("/ctxresp/shipment", GetShipment)
+}
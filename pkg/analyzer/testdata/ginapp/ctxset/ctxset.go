@@ -0,0 +1,30 @@
+// Package ctxset 处理函数只通过 c.Set 存储数据，由中间件渲染响应
+package ctxset
+
+import "github.com/gin-gonic/gin"
+
+type Order struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// Render 读取处理函数存储的数据并渲染
+func Render() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if data, ok := c.Get("data"); ok {
+			c.JSON(200, data)
+		}
+	}
+}
+
+func GetOrder(c *gin.Context) {
+	c.Set("trace", c.GetHeader("X-Trace-Id"))
+	c.Set("data", Order{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/ctxset", Render())
+	g.GET("/order", GetOrder)
+}
@@ -0,0 +1,39 @@
+// Package customjson 通过 MarshalJSON 自定义输出结构的类型
+package customjson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Money 以分为单位保存，输出为 {"amount": "12.34", "currency": "CNY"}
+type Money struct {
+	cents    int64
+	currency string
+}
+
+// MarshalJSON 输出金额文本和币种
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"amount":   fmt.Sprintf("%d.%02d", m.cents/100, m.cents%100),
+		"currency": m.currency,
+	})
+}
+
+type Invoice struct {
+	ID    int     `json:"id"`
+	Total Money   `json:"total"`
+	Items []Money `json:"items"`
+}
+
+func GetInvoice(c *gin.Context) {
+	c.JSON(200, Invoice{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/customjson")
+	g.GET("/invoice", GetInvoice)
+}
@@ -0,0 +1,42 @@
+// Package customrender 通过 c.Render 使用自定义渲染器输出响应
+package customrender
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+type Report struct {
+	Title string `json:"title"`
+	Rows  []int  `json:"rows"`
+}
+
+// csvRender 自定义的CSV渲染器
+type csvRender struct {
+	Data Report
+}
+
+func (r csvRender) Render(w http.ResponseWriter) error {
+	return nil
+}
+
+func (r csvRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/csv")
+}
+
+func ExportReport(c *gin.Context) {
+	c.Render(http.StatusOK, csvRender{Data: Report{Title: "monthly"}})
+}
+
+func GetReport(c *gin.Context) {
+	c.Render(http.StatusAccepted, render.JSON{Data: Report{}})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/customrender")
+	g.GET("/export", ExportReport)
+	g.GET("/report", GetReport)
+}
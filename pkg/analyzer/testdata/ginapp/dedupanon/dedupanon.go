@@ -0,0 +1,32 @@
+// Package dedupanon 不同处理函数返回结构相同的匿名结构体
+package dedupanon
+
+import "github.com/gin-gonic/gin"
+
+func GetOrderStatus(c *gin.Context) {
+	c.JSON(200, struct {
+		OK      bool   `json:"ok"`
+		Message string `json:"message"`
+	}{true, "order"})
+}
+
+func GetUserStatus(c *gin.Context) {
+	c.JSON(200, struct {
+		OK      bool   `json:"ok"`
+		Message string `json:"message"`
+	}{true, "user"})
+}
+
+func GetVersion(c *gin.Context) {
+	c.JSON(200, struct {
+		Version string `json:"version"`
+	}{"1.0"})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/dedupanon")
+	g.GET("/order", GetOrderStatus)
+	g.GET("/user", GetUserStatus)
+	g.GET("/version", GetVersion)
+}
@@ -0,0 +1,40 @@
+// Package defaulttag 请求结构体字段的 default 标签
+package defaulttag
+
+import "github.com/gin-gonic/gin"
+
+type ListQuery struct {
+	PageSize int     `form:"page_size" default:"10"`
+	Ratio    float64 `form:"ratio" default:"0.5"`
+	Active   bool    `form:"active" default:"true"`
+	Order    string  `form:"order" default:"desc"`
+	Keyword  string  `form:"keyword"`
+}
+
+type ExportReq struct {
+	Limit  int    `json:"limit" default:"100"`
+	Format string `json:"format" default:"csv"`
+}
+
+func List(c *gin.Context) {
+	var query ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return
+	}
+	c.String(200, query.Order)
+}
+
+func Export(c *gin.Context) {
+	var req ExportReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.String(200, req.Format)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/defaulttag")
+	g.GET("/list", List)
+	g.POST("/export", Export)
+}
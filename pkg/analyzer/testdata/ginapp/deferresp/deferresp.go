@@ -0,0 +1,28 @@
+// Package deferresp 在延迟调用中渲染响应
+package deferresp
+
+import "github.com/gin-gonic/gin"
+
+type Result struct {
+	Status string `json:"status"`
+}
+
+func Process(c *gin.Context) {
+	defer func() {
+		if err := recover(); err != nil {
+			c.JSON(500, gin.H{"error": "internal"})
+		}
+	}()
+	defer c.JSON(202, Result{Status: "accepted"})
+
+	if c.Query("id") == "" {
+		c.JSON(400, gin.H{"error": "missing id"})
+		return
+	}
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/deferresp")
+	g.POST("/jobs", Process)
+}
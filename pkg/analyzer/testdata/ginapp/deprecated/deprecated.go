@@ -0,0 +1,23 @@
+// Package deprecated 文档注释中标记 Deprecated: 的处理函数
+package deprecated
+
+import "github.com/gin-gonic/gin"
+
+// GetUserV1 获取用户信息
+//
+// Deprecated: 使用 GetUserV2 代替
+func GetUserV1(c *gin.Context) {
+	c.JSON(200, "")
+}
+
+// GetUserV2 获取用户信息
+func GetUserV2(c *gin.Context) {
+	c.JSON(200, "")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/deprecated")
+	g.GET("/v1/user", GetUserV1)
+	g.GET("/v2/user", GetUserV2)
+}
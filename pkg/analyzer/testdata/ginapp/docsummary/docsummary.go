@@ -0,0 +1,24 @@
+// Package docsummary 从处理函数文档注释中提取摘要和描述
+package docsummary
+
+import "github.com/gin-gonic/gin"
+
+// ListOrders 分页查询订单列表。
+// 按创建时间倒序返回，
+// 每页最多 100 条。
+//
+// 已取消的订单不会返回。
+func ListOrders(c *gin.Context) {
+	c.String(200, "orders")
+}
+
+func Undocumented(c *gin.Context) {
+	c.String(200, "none")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/docsummary")
+	g.GET("/orders", ListOrders)
+	g.GET("/undocumented", Undocumented)
+}
@@ -0,0 +1,18 @@
+// Package dotimport 点导入（import . "pkg"）的包中的处理函数和类型
+package dotimport
+
+import (
+	. "github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/dotimport/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func GetItem(c *gin.Context) {
+	c.JSON(200, Item{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/dotimport")
+	g.GET("/items", ListItems)
+	g.GET("/item", GetItem)
+}
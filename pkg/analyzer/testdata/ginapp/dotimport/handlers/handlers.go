@@ -0,0 +1,13 @@
+// Package handlers 通过点导入注册的处理函数
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+type Item struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func ListItems(c *gin.Context) {
+	c.JSON(200, []Item{})
+}
@@ -0,0 +1,49 @@
+// Package embedreq 请求结构体匿名嵌入公共的分页字段
+package embedreq
+
+import "github.com/gin-gonic/gin"
+
+type Pagination struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
+
+type Filter struct {
+	Keyword string `json:"keyword"`
+}
+
+type ListUsersRequest struct {
+	Pagination
+	*Filter
+	Status string `json:"status"`
+	// 外层字段优先于嵌入结构体的同名字段
+	Size string `json:"size"`
+}
+
+type SearchRequest struct {
+	Pagination `json:"pagination"`
+	Query      string `json:"query"`
+}
+
+func ListUsers(c *gin.Context) {
+	var req ListUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.JSON(200, req)
+}
+
+func Search(c *gin.Context) {
+	var req SearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.JSON(200, req)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/embedreq")
+	g.POST("/users", ListUsers)
+	g.POST("/search", Search)
+}
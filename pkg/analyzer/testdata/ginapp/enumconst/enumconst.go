@@ -0,0 +1,25 @@
+// Package enumconst 响应 map 中使用枚举常量的处理函数
+package enumconst
+
+import "github.com/gin-gonic/gin"
+
+type Status string
+
+const StatusActive Status = "active"
+
+type Level int
+
+const LevelHigh Level = 3
+
+func GetAccount(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"status": StatusActive,
+		"level":  LevelHigh,
+		"name":   "demo",
+	})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	r.GET("/enumconst/account", GetAccount)
+}
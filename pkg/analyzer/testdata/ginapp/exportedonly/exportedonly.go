@@ -0,0 +1,22 @@
+// Package exportedonly 导出、未导出和匿名处理函数混合注册
+package exportedonly
+
+import "github.com/gin-gonic/gin"
+
+func ListUsers(c *gin.Context) {
+	c.JSON(200, []string{})
+}
+
+func debugVars(c *gin.Context) {
+	c.JSON(200, map[string]int{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/exportedonly")
+	g.GET("/users", ListUsers)
+	g.GET("/debug", debugVars)
+	g.GET("/ping", func(c *gin.Context) {
+		c.String(200, "pong")
+	})
+}
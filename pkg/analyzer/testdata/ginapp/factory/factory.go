@@ -0,0 +1,42 @@
+// Package factory 通过处理函数工厂注入依赖，工厂返回闭包
+package factory
+
+import "github.com/gin-gonic/gin"
+
+type Store struct{}
+
+type Product struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type SearchQuery struct {
+	Keyword string `form:"keyword"`
+}
+
+// MakeGetProduct 查询商品
+func MakeGetProduct(store *Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query SearchQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(200, Product{Title: query.Keyword})
+	}
+}
+
+// MakeHealth 健康检查
+func MakeHealth() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		c.String(200, "ok")
+	})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	store := &Store{}
+	g := r.Group("/factory")
+	g.GET("/product", MakeGetProduct(store))
+	g.GET("/health", MakeHealth())
+}
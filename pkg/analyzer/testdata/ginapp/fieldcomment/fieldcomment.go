@@ -0,0 +1,21 @@
+// Package fieldcomment 结构体字段的行尾注释和上方注释作为属性描述
+package fieldcomment
+
+import "github.com/gin-gonic/gin"
+
+type Profile struct {
+	ID int `json:"id"` // 用户ID
+	// 昵称，可重复
+	Nickname string `json:"nickname"`
+	Age      int    `json:"age"`
+}
+
+func GetProfile(c *gin.Context) {
+	c.JSON(200, Profile{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/fieldcomment")
+	g.GET("/profile", GetProfile)
+}
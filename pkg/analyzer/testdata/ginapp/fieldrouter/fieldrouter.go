@@ -0,0 +1,32 @@
+// Package fieldrouter 路由器保存在 gin.IRouter 类型的结构体字段中
+package fieldrouter
+
+import "github.com/gin-gonic/gin"
+
+func ListUsers(c *gin.Context) {
+	c.String(200, "users")
+}
+
+func Health(c *gin.Context) {
+	c.String(200, "ok")
+}
+
+type Server struct {
+	router gin.IRouter
+	api    gin.IRoutes
+}
+
+func (s *Server) routes() {
+	s.router.GET("/health", Health)
+	users := s.router.Group("/users")
+	users.GET("", ListUsers)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	s := &Server{router: r.Group("/fieldrouter")}
+	s.routes()
+
+	s.api = r.Group("/fieldrouter/api")
+	s.api.GET("/users", ListUsers)
+}
@@ -0,0 +1,24 @@
+// Package formbind c.ShouldBind 按结构体标签推断请求体格式
+package formbind
+
+import "github.com/gin-gonic/gin"
+
+type LoginForm struct {
+	Username string `form:"username"`
+	Password string `form:"password"`
+}
+
+func Login(c *gin.Context) {
+	var form LoginForm
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{"ok": true})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/formbind")
+	g.POST("/login", Login)
+}
@@ -0,0 +1,57 @@
+// Package groupmw 带中间件参数的路由分组
+package groupmw
+
+import "github.com/gin-gonic/gin"
+
+func AuthMW(c *gin.Context) {
+	c.Next()
+}
+
+func AuditMW() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+	}
+}
+
+func ListUsers(c *gin.Context) {
+	c.JSON(200, []string{})
+}
+
+type CreateUserReq struct {
+	Name string `json:"name"`
+}
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func RateLimitMW(c *gin.Context) {
+	c.Next()
+}
+
+func CreateUser(c *gin.Context) {
+	var req CreateUserReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.JSON(201, User{Name: req.Name})
+}
+
+func Health(c *gin.Context) {
+	c.String(200, "ok")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/groupmw")
+	g.GET("/health", Health)
+
+	admin := g.Group("/admin", AuthMW)
+	admin.GET("/users", ListUsers)
+	admin.POST("/users", RateLimitMW, AuditMW(), CreateUser)
+	g.Handle("DELETE", "/cache", AuthMW, Health)
+
+	audit := admin.Group("/audit", AuditMW())
+	audit.GET("/users", ListUsers)
+}
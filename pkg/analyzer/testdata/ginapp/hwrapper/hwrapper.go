@@ -0,0 +1,32 @@
+// Package hwrapper gin.H 的值为构造响应封装的函数调用
+package hwrapper
+
+import "github.com/gin-gonic/gin"
+
+type Result struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ResponseOK 构造成功响应封装
+func ResponseOK(c *gin.Context, data interface{}) Result {
+	return Result{Code: 0, Data: data}
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"data":  ResponseOK(c, User{}),
+		"trace": c.GetHeader("X-Trace-Id"),
+	})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/hwrapper")
+	g.GET("/user", GetUser)
+}
@@ -0,0 +1,75 @@
+// Package ifaceimpl 接口类型的响应字段：唯一实现展开，多个实现生成 oneOf
+package ifaceimpl
+
+import "github.com/gin-gonic/gin"
+
+// Notifier 只有一个实现
+type Notifier interface {
+	Notify() string
+}
+
+type EmailNotifier struct {
+	Address string `json:"address"`
+}
+
+func (EmailNotifier) Notify() string { return "email" }
+
+// Shape 有两个实现
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (Circle) Area() float64 { return 0 }
+
+type Square struct {
+	Side float64 `json:"side"`
+}
+
+func (Square) Area() float64 { return 0 }
+
+// Node 的唯一实现又包含 Node 字段，展开层数受递归深度限制
+type Node interface {
+	Children() []Node
+}
+
+type Tree struct {
+	Name  string `json:"name"`
+	Child Node   `json:"child"`
+}
+
+func (t Tree) Children() []Node { return []Node{t.Child} }
+
+type NotifierResp struct {
+	Notifier Notifier `json:"notifier"`
+}
+
+type ShapeResp struct {
+	Shape Shape `json:"shape"`
+}
+
+func GetNotifier(c *gin.Context) {
+	var resp NotifierResp
+	c.JSON(200, resp)
+}
+
+func GetTree(c *gin.Context) {
+	var root Node
+	c.JSON(200, gin.H{"root": root})
+}
+
+func GetShape(c *gin.Context) {
+	var resp ShapeResp
+	c.JSON(200, resp)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/ifaceimpl")
+	g.GET("/notifier", GetNotifier)
+	g.GET("/shape", GetShape)
+	g.GET("/tree", GetTree)
+}
@@ -0,0 +1,54 @@
+// Package ifacewrap 返回接口的响应封装函数
+package ifacewrap
+
+import "github.com/gin-gonic/gin"
+
+type Responder interface {
+	StatusCode() int
+}
+
+type Envelope struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+func (e *Envelope) StatusCode() int { return 200 }
+
+type ErrorEnvelope struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e ErrorEnvelope) StatusCode() int { return 400 }
+
+type User struct {
+	Name string `json:"name"`
+}
+
+// Success 声明返回 Responder，实际构造 *Envelope
+func Success(data interface{}) Responder {
+	return &Envelope{Code: 0, Data: data}
+}
+
+// Result 按是否出错构造不同的封装类型
+func Result(data interface{}, err error) Responder {
+	if err != nil {
+		return ErrorEnvelope{Code: 1, Message: err.Error()}
+	}
+	return &Envelope{Data: data}
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, Success(User{}))
+}
+
+func GetResult(c *gin.Context) {
+	c.JSON(200, Result(User{}, nil))
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/ifacewrap")
+	g.GET("/user", GetUser)
+	g.GET("/result", GetResult)
+}
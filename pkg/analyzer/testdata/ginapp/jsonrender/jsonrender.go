@@ -0,0 +1,47 @@
+// Package jsonrender c.JSON 之外的 JSON 渲染方法
+package jsonrender
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+type Item struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func Indented(c *gin.Context) {
+	c.IndentedJSON(200, Item{})
+}
+
+func Secure(c *gin.Context) {
+	c.SecureJSON(200, []Item{})
+}
+
+func Pure(c *gin.Context) {
+	c.PureJSON(200, Item{})
+}
+
+func Ascii(c *gin.Context) {
+	c.AsciiJSON(200, Item{})
+}
+
+func Jsonp(c *gin.Context) {
+	c.JSONP(200, Item{})
+}
+
+func JsonpRender(c *gin.Context) {
+	c.Render(200, render.JsonpJSON{Callback: c.Query("callback"), Data: Item{}})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/jsonrender")
+	g.GET("/indented", Indented)
+	g.GET("/secure", Secure)
+	g.GET("/pure", Pure)
+	g.GET("/ascii", Ascii)
+	g.GET("/jsonp", Jsonp)
+	g.GET("/jsonp-render", JsonpRender)
+}
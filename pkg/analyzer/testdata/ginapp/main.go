@@ -0,0 +1,139 @@
+// Package main 分析器测试使用的示例项目，每个子包对应一类场景，路由注册在与包同名的分组下
+package main
+
+import (
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/aliasimport"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/annotated"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/anonstruct"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/beegodata"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/binaryresp"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/blockscope"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/bodywith"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/created"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/crosscall"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/ctxresp"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/ctxset"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/customjson"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/customrender"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/dedupanon"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/defaulttag"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/deferresp"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/deprecated"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/docsummary"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/dotimport"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/embedreq"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/enumconst"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/exportedonly"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/factory"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/fieldcomment"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/fieldrouter"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/formbind"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/groupmw"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/hwrapper"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/ifaceimpl"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/ifacewrap"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/jsonrender"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/mapindex"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/multistatus"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/namedmap"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/nestedgroup"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/nestedh"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/nildata"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/normalize"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/pathcheck"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/pathparams"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/pkgvar"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/positional"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/querybind"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/querytag"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/rawjson"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/readonly"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/receivers"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/redirect"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/renderhelper"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/requiredbody"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/responder"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/routetable"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/samename"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/shapes"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/slicebind"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/sprintfpath"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/svcresult"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/switchresp"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/typealias"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/typemap"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/unresolved"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/unsupported"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/wrapf"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/wrapperstatus"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.New()
+	aliasimport.Register(r)
+	annotated.Register(r)
+	anonstruct.Register(r)
+	beegodata.Register(r)
+	binaryresp.Register(r)
+	blockscope.Register(r)
+	bodywith.Register(r)
+	created.Register(r)
+	crosscall.Register(r)
+	ctxresp.Register(r)
+	ctxset.Register(r)
+	customjson.Register(r)
+	customrender.Register(r)
+	dedupanon.Register(r)
+	defaulttag.Register(r)
+	deferresp.Register(r)
+	deprecated.Register(r)
+	docsummary.Register(r)
+	dotimport.Register(r)
+	embedreq.Register(r)
+	enumconst.Register(r)
+	exportedonly.Register(r)
+	factory.Register(r)
+	fieldcomment.Register(r)
+	fieldrouter.Register(r)
+	formbind.Register(r)
+	groupmw.Register(r)
+	hwrapper.Register(r)
+	ifaceimpl.Register(r)
+	ifacewrap.Register(r)
+	jsonrender.Register(r)
+	mapindex.Register(r)
+	multistatus.Register(r)
+	namedmap.Register(r)
+	nestedgroup.Register(r)
+	nestedh.Register(r)
+	nildata.Register(r)
+	normalize.Register(r)
+	pathcheck.Register(r)
+	pathparams.Register(r)
+	pkgvar.Register(r)
+	positional.Register(r)
+	querybind.Register(r)
+	querytag.Register(r)
+	rawjson.Register(r)
+	readonly.Register(r)
+	receivers.Register(r)
+	redirect.Register(r)
+	renderhelper.Register(r)
+	requiredbody.Register(r)
+	responder.Register(r)
+	routetable.Register(r)
+	samename.Register(r)
+	shapes.Register(r)
+	slicebind.Register(r)
+	sprintfpath.Register(r)
+	svcresult.Register(r)
+	switchresp.Register(r)
+	typealias.Register(r)
+	typemap.Register(r)
+	unresolved.Register(r)
+	unsupported.Register(r)
+	wrapf.Register(r)
+	wrapperstatus.Register(r)
+	r.Run()
+}
@@ -0,0 +1,61 @@
+// Package mapindex 通过索引赋值填充的 gin.H 响应与 append 构造的切片响应
+package mapindex
+
+import "github.com/gin-gonic/gin"
+
+const totalKey = "total"
+
+type Item struct {
+	ID int `json:"id"`
+}
+
+func GetStats(c *gin.Context) {
+	resp := gin.H{"code": 0}
+	resp["data"] = Item{}
+	resp[totalKey] = 10
+	if c.Query("debug") != "" {
+		resp["debug"] = "on"
+	}
+	c.JSON(200, resp)
+}
+
+func GetProfile(c *gin.Context) {
+	h := gin.H{"id": 1}
+	switch c.Query("role") {
+	case "admin":
+		h["perms"] = []string{"all"}
+	}
+	for _, tag := range c.QueryArray("tag") {
+		h["last_tag"] = tag
+	}
+	if c.Query("legacy") != "" {
+		h["id"] = "legacy"
+	}
+	h["name"] = "profile"
+	c.JSON(200, h)
+}
+
+func GetNested(c *gin.Context) {
+	data := map[string]interface{}{}
+	data["count"] = 1
+	c.JSON(200, gin.H{"data": data})
+}
+
+func ListItems(c *gin.Context) {
+	var list []Item
+	for i := 0; i < 3; i++ {
+		list = append(list, Item{ID: i})
+	}
+	ids := make([]int, 0, len(list))
+	_ = ids
+	c.JSON(200, list)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/mapindex")
+	g.GET("/stats", GetStats)
+	g.GET("/profile", GetProfile)
+	g.GET("/nested", GetNested)
+	g.GET("/items", ListItems)
+}
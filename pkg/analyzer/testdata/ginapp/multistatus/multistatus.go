@@ -0,0 +1,26 @@
+// Package multistatus 同时返回成功与错误响应的处理函数
+package multistatus
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func GetUser(c *gin.Context) {
+	if c.Param("id") == "" {
+		c.JSON(400, ErrorResponse{Message: "missing id"})
+		return
+	}
+	c.JSON(200, User{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/multistatus")
+	g.GET("/users/:id", GetUser)
+}
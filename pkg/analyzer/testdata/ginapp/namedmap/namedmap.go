@@ -0,0 +1,21 @@
+// Package namedmap 以map为底层类型的命名类型，仍是键不固定的map
+package namedmap
+
+import "github.com/gin-gonic/gin"
+
+type Labels map[string]string
+
+type Pod struct {
+	Name   string `json:"name"`
+	Labels Labels `json:"labels"`
+}
+
+func GetPod(c *gin.Context) {
+	c.JSON(200, Pod{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/namedmap")
+	g.GET("/pod", GetPod)
+}
@@ -0,0 +1,36 @@
+// Package nestedgroup 多级嵌套的路由分组
+package nestedgroup
+
+import "github.com/gin-gonic/gin"
+
+func Ping(c *gin.Context) {
+	c.String(200, "pong")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	registerDeep(&r.RouterGroup)
+
+	g := r.Group("/nestedgroup")
+	v1 := g.Group("/v1")
+	admin := v1.Group("/admin")
+	admin.GET("/stats", Ping)
+
+	registerUsers(v1)
+	registerOrders(v1.Group("/orders"))
+}
+
+func registerDeep(r *gin.RouterGroup) {
+	v := r.Group("/nestedgroup").Group("/v2").Group("/deep")
+	v.GET("/x", Ping)
+}
+
+func registerUsers(rg *gin.RouterGroup) {
+	users := rg.Group("/users")
+	profile := users.Group("/:id").Group("/profile")
+	profile.GET("", Ping)
+}
+
+func registerOrders(orders *gin.RouterGroup) {
+	orders.GET("/:id", Ping)
+}
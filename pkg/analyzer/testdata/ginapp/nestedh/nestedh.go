@@ -0,0 +1,26 @@
+// Package nestedh 多层嵌套 gin.H 字面量的响应
+package nestedh
+
+import "github.com/gin-gonic/gin"
+
+func GetDashboard(c *gin.Context) {
+	total := 42
+	c.JSON(200, gin.H{
+		"stats": gin.H{
+			"visits": gin.H{
+				"total": total,
+				"today": 3,
+			},
+			"region": "cn",
+		},
+		"widgets": []gin.H{
+			{"id": 1},
+			{"id": 2, "title": "orders"},
+		},
+	})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	r.GET("/nestedh/dashboard", GetDashboard)
+}
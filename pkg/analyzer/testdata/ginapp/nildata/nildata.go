@@ -0,0 +1,44 @@
+// Package nildata 响应封装函数的 data 参数为 nil
+package nildata
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Response struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// OK 成功响应
+func OK(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+func Converted(c *gin.Context) {
+	OK(c, (*User)(nil))
+}
+
+func Declared(c *gin.Context) {
+	var u *User
+	OK(c, u)
+}
+
+func Untyped(c *gin.Context) {
+	OK(c, nil)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/nildata")
+	g.GET("/converted", Converted)
+	g.GET("/declared", Declared)
+	g.GET("/untyped", Untyped)
+}
@@ -0,0 +1,19 @@
+// Package normalize 路由路径中的重复斜杠和末尾斜杠
+package normalize
+
+import "github.com/gin-gonic/gin"
+
+func Index(c *gin.Context) {
+	c.String(200, "ok")
+}
+
+func ListUsers(c *gin.Context) {
+	c.JSON(200, []string{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/normalize/")
+	g.GET("/", Index)
+	g.GET("//api/", ListUsers)
+}
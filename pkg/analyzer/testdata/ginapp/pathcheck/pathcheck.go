@@ -0,0 +1,27 @@
+// Package pathcheck 路由路径参数与处理函数读取的路径参数不一致
+package pathcheck
+
+import "github.com/gin-gonic/gin"
+
+// GetUser 读取了路径中不存在的 uid，路径中的 id 未被读取
+func GetUser(c *gin.Context) {
+	c.String(200, c.Param("uid"))
+}
+
+// GetOrder 路径参数与读取一致
+func GetOrder(c *gin.Context) {
+	c.String(200, c.Param("id"))
+}
+
+// ListItems 路径中的 shop 未被读取
+func ListItems(c *gin.Context) {
+	c.String(200, c.Param("category"))
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/pathcheck")
+	g.GET("/users/:id", GetUser)
+	g.GET("/orders/:id", GetOrder)
+	g.GET("/shops/:shop/items/:category", ListItems)
+}
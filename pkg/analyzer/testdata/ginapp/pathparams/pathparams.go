@@ -0,0 +1,26 @@
+// Package pathparams 通过 c.Params.ByName 和常量键读取路径参数
+package pathparams
+
+import "github.com/gin-gonic/gin"
+
+const orderKey = "orderID"
+
+func GetUser(c *gin.Context) {
+	c.String(200, c.Params.ByName("id"))
+}
+
+func GetOrder(c *gin.Context) {
+	c.String(200, c.Param(orderKey))
+}
+
+func GetItem(c *gin.Context) {
+	c.String(200, c.Param("id")+c.Param("missing"))
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/pathparams")
+	g.GET("/users/:id", GetUser)
+	g.GET("/orders/:orderID", GetOrder)
+	g.GET("/items/:id", GetItem)
+}
@@ -0,0 +1,13 @@
+// Package defaults 其他包中定义的默认响应
+package defaults
+
+type Limits struct {
+	MaxItems int `json:"max_items"`
+}
+
+type Envelope struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+var Response = Envelope{Data: Limits{MaxItems: 100}}
@@ -0,0 +1,40 @@
+// Package pkgvar 以包级变量作为响应
+package pkgvar
+
+import (
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/pkgvar/defaults"
+	"github.com/gin-gonic/gin"
+)
+
+type Settings struct {
+	Theme string `json:"theme"`
+}
+
+type Config struct {
+	Name string      `json:"name"`
+	Data interface{} `json:"data"`
+}
+
+var DefaultConfig = Config{Name: "default", Data: Settings{Theme: "dark"}}
+
+var defaultPtr = &Config{Data: []Settings{}}
+
+func GetConfig(c *gin.Context) {
+	c.JSON(200, DefaultConfig)
+}
+
+func GetConfigPtr(c *gin.Context) {
+	c.JSON(200, defaultPtr)
+}
+
+func GetDefaults(c *gin.Context) {
+	c.JSON(200, defaults.Response)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/pkgvar")
+	g.GET("/config", GetConfig)
+	g.GET("/config-ptr", GetConfigPtr)
+	g.GET("/defaults", GetDefaults)
+}
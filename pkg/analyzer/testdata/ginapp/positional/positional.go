@@ -0,0 +1,23 @@
+// Package positional 按位置赋值的结构体字面量响应
+package positional
+
+import "github.com/gin-gonic/gin"
+
+type Envelope struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+type Coupon struct {
+	Code     string `json:"code"`
+	Discount int    `json:"discount"`
+}
+
+func GetCoupon(c *gin.Context) {
+	c.JSON(200, Envelope{0, Coupon{}})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	r.GET("/positional/coupon", GetCoupon)
+}
@@ -0,0 +1,38 @@
+// Package querybind 查询参数与路径参数结构体的必填校验
+package querybind
+
+import "github.com/gin-gonic/gin"
+
+type SearchQuery struct {
+	Q    string `form:"q" binding:"required"`
+	Page int    `form:"page"`
+	Sort string
+}
+
+type ItemURI struct {
+	ID  string `uri:"id" binding:"required"`
+	Rev string `uri:"rev"`
+}
+
+func Search(c *gin.Context) {
+	var query SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return
+	}
+	c.String(200, query.Q)
+}
+
+func GetItem(c *gin.Context) {
+	var uri ItemURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		return
+	}
+	c.String(200, uri.ID)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/querybind")
+	g.GET("/search", Search)
+	g.GET("/items/:id/:rev", GetItem)
+}
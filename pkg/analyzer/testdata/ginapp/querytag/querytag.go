@@ -0,0 +1,25 @@
+// Package querytag 使用第三方绑定库 query 标签的查询参数结构体
+package querytag
+
+import "github.com/gin-gonic/gin"
+
+type SearchQuery struct {
+	Keyword  string `query:"kw" form:"keyword"`
+	PageSize int    `query:"page_size"`
+	Sort     string `form:"sort"`
+	Internal string `query:"-" form:"internal"`
+}
+
+func Search(c *gin.Context) {
+	var query SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return
+	}
+	c.String(200, query.Keyword)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/querytag")
+	g.GET("/search", Search)
+}
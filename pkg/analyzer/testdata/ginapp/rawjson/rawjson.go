@@ -0,0 +1,24 @@
+// Package rawjson json.RawMessage 字段表示任意JSON
+package rawjson
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Event struct {
+	Name    string           `json:"name"`
+	Payload json.RawMessage  `json:"payload"`
+	Extra   *json.RawMessage `json:"extra"`
+}
+
+func GetEvent(c *gin.Context) {
+	c.JSON(200, Event{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/rawjson")
+	g.GET("/event", GetEvent)
+}
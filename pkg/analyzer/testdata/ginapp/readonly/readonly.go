@@ -0,0 +1,25 @@
+// Package readonly 只读、只写字段
+package readonly
+
+import "github.com/gin-gonic/gin"
+
+type Audit struct {
+	By string `json:"by"`
+}
+
+type Account struct {
+	ID       int64  `json:"id" readonly:"true"`
+	Name     string `json:"name"`
+	Password string `json:"password" writeonly:"true"`
+	Audit    Audit  `json:"audit" readonly:"true"`
+}
+
+func GetAccount(c *gin.Context) {
+	c.JSON(200, Account{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/readonly")
+	g.GET("/account", GetAccount)
+}
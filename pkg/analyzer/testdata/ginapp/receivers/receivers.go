@@ -0,0 +1,38 @@
+// Package receivers 多个类型定义同名的 Get 方法，另有同名的普通函数
+package receivers
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Order struct {
+	Total float64 `json:"total"`
+}
+
+type UserHandler struct{}
+
+func (h *UserHandler) Get(c *gin.Context) {
+	c.JSON(200, User{})
+}
+
+type OrderHandler struct{}
+
+func (h OrderHandler) Get(c *gin.Context) {
+	c.JSON(200, Order{})
+}
+
+func Get(c *gin.Context) {
+	c.String(200, "free function")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	users := &UserHandler{}
+	orders := OrderHandler{}
+	g := r.Group("/receivers")
+	g.GET("/user", users.Get)
+	g.GET("/order", orders.Get)
+	g.GET("/free", Get)
+}
@@ -0,0 +1,38 @@
+// Package redirect 只重定向、没有响应体的接口
+package redirect
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Login(c *gin.Context) {
+	c.Redirect(http.StatusFound, "/sso/login")
+}
+
+func Legacy(c *gin.Context) {
+	moved(c.Writer, c.Request)
+}
+
+// moved 在项目内函数中重定向
+func moved(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/v2"+r.URL.Path, http.StatusMovedPermanently)
+}
+
+// Callback 出错时重定向，成功时返回JSON，不算只重定向的接口
+func Callback(c *gin.Context) {
+	if c.Query("code") == "" {
+		c.Redirect(http.StatusFound, "/sso/login")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": c.Query("code")})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/redirect")
+	g.GET("/login", Login)
+	g.GET("/legacy", Legacy)
+	g.GET("/callback", Callback)
+}
@@ -0,0 +1,35 @@
+// Package renderhelper 处理函数调用辅助方法组装并渲染响应
+package renderhelper
+
+import "github.com/gin-gonic/gin"
+
+type envelope struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+type Order struct {
+	ID    int     `json:"id"`
+	Total float64 `json:"total"`
+}
+
+type OrderController struct{}
+
+// render 组装响应封装并输出
+func (ctl *OrderController) render(c *gin.Context, data interface{}) {
+	resp := envelope{Code: 0, Data: data}
+	c.JSON(200, resp)
+}
+
+func (ctl *OrderController) GetOrder(c *gin.Context) {
+	order := Order{ID: 1}
+	ctl.render(c, order)
+	return
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	ctl := &OrderController{}
+	g := r.Group("/renderhelper")
+	g.GET("/order", ctl.GetOrder)
+}
@@ -0,0 +1,24 @@
+// Package requiredbody 请求体字段的必填校验标签
+package requiredbody
+
+import "github.com/gin-gonic/gin"
+
+type CreateUserRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" binding:"omitempty,gte=0"`
+}
+
+func CreateUser(c *gin.Context) {
+	var req CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.JSON(200, req)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/requiredbody")
+	g.POST("/users", CreateUser)
+}
@@ -0,0 +1,41 @@
+// Package responder 通过注入的响应器接口方法返回响应
+package responder
+
+import "github.com/gin-gonic/gin"
+
+type Body struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+// Responder 响应器接口
+type Responder interface {
+	OK(c *gin.Context, data interface{})
+}
+
+type jsonResponder struct{}
+
+// OK 返回成功响应
+func (jsonResponder) OK(c *gin.Context, data interface{}) {
+	c.JSON(200, Body{Code: 0, Data: data})
+}
+
+type Item struct {
+	SKU   string `json:"sku"`
+	Price int    `json:"price"`
+}
+
+type Handler struct {
+	resp Responder
+}
+
+func (h *Handler) GetItem(c *gin.Context) {
+	h.resp.OK(c, Item{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	h := &Handler{resp: jsonResponder{}}
+	g := r.Group("/responder")
+	g.GET("/item", h.GetItem)
+}
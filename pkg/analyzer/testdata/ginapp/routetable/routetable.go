@@ -0,0 +1,44 @@
+// Package routetable 声明式路由表，在循环中注册
+package routetable
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type route struct {
+	Method  string
+	Path    string
+	Handler gin.HandlerFunc
+}
+
+type Order struct {
+	ID int `json:"id"`
+}
+
+func ListOrders(c *gin.Context) {
+	c.JSON(200, []Order{})
+}
+
+func CreateOrder(c *gin.Context) {
+	var order Order
+	if err := c.ShouldBindJSON(&order); err != nil {
+		return
+	}
+	c.JSON(200, order)
+}
+
+var routes = []route{
+	{Method: http.MethodGet, Path: "/orders", Handler: ListOrders},
+	{Method: "POST", Path: "/orders", Handler: CreateOrder},
+	{"DELETE", "/orders/:id", func(c *gin.Context) { c.Status(204) }},
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/routetable")
+	for _, rt := range routes {
+		g.Handle(rt.Method, rt.Path, rt.Handler)
+	}
+}
@@ -0,0 +1,6 @@
+// Package account 与 profile 包定义同名的 UserInfo
+package account
+
+type UserInfo struct {
+	Username string `json:"username"`
+}
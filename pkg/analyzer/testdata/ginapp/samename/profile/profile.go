@@ -0,0 +1,6 @@
+// Package profile 与 account 包定义同名的 UserInfo
+package profile
+
+type UserInfo struct {
+	Nickname string `json:"nickname"`
+}
@@ -0,0 +1,23 @@
+// Package samename 不同包中的同名结构体作为响应
+package samename
+
+import (
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/samename/account"
+	"github.com/YogeLiu/api-tool/pkg/analyzer/testdata/ginapp/samename/profile"
+	"github.com/gin-gonic/gin"
+)
+
+func GetAccount(c *gin.Context) {
+	c.JSON(200, account.UserInfo{})
+}
+
+func GetProfile(c *gin.Context) {
+	c.JSON(200, profile.UserInfo{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/samename")
+	g.GET("/account", GetAccount)
+	g.GET("/profile", GetProfile)
+}
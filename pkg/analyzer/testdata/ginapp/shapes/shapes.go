@@ -0,0 +1,24 @@
+// Package shapes 指针切片、切片指针和二维切片字段
+package shapes
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Shapes struct {
+	Pointers []*User  `json:"pointers"`
+	SlicePtr *[]User  `json:"slice_ptr"`
+	Matrix   [][]User `json:"matrix"`
+}
+
+func GetShapes(c *gin.Context) {
+	c.JSON(200, Shapes{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/shapes")
+	g.GET("", GetShapes)
+}
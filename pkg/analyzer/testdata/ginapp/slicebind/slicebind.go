@@ -0,0 +1,23 @@
+// Package slicebind 请求体绑定到结构体切片
+package slicebind
+
+import "github.com/gin-gonic/gin"
+
+type CreateReq struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func BatchCreate(c *gin.Context) {
+	var reqs []CreateReq
+	if err := c.ShouldBindJSON(&reqs); err != nil {
+		return
+	}
+	c.JSON(200, gin.H{"created": len(reqs)})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/slicebind")
+	g.POST("/batch", BatchCreate)
+}
@@ -0,0 +1,27 @@
+// Package sprintfpath 通过 fmt.Sprintf 和字符串拼接构造的路由路径
+package sprintfpath
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+const prefix = "/items"
+
+var kind string
+
+func ListItems(c *gin.Context) {
+	c.JSON(200, []string{})
+}
+
+func GetItem(c *gin.Context) {
+	c.JSON(200, "")
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/sprintfpath")
+	g.GET(fmt.Sprintf(prefix+"/%s", kind), ListItems)
+	g.GET(prefix+"/detail", GetItem)
+}
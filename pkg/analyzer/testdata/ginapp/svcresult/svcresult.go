@@ -0,0 +1,72 @@
+// Package svcresult 由服务调用 (T, error) 结果提供的响应
+package svcresult
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Profile struct {
+	Bio string `json:"bio"`
+}
+
+type Result interface{}
+
+type Service struct{}
+
+func (s *Service) GetUser(id string) (User, error) {
+	if id == "" {
+		return User{}, errors.New("empty id")
+	}
+	return User{Name: id}, nil
+}
+
+// FindProfile 声明返回接口，实际返回 *Profile
+func (s *Service) FindProfile(id string) (Result, error) {
+	if id == "" {
+		return nil, errors.New("empty id")
+	}
+	return &Profile{}, nil
+}
+
+// Lookup 返回值直接来自另一个返回接口的调用
+func (s *Service) Lookup(id string) (interface{}, error) {
+	return s.FindProfile(id)
+}
+
+var svc = &Service{}
+
+func GetUser(c *gin.Context) {
+	user, err := svc.GetUser(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, user)
+}
+
+func GetProfile(c *gin.Context) {
+	profile, err := svc.FindProfile(c.Param("id"))
+	if err != nil {
+		return
+	}
+	c.JSON(200, profile)
+}
+
+func LookupProfile(c *gin.Context) {
+	result, _ := svc.Lookup(c.Param("id"))
+	c.JSON(200, result)
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/svcresult")
+	g.GET("/users/:id", GetUser)
+	g.GET("/profiles/:id", GetProfile)
+	g.GET("/lookup/:id", LookupProfile)
+}
@@ -0,0 +1,23 @@
+// Package switchresp 在 switch 的各分支中返回不同的响应
+package switchresp
+
+import "github.com/gin-gonic/gin"
+
+type Order struct {
+	ID string `json:"id"`
+}
+
+func GetOrder(c *gin.Context) {
+	switch c.Query("state") {
+	case "found":
+		c.JSON(200, Order{ID: c.Param("id")})
+	default:
+		c.JSON(404, gin.H{"error": "not found"})
+	}
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/switchresp")
+	g.GET("/orders/:id", GetOrder)
+}
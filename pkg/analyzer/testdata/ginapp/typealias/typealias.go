@@ -0,0 +1,28 @@
+// Package typealias 别名声明（type X = Y）的字段类型按右侧类型解析
+package typealias
+
+import "github.com/gin-gonic/gin"
+
+type UserID = int64
+
+type JSONMap = map[string]interface{}
+
+type Address = struct {
+	City string `json:"city"` // 城市
+}
+
+type User struct {
+	ID      UserID  `json:"id"`
+	Extra   JSONMap `json:"extra"`
+	Address Address `json:"address"`
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, User{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/typealias")
+	g.GET("/user", GetUser)
+}
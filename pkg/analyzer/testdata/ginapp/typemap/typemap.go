@@ -0,0 +1,23 @@
+// Package typemap 领域标量类型
+package typemap
+
+import "github.com/gin-gonic/gin"
+
+type Email string
+
+type Money int64
+
+type Contact struct {
+	Email   Email `json:"email"`
+	Balance Money `json:"balance"`
+}
+
+func GetContact(c *gin.Context) {
+	c.JSON(200, Contact{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/typemap")
+	g.GET("/contact", GetContact)
+}
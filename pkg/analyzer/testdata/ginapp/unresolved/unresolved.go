@@ -0,0 +1,17 @@
+// Package unresolved 处理函数无法静态解析的路由
+package unresolved
+
+import "github.com/gin-gonic/gin"
+
+func List(c *gin.Context) {
+	c.String(200, "list")
+}
+
+var handlers = map[string]gin.HandlerFunc{"list": List}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/unresolved")
+	g.GET("/dynamic", handlers["list"])
+	g.GET("/list", List)
+}
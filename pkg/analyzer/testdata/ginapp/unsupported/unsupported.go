@@ -0,0 +1,31 @@
+// Package unsupported 响应结构体中包含 encoding/json 无法序列化的字段
+package unsupported
+
+import "github.com/gin-gonic/gin"
+
+// Duration 实现了 MarshalText 的 func 类型，可以序列化
+type Duration func() int64
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte("1s"), nil
+}
+
+type Job struct {
+	ID       string          `json:"id"`
+	Done     chan struct{}   `json:"done"`
+	Callback func(err error) `json:"callback"`
+	Phase    complex128      `json:"phase"`
+	Cancel   *func()         `json:"cancel"`
+	Timeout  Duration        `json:"timeout"`
+	Results  chan<- []string `json:"-"`
+	Status   string          `json:"status"`
+}
+
+func GetJob(c *gin.Context) {
+	c.JSON(200, Job{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	r.GET("/unsupported/job", GetJob)
+}
@@ -0,0 +1,23 @@
+// Package wrapf 通过 gin.WrapF 适配的标准库处理函数
+package wrapf
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Health struct {
+	Status string `json:"status"`
+}
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Health{Status: "ok"})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/wrapf")
+	g.GET("/health", gin.WrapF(healthHandler))
+}
@@ -0,0 +1,76 @@
+// Package wrapperstatus 响应封装函数的状态码与不同分支的成功响应
+package wrapperstatus
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Response struct {
+	Code int         `json:"code"`
+	Msg  string      `json:"msg"`
+	Data interface{} `json:"data"`
+}
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// OK 成功响应
+func OK(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+// Fail 参数错误响应
+func Fail(c *gin.Context, code int, msg string) {
+	c.JSON(http.StatusBadRequest, Response{Code: code, Msg: msg})
+}
+
+// Abort 状态码由调用方传入的错误响应
+func Abort(c *gin.Context, status int, msg string) {
+	c.JSON(status, Response{Code: status, Msg: msg})
+}
+
+func load(id string) (User, error) {
+	if id == "" {
+		return User{}, errors.New("id is required")
+	}
+	return User{}, nil
+}
+
+func GetUser(c *gin.Context) {
+	u, err := load(c.Param("id"))
+	if err != nil {
+		Fail(c, 2, err.Error())
+		return
+	}
+	OK(c, u)
+}
+
+func GetProfile(c *gin.Context) {
+	u, err := load(c.Param("id"))
+	if err != nil {
+		Abort(c, http.StatusNotFound, err.Error())
+		return
+	}
+	OK(c, u)
+}
+
+func Search(c *gin.Context) {
+	if c.Query("detail") == "" {
+		OK(c, []string{})
+		return
+	}
+	OK(c, User{})
+}
+
+// Register 注册路由
+func Register(r *gin.Engine) {
+	g := r.Group("/wrapperstatus")
+	g.GET("/user/:id", GetUser)
+	g.GET("/profile/:id", GetProfile)
+	g.GET("/search", Search)
+}
@@ -0,0 +1,13 @@
+// Package context 测试用的 Iris 请求上下文桩代码
+package context
+
+type Context struct{}
+
+func (ctx *Context) ReadJSON(v interface{}) error         { return nil }
+func (ctx *Context) ReadQuery(v interface{}) error        { return nil }
+func (ctx *Context) URLParam(name string) string          { return "" }
+func (ctx *Context) URLParamInt(name string) (int, error) { return 0, nil }
+func (ctx *Context) StatusCode(code int)                  {}
+func (ctx *Context) JSON(v interface{}) error             { return nil }
+
+type Handler func(*Context)
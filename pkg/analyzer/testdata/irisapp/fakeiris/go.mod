@@ -0,0 +1,3 @@
+module github.com/kataras/iris/v12
+
+go 1.20
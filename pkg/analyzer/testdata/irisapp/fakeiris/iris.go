@@ -0,0 +1,16 @@
+// Package iris 测试用的 Iris 框架桩代码，只保留分析所需的类型和方法签名
+package iris
+
+import "github.com/kataras/iris/v12/context"
+
+type (
+	Context = *context.Context
+	Handler = context.Handler
+)
+
+type Application struct{}
+
+func New() *Application                               { return &Application{} }
+func (a *Application) Get(path string, h ...Handler)  {}
+func (a *Application) Post(path string, h ...Handler) {}
+func (a *Application) Listen(addr string) error       { return nil }
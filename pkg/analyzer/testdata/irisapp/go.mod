@@ -0,0 +1,7 @@
+module example.com/irisapp
+
+go 1.20
+
+require github.com/kataras/iris/v12 v12.2.0
+
+replace github.com/kataras/iris/v12 => ./fakeiris
@@ -0,0 +1,64 @@
+// Package main Iris 示例项目
+package main
+
+import "github.com/kataras/iris/v12"
+
+type Response struct {
+	Code int         `json:"code"`
+	Msg  string      `json:"msg"`
+	Data interface{} `json:"data"`
+}
+
+type CreateUserReq struct {
+	// 用户名
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email,omitempty"`
+}
+
+type ListQuery struct {
+	Page int    `url:"page" default:"1"`
+	Sort string `url:"sort"`
+}
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// RespondOK 成功响应
+func RespondOK(ctx iris.Context, data interface{}) {
+	ctx.JSON(Response{Data: data})
+}
+
+func CreateUser(ctx iris.Context) {
+	var req CreateUserReq
+	if err := ctx.ReadJSON(&req); err != nil {
+		ctx.StatusCode(400)
+		return
+	}
+	RespondOK(ctx, User{Name: req.Name})
+}
+
+func ListUsers(ctx iris.Context) {
+	var query ListQuery
+	ctx.ReadQuery(&query)
+	keyword := ctx.URLParam("keyword")
+	limit, _ := ctx.URLParamInt("limit")
+	users := make([]User, 0, limit)
+	if keyword != "" {
+		users = append(users, User{Name: keyword})
+	}
+	RespondOK(ctx, users)
+}
+
+func GetUser(ctx iris.Context) {
+	ctx.JSON(User{})
+}
+
+func main() {
+	app := iris.New()
+	app.Post("/users", CreateUser)
+	app.Get("/users", ListUsers)
+	app.Get("/user", GetUser)
+	app.Listen(":8080")
+}
@@ -0,0 +1,7 @@
+//go:build !prod
+
+package main
+
+import "github.com/gin-gonic/gin"
+
+func registerProd(r *gin.Engine) {}
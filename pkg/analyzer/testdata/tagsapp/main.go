@@ -0,0 +1,16 @@
+// Package main 路由文件带构建约束的示例项目
+package main
+
+import "github.com/gin-gonic/gin"
+
+func Ping(c *gin.Context) {
+	c.String(200, "pong")
+}
+
+func main() {
+	r := gin.New()
+	r.GET("/ping", Ping)
+	registerProd(r)
+	registerPlatform(r)
+	r.Run()
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package main
+
+import "github.com/gin-gonic/gin"
+
+func registerPlatform(r *gin.Engine) {}
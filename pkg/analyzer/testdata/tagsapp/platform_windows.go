@@ -0,0 +1,11 @@
+package main
+
+import "github.com/gin-gonic/gin"
+
+func Service(c *gin.Context) {
+	c.String(200, "windows")
+}
+
+func registerPlatform(r *gin.Engine) {
+	r.GET("/service", Service)
+}
@@ -0,0 +1,13 @@
+//go:build prod
+
+package main
+
+import "github.com/gin-gonic/gin"
+
+func Metrics(c *gin.Context) {
+	c.String(200, "ok")
+}
+
+func registerProd(r *gin.Engine) {
+	r.GET("/metrics", Metrics)
+}
@@ -0,0 +1,22 @@
+// Package main 在测试文件中注册路由的示例项目
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Echo struct {
+	Message string `json:"message"`
+}
+
+func Ping(c *gin.Context) {
+	c.String(200, "pong")
+}
+
+func EchoMessage(c *gin.Context) {
+	c.JSON(200, Echo{Message: c.Query("message")})
+}
+
+func main() {
+	r := gin.New()
+	r.GET("/ping", Ping)
+	r.Run()
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEcho(t *testing.T) {
+	r := gin.New()
+	r.GET("/echo", EchoMessage)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/echo?message=hi", nil))
+	if w.Code != 200 {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+}
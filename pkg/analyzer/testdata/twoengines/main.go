@@ -0,0 +1,33 @@
+// Package main 同时运行 API 与管理后台两个 Gin 服务的示例项目
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Status struct {
+	OK bool `json:"ok"`
+}
+
+func Health(c *gin.Context) {
+	c.JSON(200, Status{OK: true})
+}
+
+func ListUsers(c *gin.Context) {
+	c.String(200, "users")
+}
+
+func Stats(c *gin.Context) {
+	c.String(200, "stats")
+}
+
+func main() {
+	api := gin.New()
+	api.GET("/health", Health)
+	api.GET("/users", ListUsers)
+
+	admin := gin.New()
+	admin.GET("/health", Health)
+	admin.GET("/stats", Stats)
+
+	go admin.Run(":9090")
+	api.Run(":8080")
+}
@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// validatePathParams 校验路由路径中的 :name、*name 段与处理函数实际读取的路径参数，不一致时记录警告：
+// 处理函数读取了路径中不存在的参数（取值总为空）时去除该参数；gin 路由中的路径参数未被处理函数读取时同样提示
+func (a *Analyzer) validatePathParams(route *models.RouteInfo) {
+	segments := pathParamSegments(route.Path)
+
+	var unknown []string
+	route.RequestParams, unknown = checkPathParams(route.RequestParams, segments)
+	for _, name := range unknown {
+		log.Printf("[DEBUG] 路径参数 %s 不在路由 %s 中，已忽略\n", name, route.Path)
+		a.warnings = append(a.warnings, fmt.Sprintf("%s %s: 处理函数读取的路径参数 %s 不在路由路径中", route.Method, route.Path, name))
+	}
+
+	// 其他框架读取路径参数的方式尚未完全识别，只检查 gin 路由；无法解析处理函数时不检查
+	if route.HandlerUnresolved || a.extractor.GetFrameworkName() != "gin" {
+		return
+	}
+	read := make(map[string]bool)
+	for _, param := range route.RequestParams {
+		if param.ParamType == "path" {
+			read[param.ParamName] = true
+		}
+	}
+	for _, name := range segments {
+		if !read[name] {
+			a.warnings = append(a.warnings, fmt.Sprintf("%s %s: 路径参数 %s 未被处理函数读取", route.Method, route.Path, name))
+		}
+	}
+}
+
+// pathParamSegments 路由路径中 :name、*name 段的参数名，按出现顺序
+func pathParamSegments(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			names = append(names, segment[1:])
+		}
+	}
+	return names
+}
+
+// checkPathParams 将处理函数中读取的路径参数（c.Param、c.Params.ByName、c.ShouldBindUri 绑定的字段）与路由路径中的参数段对照：
+// 路由中不存在的参数取值总为空，去除后返回其名称；同名参数只保留一个
+func checkPathParams(params []models.RequestParamInfo, segments []string) ([]models.RequestParamInfo, []string) {
+	inPath := make(map[string]bool, len(segments))
+	for _, name := range segments {
+		inPath[name] = true
+	}
+
+	var checked []models.RequestParamInfo
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, param := range params {
+		if param.ParamType == "path" && (strings.HasPrefix(param.Source, "c.Param") || param.Source == "c.ShouldBindUri") {
+			if seen[param.ParamName] {
+				continue
+			}
+			seen[param.ParamName] = true
+			if !inPath[param.ParamName] {
+				unknown = append(unknown, param.ParamName)
+				continue
+			}
+		}
+		checked = append(checked, param)
+	}
+	return checked, unknown
+}
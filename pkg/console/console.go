@@ -0,0 +1,105 @@
+// 文件位置: pkg/console/console.go
+package console
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// noEmoji 为 true 时输出中的 emoji 替换为ASCII标记，便于日志解析和CI
+// 设置了 NO_COLOR 环境变量 (https://no-color.org) 时默认开启
+var noEmoji = os.Getenv("NO_COLOR") != ""
+
+// 常用 emoji 对应的ASCII标记，其余 emoji 直接去掉
+var emojiReplacer = strings.NewReplacer(
+	"✅", "[OK]",
+	"❌", "[ERROR]",
+	"⚠️", "[WARN]",
+	"⚠", "[WARN]",
+	"📊", "[STAT]",
+	"📝", "[NOTE]",
+	"💡", "[TIP]",
+	"🚀", "[RUN]",
+	"🔍", "[INFO]",
+	"📋", "[INFO]",
+	"⏱", "[TIME]",
+)
+
+// SetNoEmoji 设置是否关闭 emoji 输出（如 -no-emoji 参数）
+func SetNoEmoji(enabled bool) {
+	noEmoji = enabled
+}
+
+// NoEmoji 返回当前是否关闭 emoji 输出
+func NoEmoji() bool {
+	return noEmoji
+}
+
+// Text 关闭 emoji 输出时将文本中的 emoji 替换为ASCII标记，否则原样返回
+func Text(s string) string {
+	if !noEmoji {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, emojiReplacer.Replace(s))
+}
+
+// isEmoji 判断字符是否属于 emoji 及其修饰符
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // 图形符号、表情
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // 杂项符号、装饰符号
+		return true
+	case r >= 0x23E9 && r <= 0x23FA: // 媒体控制符号 (⏱ 等)
+		return true
+	case r == 0xFE0F || r == 0x200D: // 变体选择符、零宽连接符
+		return true
+	}
+	return false
+}
+
+// Printf 输出格式化的状态信息到标准输出
+func Printf(format string, a ...interface{}) {
+	fmt.Print(Text(fmt.Sprintf(format, a...)))
+}
+
+// Println 输出一行状态信息到标准输出
+func Println(a ...interface{}) {
+	fmt.Print(Text(fmt.Sprintln(a...)))
+}
+
+// Fprintf 输出格式化的状态信息到 w
+func Fprintf(w io.Writer, format string, a ...interface{}) {
+	fmt.Fprint(w, Text(fmt.Sprintf(format, a...)))
+}
+
+// Fprintln 输出一行状态信息到 w
+func Fprintln(w io.Writer, a ...interface{}) {
+	fmt.Fprint(w, Text(fmt.Sprintln(a...)))
+}
+
+// Writer 返回按当前设置过滤 emoji 的 Writer，用于 log.SetOutput
+func Writer(w io.Writer) io.Writer {
+	return writer{w}
+}
+
+type writer struct {
+	w io.Writer
+}
+
+func (w writer) Write(p []byte) (int, error) {
+	if !noEmoji {
+		return w.w.Write(p)
+	}
+	if _, err := io.WriteString(w.w, Text(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,43 @@
+package console
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// hasEmoji 检查文本中是否包含 emoji
+func hasEmoji(s string) bool {
+	return strings.IndexFunc(s, isEmoji) >= 0
+}
+
+func TestNoEmojiOutput(t *testing.T) {
+	defer SetNoEmoji(NoEmoji())
+	messages := []string{
+		"✅ JSON输出已保存到: api.json\n",
+		"⚠️ 2 个路由无法解析处理函数\n",
+		"🔍 分析项目 ./example 🎉\n",
+		"⏱ analyze 1.2s\n",
+	}
+
+	SetNoEmoji(true)
+	var buf bytes.Buffer
+	for _, message := range messages {
+		Fprintf(&buf, "%s", message)
+	}
+	Writer(&buf).Write([]byte("📋 路由列表\n"))
+	output := buf.String()
+	if hasEmoji(output) {
+		t.Errorf("关闭 emoji 后输出不应包含 emoji:\n%s", output)
+	}
+	for _, marker := range []string{"[OK] JSON输出已保存到", "[WARN] 2 个路由", "[INFO] 分析项目 ./example \n", "[TIME] analyze", "[INFO] 路由列表"} {
+		if !strings.Contains(output, marker) {
+			t.Errorf("输出应包含 %q:\n%s", marker, output)
+		}
+	}
+
+	SetNoEmoji(false)
+	if got := Text(messages[0]); got != messages[0] {
+		t.Errorf("未关闭 emoji 时应原样输出，实际为 %q", got)
+	}
+}
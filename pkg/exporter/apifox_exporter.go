@@ -0,0 +1,588 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// Apifox 项目导出格式的版本信息
+const (
+	apifoxProjectVersion = "1.0.0"
+	apifoxSchemaVersion  = "1.2.0"
+)
+
+// ApifoxProject Apifox项目导入文件 (Apifox 格式的项目导出结构)
+type ApifoxProject struct {
+	ApifoxProject    string             `json:"apifoxProject"`
+	Schema           ApifoxSchemaInfo   `json:"$schema"`
+	Info             ApifoxInfo         `json:"info"`
+	APICollection    []*ApifoxFolder    `json:"apiCollection"`
+	SchemaCollection []interface{}      `json:"schemaCollection"`
+	Environments     []ApifoxEnvMapping `json:"environments"`
+}
+
+// ApifoxSchemaInfo 导入文件的格式声明
+type ApifoxSchemaInfo struct {
+	App     string `json:"app"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+}
+
+// ApifoxInfo 项目信息
+type ApifoxInfo struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	MockRule    ApifoxMockRule `json:"mockRule"`
+}
+
+// ApifoxMockRule 项目的Mock规则，使用系统内置规则
+type ApifoxMockRule struct {
+	Rules            []interface{} `json:"rules"`
+	EnableSystemRule bool          `json:"enableSystemRule"`
+}
+
+// ApifoxEnvMapping 环境及其前置URL
+type ApifoxEnvMapping struct {
+	Name     string            `json:"name"`
+	BaseURLs map[string]string `json:"baseUrls"`
+}
+
+// ApifoxFolder 接口目录，Items 中为子目录 (*ApifoxFolder) 或接口 (*ApifoxAPIItem)
+type ApifoxFolder struct {
+	Name        string        `json:"name"`
+	ID          int           `json:"id"`
+	ParentID    int           `json:"parentId"`
+	Description string        `json:"description"`
+	Items       []interface{} `json:"items"`
+}
+
+// ApifoxAPIItem 目录中的接口
+type ApifoxAPIItem struct {
+	Name string    `json:"name"`
+	API  ApifoxAPI `json:"api"`
+}
+
+// ApifoxAPI 接口定义
+type ApifoxAPI struct {
+	ID               string                  `json:"id"`
+	Method           string                  `json:"method"`
+	Path             string                  `json:"path"`
+	Parameters       ApifoxParameters        `json:"parameters"`
+	RequestBody      ApifoxRequestBody       `json:"requestBody"`
+	Responses        []ApifoxResponse        `json:"responses"`
+	ResponseExamples []ApifoxResponseExample `json:"responseExamples"`
+	Description      string                  `json:"description"`
+	Tags             []string                `json:"tags"`
+	Status           string                  `json:"status"`
+	Ordering         int                     `json:"ordering"`
+}
+
+// ApifoxParameters 按位置分组的请求参数
+type ApifoxParameters struct {
+	Path   []ApifoxParameter `json:"path"`
+	Query  []ApifoxParameter `json:"query"`
+	Header []ApifoxParameter `json:"header"`
+	Cookie []ApifoxParameter `json:"cookie"`
+}
+
+// ApifoxParameter 请求参数或表单字段
+type ApifoxParameter struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Required    bool        `json:"required"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Example     interface{} `json:"example,omitempty"`
+	Enable      bool        `json:"enable"`
+}
+
+// ApifoxRequestBody 请求体，Type 为 none 或内容类型
+type ApifoxRequestBody struct {
+	Type       string                 `json:"type"`
+	Parameters []ApifoxParameter      `json:"parameters"`
+	JSONSchema map[string]interface{} `json:"jsonSchema,omitempty"`
+	Example    string                 `json:"example,omitempty"`
+}
+
+// ApifoxResponse 某个状态码的响应定义
+type ApifoxResponse struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Code        int                    `json:"code"`
+	ContentType string                 `json:"contentType"`
+	JSONSchema  map[string]interface{} `json:"jsonSchema"`
+}
+
+// ApifoxResponseExample 响应示例，Data 为示例JSON文本
+type ApifoxResponseExample struct {
+	Name       string `json:"name"`
+	ResponseID string `json:"responseId"`
+	Data       string `json:"data"`
+}
+
+// ApifoxExporter Apifox格式导出器：按包路径生成目录树，请求参数、请求体和响应使用JSON Schema描述
+type ApifoxExporter struct {
+	projectName string
+	baseURL     string
+	outputDir   string
+	options     Options
+	schemas     *YAPIExporter // 复用YAPI导出器的 JSON Schema 和示例值转换
+	nextID      int
+}
+
+// NewApifoxExporter 创建Apifox导出器，baseURL 为空时不生成环境
+func NewApifoxExporter(projectName, baseURL, outputDir string) *ApifoxExporter {
+	return &ApifoxExporter{
+		projectName: projectName,
+		baseURL:     baseURL,
+		outputDir:   outputDir,
+		schemas:     &YAPIExporter{},
+	}
+}
+
+// SetOptions 设置导出配置
+func (e *ApifoxExporter) SetOptions(options Options) {
+	e.options = options
+	e.schemas.SetOptions(options)
+}
+
+// Export 导出API信息为Apifox格式
+func (e *ApifoxExporter) Export(apiInfo *models.APIInfo) error {
+	project := e.Generate(apiInfo)
+
+	if err := e.ensureOutputDir(); err != nil {
+		return fmt.Errorf("创建输出目录失败: %v", err)
+	}
+
+	jsonData, err := MarshalOutput(project, e.options.Compact)
+	if err != nil {
+		return fmt.Errorf("JSON序列化失败: %v", err)
+	}
+
+	filename := fmt.Sprintf("%s_apifox_%d.json",
+		e.schemas.sanitizeFilename(e.projectName),
+		time.Now().Unix())
+	path := filepath.Join(e.outputDir, filename)
+	if err := os.WriteFile(path, jsonData, 0644); err != nil {
+		return fmt.Errorf("保存文件失败: %v", err)
+	}
+
+	console.Printf("✅ Apifox格式导出成功: %s\n", path)
+	console.Printf("📊 导出统计: %d个接口, %d个目录\n", len(apiInfo.Routes), countApifoxFolders(project.APICollection))
+	return nil
+}
+
+// Generate 生成Apifox项目结构（不写入文件）
+func (e *ApifoxExporter) Generate(apiInfo *models.APIInfo) *ApifoxProject {
+	e.nextID = 0
+
+	project := &ApifoxProject{
+		ApifoxProject: apifoxProjectVersion,
+		Schema: ApifoxSchemaInfo{
+			App:     "apifox",
+			Type:    "project",
+			Version: apifoxSchemaVersion,
+		},
+		Info: ApifoxInfo{
+			Name:        e.projectName,
+			Description: fmt.Sprintf("通过api-tool自动生成的API文档 (生成时间: %s)", time.Now().Format("2006-01-02 15:04:05")),
+			MockRule:    ApifoxMockRule{Rules: []interface{}{}, EnableSystemRule: true},
+		},
+		APICollection:    []*ApifoxFolder{e.createFolderTree(apiInfo.Routes)},
+		SchemaCollection: []interface{}{},
+		Environments:     []ApifoxEnvMapping{},
+	}
+	if e.baseURL != "" {
+		project.Environments = append(project.Environments, ApifoxEnvMapping{
+			Name:     "local",
+			BaseURLs: map[string]string{"default": e.baseURL},
+		})
+	}
+	return project
+}
+
+// createFolderTree 按包路径生成目录树：去掉所有包路径的公共前缀（配置了 TrimPackagePrefix 时去掉该前缀）后，
+// 每级路径对应一层目录，如 example.com/app/user 和 example.com/app/admin/order 生成 user、admin/order 两个分支；
+// GroupBy 为 path-prefix 时与Swagger标签一致，每个路径前缀分组一个目录
+func (e *ApifoxExporter) createFolderTree(routes []models.RouteInfo) *ApifoxFolder {
+	root := &ApifoxFolder{Name: "根目录", ID: e.newID(), Items: []interface{}{}}
+
+	var pkgPaths []string
+	for _, route := range routes {
+		pkgPaths = append(pkgPaths, route.PackagePath)
+	}
+	prefix := commonPackagePrefix(pkgPaths)
+	if e.options.TrimPackagePrefix != "" {
+		prefix = strings.TrimSuffix(e.options.TrimPackagePrefix, "/")
+	}
+
+	folders := make(map[string]*ApifoxFolder)
+	for i, route := range routes {
+		folder := root
+		folderPath := ""
+		segments := apifoxFolderSegments(route.PackagePath, prefix)
+		description := "包路径: %s"
+		if e.options.GroupBy == GroupByPathPrefix {
+			segments = []string{extractTagFromPath(route.Path)}
+			description = "路径分组: %s"
+		} else if prefix != "" && strings.HasPrefix(route.PackagePath, prefix+"/") {
+			folderPath = prefix
+		}
+		for _, segment := range segments {
+			folderPath = strings.TrimPrefix(folderPath+"/"+segment, "/")
+			child, ok := folders[folderPath]
+			if !ok {
+				child = &ApifoxFolder{
+					Name:        segment,
+					ID:          e.newID(),
+					ParentID:    folder.ID,
+					Description: fmt.Sprintf(description, folderPath),
+					Items:       []interface{}{},
+				}
+				folders[folderPath] = child
+				folder.Items = append(folder.Items, child)
+			}
+			folder = child
+		}
+		folder.Items = append(folder.Items, &ApifoxAPIItem{
+			Name: e.schemas.generateInterfaceTitle(route),
+			API:  e.convertAPI(route, i),
+		})
+	}
+	return root
+}
+
+// commonPackagePrefix 所有包路径按 / 分段的公共前缀；公共前缀本身是某个包时去掉最后一段，使该包也有自己的目录。
+// 只有一段的包路径（如 main、anonymous）不参与计算，直接作为顶层目录
+func commonPackagePrefix(pkgPaths []string) string {
+	var prefix []string
+	isPackage := make(map[string]bool)
+	for _, pkgPath := range pkgPaths {
+		if !strings.Contains(pkgPath, "/") {
+			continue
+		}
+		isPackage[pkgPath] = true
+		parts := strings.Split(pkgPath, "/")
+		if prefix == nil {
+			prefix = parts
+			continue
+		}
+		n := 0
+		for n < len(prefix) && n < len(parts) && prefix[n] == parts[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	if len(prefix) > 0 && isPackage[strings.Join(prefix, "/")] {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return strings.Join(prefix, "/")
+}
+
+// apifoxFolderSegments 包路径去掉公共前缀后的各级目录名，不在公共前缀下的包使用完整路径，没有包路径时归入 default 目录
+func apifoxFolderSegments(pkgPath, prefix string) []string {
+	if pkgPath == "" {
+		return []string{"default"}
+	}
+	if prefix == "" || !strings.HasPrefix(pkgPath, prefix+"/") {
+		return strings.Split(pkgPath, "/")
+	}
+	return strings.Split(strings.TrimPrefix(pkgPath, prefix+"/"), "/")
+}
+
+// countApifoxFolders 统计目录数量（不含根目录）
+func countApifoxFolders(folders []*ApifoxFolder) int {
+	count := 0
+	var walk func(folder *ApifoxFolder)
+	walk = func(folder *ApifoxFolder) {
+		for _, item := range folder.Items {
+			if child, ok := item.(*ApifoxFolder); ok {
+				count++
+				walk(child)
+			}
+		}
+	}
+	for _, folder := range folders {
+		walk(folder)
+	}
+	return count
+}
+
+// newID 生成导入文件中唯一的ID
+func (e *ApifoxExporter) newID() int {
+	e.nextID++
+	return e.nextID
+}
+
+// convertAPI 转换单个路由为Apifox接口
+func (e *ApifoxExporter) convertAPI(route models.RouteInfo, ordering int) ApifoxAPI {
+	api := ApifoxAPI{
+		ID:          fmt.Sprintf("%d", e.newID()),
+		Method:      strings.ToLower(route.Method),
+		Path:        apifoxPath(route.Path),
+		Parameters:  e.convertParameters(route.Path, route.RequestParams),
+		RequestBody: e.convertRequestBody(route.RequestParams),
+		Description: e.schemas.generateDescription(route),
+		Tags:        []string{route.PackageName},
+		Status:      "released",
+		Ordering:    ordering,
+	}
+	if route.Deprecated {
+		api.Status = "deprecated"
+	}
+	api.Responses, api.ResponseExamples = e.convertResponses(route)
+	return api
+}
+
+// apifoxPath 将 gin 的路径参数 :id 和 *path 转换为 Apifox 使用的 {id}、{path}
+func apifoxPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// convertParameters 转换路径、查询和请求头参数，路径中未推断出的路径参数按必需的字符串参数补充
+func (e *ApifoxExporter) convertParameters(path string, requestParams []models.RequestParamInfo) ApifoxParameters {
+	params := ApifoxParameters{
+		Path:   []ApifoxParameter{},
+		Query:  []ApifoxParameter{},
+		Header: []ApifoxParameter{},
+		Cookie: []ApifoxParameter{},
+	}
+	for _, param := range requestParams {
+		converted := ApifoxParameter{
+			ID:          fmt.Sprintf("%d", e.newID()),
+			Name:        param.ParamName,
+			Required:    param.IsRequired,
+			Description: e.schemas.generateParamDescription(param),
+			Type:        apifoxParamType(param.ParamSchema),
+			Example:     apifoxExample(param.ParamSchema),
+			Enable:      true,
+		}
+		switch param.ParamType {
+		case "path":
+			// 路径参数总是必需的
+			converted.Required = true
+			params.Path = append(params.Path, converted)
+		case "query":
+			params.Query = append(params.Query, converted)
+		case "header":
+			params.Header = append(params.Header, converted)
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, param := range params.Path {
+		declared[param.Name] = true
+	}
+	for _, segment := range strings.Split(apifoxPath(path), "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		if name == "" || declared[name] {
+			continue
+		}
+		declared[name] = true
+		params.Path = append(params.Path, ApifoxParameter{
+			ID:       fmt.Sprintf("%d", e.newID()),
+			Name:     name,
+			Required: true,
+			Type:     "string",
+			Example:  "string",
+			Enable:   true,
+		})
+	}
+	return params
+}
+
+// convertRequestBody 转换请求体：JSON请求体使用 JSON Schema 和示例，表单请求体按字段展开为参数
+func (e *ApifoxExporter) convertRequestBody(requestParams []models.RequestParamInfo) ApifoxRequestBody {
+	body := ApifoxRequestBody{Type: "none", Parameters: []ApifoxParameter{}}
+	for _, param := range requestParams {
+		if param.ParamType != "body" || param.ParamSchema == nil {
+			continue
+		}
+		if isFormContentType(param.ContentType) {
+			body.Type = param.ContentType
+			body.Parameters = e.convertFormParameters(param)
+			return body
+		}
+
+		body.Type = "application/json"
+		body.JSONSchema = e.schemas.convertAPISchemaToYAPIJSONSchema(param.ParamSchema)
+		body.Example = apifoxExampleJSON(e.schemas.convertAPISchemaToJSONSchema(param.ParamSchema))
+		return body
+	}
+	return body
+}
+
+// convertFormParameters 表单请求体按结构体字段展开为表单参数，字段名优先使用form标签
+func (e *ApifoxExporter) convertFormParameters(param models.RequestParamInfo) []ApifoxParameter {
+	var keys []string
+	for key := range param.ParamSchema.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := []ApifoxParameter{}
+	for _, key := range keys {
+		prop, ok := e.options.redactProperty(key, param.ParamSchema.Properties[key])
+		if !ok {
+			continue
+		}
+		name := e.options.propertyKey(key, prop)
+		if prop.FormTag != "" {
+			name = prop.FormTag
+		}
+		params = append(params, ApifoxParameter{
+			ID:          fmt.Sprintf("%d", e.newID()),
+			Name:        name,
+			Required:    param.IsRequired || (prop != nil && prop.Required),
+			Description: prop.Description,
+			Type:        apifoxParamType(prop),
+			Example:     apifoxExample(prop),
+			Enable:      true,
+		})
+	}
+	return params
+}
+
+// convertResponses 转换响应定义和示例：有按状态码分组的响应时每个状态码一个响应，否则只生成成功响应
+func (e *ApifoxExporter) convertResponses(route models.RouteInfo) ([]ApifoxResponse, []ApifoxResponseExample) {
+	responses := []ApifoxResponse{}
+	examples := []ApifoxResponseExample{}
+
+	// WebSocket 接口没有JSON响应体，只描述协议切换
+	if route.Kind == models.RouteKindWebSocket {
+		responses = append(responses, ApifoxResponse{
+			ID:          fmt.Sprintf("%d", e.newID()),
+			Name:        e.options.statusDescription(http.StatusSwitchingProtocols),
+			Code:        http.StatusSwitchingProtocols,
+			ContentType: "raw",
+			JSONSchema:  map[string]interface{}{},
+		})
+		return responses, examples
+	}
+
+	schemas := map[int]*models.APISchema{successStatus(route): route.ResponseSchema}
+	if len(route.Responses) > 0 {
+		schemas = make(map[int]*models.APISchema, len(route.Responses))
+		for status, schema := range route.Responses {
+			// default 对应未能确定状态码的响应，按成功状态码处理
+			code, err := strconv.Atoi(status)
+			if err != nil {
+				code = successStatus(route)
+			}
+			schemas[code] = schema
+		}
+	}
+
+	codes := make([]int, 0, len(schemas))
+	for code := range schemas {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		response := ApifoxResponse{
+			ID:          fmt.Sprintf("%d", e.newID()),
+			Name:        e.options.statusDescription(code),
+			Code:        code,
+			ContentType: "json",
+			JSONSchema:  map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+		}
+		if code == successStatus(route) {
+			response.Name = e.options.successDescription(route)
+		}
+
+		schema := schemas[code]
+		if schema != nil && schema.Format == "binary" {
+			// 二进制/文件响应 (c.Data、c.File)，不生成JSON结构
+			response.ContentType = "raw"
+			response.JSONSchema = map[string]interface{}{"type": "string", "format": "binary"}
+			responses = append(responses, response)
+			continue
+		}
+		if schema != nil {
+			// 按配置的封装字段解包（保留封装字段时使用完整结构）
+			if !e.options.KeepEnvelope {
+				if _, dataField := findEnvelopeField(schema, e.options.EnvelopeField); dataField != nil {
+					schema = dataField
+				}
+			}
+			response.JSONSchema = e.schemas.convertAPISchemaToYAPIJSONSchema(schema)
+			examples = append(examples, ApifoxResponseExample{
+				Name:       response.Name,
+				ResponseID: response.ID,
+				Data:       apifoxExampleJSON(e.schemas.convertAPISchemaToJSONSchema(schema)),
+			})
+		}
+		responses = append(responses, response)
+	}
+	return responses, examples
+}
+
+// apifoxParamType 参数的Apifox类型，上传文件字段为 file，无法识别的类型按 string 处理
+func apifoxParamType(schema *models.APISchema) string {
+	if schema == nil {
+		return "string"
+	}
+	if schema.Type == "FileHeader" || (schema.Items != nil && schema.Items.Type == "FileHeader") || schema.Format == "binary" {
+		return "file"
+	}
+	switch schema.Type {
+	case "string", "integer", "number", "boolean", "array", "object":
+		return schema.Type
+	}
+	return "string"
+}
+
+// apifoxExample 参数的示例值，与YAPI响应示例使用相同的占位值
+func apifoxExample(schema *models.APISchema) interface{} {
+	if schema == nil {
+		return nil
+	}
+	switch schema.Type {
+	case "string":
+		return "string"
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return false
+	}
+	return nil
+}
+
+// apifoxExampleJSON 将示例值序列化为带缩进的JSON文本
+func apifoxExampleJSON(example interface{}) string {
+	jsonData, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+// ensureOutputDir 确保输出目录存在
+func (e *ApifoxExporter) ensureOutputDir() error {
+	if e.outputDir == "" {
+		e.outputDir = ResolveOutputDir("", "", OutputFormatApifox)
+	}
+	return os.MkdirAll(e.outputDir, 0755)
+}
@@ -0,0 +1,130 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// apifoxRoutes 返回分属 user、admin/order 两个包的路由
+func apifoxRoutes() *models.APIInfo {
+	user := &models.APISchema{
+		Type: "User",
+		Properties: map[string]*models.APISchema{
+			"Name": {Type: "string", JSONTag: "name"},
+		},
+	}
+	return &models.APIInfo{Routes: []models.RouteInfo{
+		{
+			Method:      "GET",
+			Path:        "/users/:id",
+			Handler:     "GetUser",
+			PackageName: "user",
+			PackagePath: "example.com/app/user",
+			RequestParams: []models.RequestParamInfo{
+				{ParamName: "fields", ParamType: "query", ParamSchema: &models.APISchema{Type: "string"}},
+			},
+			ResponseSchema: user,
+		},
+		{
+			Method:      "POST",
+			Path:        "/admin/orders",
+			Handler:     "CreateOrder",
+			PackageName: "order",
+			PackagePath: "example.com/app/admin/order",
+			RequestParams: []models.RequestParamInfo{{
+				ParamName:   "request_body",
+				ParamType:   "body",
+				ContentType: "application/json",
+				ParamSchema: &models.APISchema{
+					Type: "CreateOrderReq",
+					Properties: map[string]*models.APISchema{
+						"Amount": {Type: "int", JSONTag: "amount"},
+					},
+				},
+			}},
+		},
+	}}
+}
+
+// apifoxAPIs 按目录路径收集项目中的接口
+func apifoxAPIs(folder *ApifoxFolder, prefix string, apis map[string]ApifoxAPI) {
+	for _, item := range folder.Items {
+		switch item := item.(type) {
+		case *ApifoxFolder:
+			apifoxAPIs(item, strings.TrimPrefix(prefix+"/"+item.Name, "/"), apis)
+		case *ApifoxAPIItem:
+			apis[prefix] = item.API
+		}
+	}
+}
+
+func TestApifoxProject(t *testing.T) {
+	project := NewApifoxExporter("fixture", "http://localhost:8080", "").Generate(apifoxRoutes())
+	if project.Schema.App != "apifox" || len(project.APICollection) != 1 {
+		t.Fatalf("应生成 Apifox 项目导入格式，实际为 %+v", project)
+	}
+	if len(project.Environments) != 1 || project.Environments[0].BaseURLs["default"] != "http://localhost:8080" {
+		t.Errorf("应生成包含前置URL的环境，实际为 %+v", project.Environments)
+	}
+
+	apis := make(map[string]ApifoxAPI)
+	apifoxAPIs(project.APICollection[0], "", apis)
+	getUser, ok := apis["user"]
+	if !ok {
+		t.Fatalf("user 包的接口应位于 user 目录，实际为 %v", apis)
+	}
+	createOrder, ok := apis["admin/order"]
+	if !ok {
+		t.Fatalf("admin/order 包的接口应位于 admin/order 目录，实际为 %v", apis)
+	}
+
+	if getUser.Path != "/users/{id}" || getUser.Method != "get" {
+		t.Errorf("路径参数应转换为 {id}，实际为 %s %s", getUser.Method, getUser.Path)
+	}
+	if len(getUser.Parameters.Path) != 1 || !getUser.Parameters.Path[0].Required {
+		t.Errorf("应补充必需的路径参数 id，实际为 %+v", getUser.Parameters.Path)
+	}
+	if len(getUser.Parameters.Query) != 1 || getUser.Parameters.Query[0].Name != "fields" {
+		t.Errorf("应包含查询参数 fields，实际为 %+v", getUser.Parameters.Query)
+	}
+	if len(getUser.Responses) != 1 || getUser.Responses[0].Code != 200 {
+		t.Fatalf("应生成 200 响应，实际为 %+v", getUser.Responses)
+	}
+	if len(getUser.ResponseExamples) != 1 || getUser.ResponseExamples[0].ResponseID != getUser.Responses[0].ID ||
+		!strings.Contains(getUser.ResponseExamples[0].Data, `"name"`) {
+		t.Errorf("响应示例应关联响应并包含字段 name，实际为 %+v", getUser.ResponseExamples)
+	}
+
+	if createOrder.RequestBody.Type != "application/json" || !strings.Contains(createOrder.RequestBody.Example, `"amount"`) {
+		t.Errorf("JSON 请求体应包含 JSON Schema 与示例，实际为 %+v", createOrder.RequestBody)
+	}
+}
+
+func TestApifoxExport(t *testing.T) {
+	dir := t.TempDir()
+	if err := NewApifoxExporter("fixture", "", dir).Export(apifoxRoutes()); err != nil {
+		t.Fatalf("导出失败: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("应输出一个 Apifox 文件，实际为 %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var project map[string]interface{}
+	if err := json.Unmarshal(data, &project); err != nil {
+		t.Fatalf("输出应为合法 JSON: %v", err)
+	}
+	for _, key := range []string{"apifoxProject", "$schema", "apiCollection"} {
+		if _, ok := project[key]; !ok {
+			t.Errorf("输出缺少 %s", key)
+		}
+	}
+}
@@ -0,0 +1,470 @@
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// 变更类型
+const (
+	ChangeAdded           = "added"            // 新增
+	ChangeRemoved         = "removed"          // 删除
+	ChangeTypeChanged     = "type_changed"     // 类型变更
+	ChangeMethodChanged   = "method_changed"   // HTTP方法变更
+	ChangeRequiredChanged = "required_changed" // 是否必填变更
+	ChangeNullableChanged = "nullable_changed" // 是否可为 null 变更
+)
+
+// SchemaChange 单个字段/参数的变更
+type SchemaChange struct {
+	Location string `json:"location"`           // 变更位置: request.query / request.body / response
+	Field    string `json:"field,omitempty"`    // 字段路径，如 data.user.name
+	Kind     string `json:"kind"`               // 变更类型
+	OldType  string `json:"old_type,omitempty"` // 旧类型
+	NewType  string `json:"new_type,omitempty"` // 新类型
+	Breaking bool   `json:"breaking,omitempty"` // 是否为破坏性变更
+}
+
+// RouteDiff 单个路由的变更
+type RouteDiff struct {
+	Method    string         `json:"method"`
+	Path      string         `json:"path"`
+	Handler   string         `json:"handler,omitempty"`
+	OldMethod string         `json:"old_method,omitempty"` // 仅在HTTP方法变更时设置
+	Changes   []SchemaChange `json:"changes,omitempty"`
+}
+
+// APIDiff 两次分析结果之间的差异
+type APIDiff struct {
+	AddedRoutes   []RouteDiff `json:"added_routes"`
+	RemovedRoutes []RouteDiff `json:"removed_routes"`
+	ChangedRoutes []RouteDiff `json:"changed_routes"`
+}
+
+// IsEmpty 是否没有任何变更
+func (d *APIDiff) IsEmpty() bool {
+	return len(d.AddedRoutes) == 0 && len(d.RemovedRoutes) == 0 && len(d.ChangedRoutes) == 0
+}
+
+// HasBreakingChanges 是否包含破坏性变更：删除路由、变更方法、删除字段、字段类型变更、
+// 是否必填或可为 null 变更，以及请求中新增的必填参数或字段
+func (d *APIDiff) HasBreakingChanges() bool {
+	if len(d.RemovedRoutes) > 0 {
+		return true
+	}
+	for _, route := range d.ChangedRoutes {
+		for _, change := range route.Changes {
+			if change.Breaking {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Diff 比较两次分析结果，路由以 Method+Path 作为唯一标识
+func Diff(oldInfo, newInfo *models.APIInfo) *APIDiff {
+	diff := &APIDiff{}
+
+	oldRoutes := indexRoutes(oldInfo)
+	newRoutes := indexRoutes(newInfo)
+
+	var added, removed []models.RouteInfo
+	for _, key := range sortedRouteKeys(newRoutes) {
+		newRoute := newRoutes[key]
+		oldRoute, exists := oldRoutes[key]
+		if !exists {
+			added = append(added, newRoute)
+			continue
+		}
+		if changes := diffRoute(oldRoute, newRoute); len(changes) > 0 {
+			diff.ChangedRoutes = append(diff.ChangedRoutes, RouteDiff{
+				Method:  newRoute.Method,
+				Path:    newRoute.Path,
+				Handler: newRoute.Handler,
+				Changes: changes,
+			})
+		}
+	}
+	for _, key := range sortedRouteKeys(oldRoutes) {
+		if _, exists := newRoutes[key]; !exists {
+			removed = append(removed, oldRoutes[key])
+		}
+	}
+
+	// 同一路径、同一处理函数仅HTTP方法不同时，视为方法变更
+	matched := make(map[int]bool)
+	for _, oldRoute := range removed {
+		pairIdx := -1
+		for i, newRoute := range added {
+			if !matched[i] && newRoute.Path == oldRoute.Path && newRoute.Handler == oldRoute.Handler {
+				pairIdx = i
+				break
+			}
+		}
+		if pairIdx == -1 {
+			diff.RemovedRoutes = append(diff.RemovedRoutes, newRouteDiff(oldRoute))
+			continue
+		}
+
+		matched[pairIdx] = true
+		newRoute := added[pairIdx]
+		changes := []SchemaChange{{Location: "method", Kind: ChangeMethodChanged, OldType: oldRoute.Method, NewType: newRoute.Method, Breaking: true}}
+		changes = append(changes, diffRoute(oldRoute, newRoute)...)
+		diff.ChangedRoutes = append(diff.ChangedRoutes, RouteDiff{
+			Method:    newRoute.Method,
+			Path:      newRoute.Path,
+			Handler:   newRoute.Handler,
+			OldMethod: oldRoute.Method,
+			Changes:   changes,
+		})
+	}
+	for i, newRoute := range added {
+		if !matched[i] {
+			diff.AddedRoutes = append(diff.AddedRoutes, newRouteDiff(newRoute))
+		}
+	}
+
+	return diff
+}
+
+// indexRoutes 以 METHOD path 为键索引路由
+func indexRoutes(apiInfo *models.APIInfo) map[string]models.RouteInfo {
+	routes := make(map[string]models.RouteInfo)
+	if apiInfo == nil {
+		return routes
+	}
+	for _, route := range apiInfo.Routes {
+		routes[routeKey(route)] = route
+	}
+	return routes
+}
+
+// routeKey 路由的稳定比较键
+func routeKey(route models.RouteInfo) string {
+	return strings.ToUpper(route.Method) + " " + route.Path
+}
+
+// sortedRouteKeys 返回排序后的路由键，保证输出稳定
+func sortedRouteKeys(routes map[string]models.RouteInfo) []string {
+	keys := make([]string, 0, len(routes))
+	for key := range routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newRouteDiff(route models.RouteInfo) RouteDiff {
+	return RouteDiff{
+		Method:  route.Method,
+		Path:    route.Path,
+		Handler: route.Handler,
+	}
+}
+
+// diffRoute 比较同一路由的请求参数与响应结构
+func diffRoute(oldRoute, newRoute models.RouteInfo) []SchemaChange {
+	var changes []SchemaChange
+
+	oldParams := indexParams(oldRoute.RequestParams)
+	newParams := indexParams(newRoute.RequestParams)
+	for _, key := range sortedParamKeys(oldParams, newParams) {
+		oldParam, inOld := oldParams[key]
+		newParam, inNew := newParams[key]
+		location := "request." + paramLocation(oldParam, newParam)
+
+		switch {
+		case !inOld:
+			// 新增的必填参数使原有请求失效
+			changes = append(changes, SchemaChange{Location: location, Field: newParam.ParamName, Kind: ChangeAdded, NewType: schemaTypeName(newParam.ParamSchema), Breaking: newParam.IsRequired})
+		case !inNew:
+			changes = append(changes, SchemaChange{Location: location, Field: oldParam.ParamName, Kind: ChangeRemoved, OldType: schemaTypeName(oldParam.ParamSchema), Breaking: true})
+		default:
+			if oldParam.IsRequired != newParam.IsRequired {
+				changes = append(changes, SchemaChange{Location: location, Field: oldParam.ParamName, Kind: ChangeRequiredChanged, OldType: requiredLabel(oldParam.IsRequired), NewType: requiredLabel(newParam.IsRequired), Breaking: true})
+			}
+			// 请求体的参数名（request_body）没有业务含义，字段路径从结构体开始
+			prefix := oldParam.ParamName
+			if oldParam.ParamType == "body" {
+				prefix = ""
+			}
+			changes = append(changes, diffSchema(location, prefix, oldParam.ParamSchema, newParam.ParamSchema)...)
+		}
+	}
+
+	changes = append(changes, diffSchema("response", "", oldRoute.ResponseSchema, newRoute.ResponseSchema)...)
+	return changes
+}
+
+// indexParams 以 参数类型:参数名 为键索引请求参数
+func indexParams(params []models.RequestParamInfo) map[string]models.RequestParamInfo {
+	indexed := make(map[string]models.RequestParamInfo)
+	for _, param := range params {
+		indexed[param.ParamType+":"+param.ParamName] = param
+	}
+	return indexed
+}
+
+func sortedParamKeys(oldParams, newParams map[string]models.RequestParamInfo) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, params := range []map[string]models.RequestParamInfo{oldParams, newParams} {
+		for key := range params {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func paramLocation(oldParam, newParam models.RequestParamInfo) string {
+	if oldParam.ParamType != "" {
+		return oldParam.ParamType
+	}
+	return newParam.ParamType
+}
+
+// diffSchema 递归比较两个结构，字段以JSON标签（没有时使用字段名）作为键，
+// 两边都有多个候选结构（OneOf）时按类型名逐个比较候选
+func diffSchema(location, field string, oldSchema, newSchema *models.APISchema) []SchemaChange {
+	var changes []SchemaChange
+
+	switch {
+	case oldSchema == nil && newSchema == nil:
+		return nil
+	case oldSchema == nil:
+		// 请求中新增的必填字段使原有请求失效
+		breaking := strings.HasPrefix(location, "request.") && newSchema.Required
+		return []SchemaChange{{Location: location, Field: field, Kind: ChangeAdded, NewType: schemaTypeName(newSchema), Breaking: breaking}}
+	case newSchema == nil:
+		return []SchemaChange{{Location: location, Field: field, Kind: ChangeRemoved, OldType: schemaTypeName(oldSchema), Breaking: true}}
+	}
+
+	if len(oldSchema.OneOf) > 0 && len(newSchema.OneOf) > 0 {
+		changes = append(changes, diffOneOf(location, field, oldSchema.OneOf, newSchema.OneOf)...)
+	} else if oldType, newType := schemaTypeName(oldSchema), schemaTypeName(newSchema); oldType != newType {
+		changes = append(changes, SchemaChange{Location: location, Field: field, Kind: ChangeTypeChanged, OldType: oldType, NewType: newType, Breaking: true})
+	}
+	if oldSchema.Required != newSchema.Required {
+		changes = append(changes, SchemaChange{Location: location, Field: field, Kind: ChangeRequiredChanged, OldType: requiredLabel(oldSchema.Required), NewType: requiredLabel(newSchema.Required), Breaking: true})
+	}
+	if oldSchema.Nullable != newSchema.Nullable {
+		changes = append(changes, SchemaChange{Location: location, Field: field, Kind: ChangeNullableChanged, OldType: nullableLabel(oldSchema.Nullable), NewType: nullableLabel(newSchema.Nullable), Breaking: true})
+	}
+
+	if oldSchema.Items != nil || newSchema.Items != nil {
+		changes = append(changes, diffSchema(location, field+"[]", oldSchema.Items, newSchema.Items)...)
+	}
+
+	oldProps := schemaPropertiesByKey(oldSchema)
+	newProps := schemaPropertiesByKey(newSchema)
+	for _, key := range unionKeys(oldProps, newProps) {
+		childField := key
+		if field != "" {
+			childField = field + "." + key
+		}
+		changes = append(changes, diffSchema(location, childField, oldProps[key], newProps[key])...)
+	}
+
+	return changes
+}
+
+// diffOneOf 按类型名比较候选结构，字段路径中以 <类型名> 标明候选，如 data<User>.name；
+// 新增或删除的候选按新增、删除字段处理
+func diffOneOf(location, field string, oldCandidates, newCandidates []*models.APISchema) []SchemaChange {
+	oldByName := oneOfByTypeName(oldCandidates)
+	newByName := oneOfByTypeName(newCandidates)
+	var changes []SchemaChange
+	for _, name := range unionKeys(oldByName, newByName) {
+		changes = append(changes, diffSchema(location, field+"<"+name+">", oldByName[name], newByName[name])...)
+	}
+	return changes
+}
+
+// oneOfByTypeName 以类型名为键索引候选结构，同名的候选依次追加序号，如 object、object#2
+func oneOfByTypeName(candidates []*models.APISchema) map[string]*models.APISchema {
+	byName := make(map[string]*models.APISchema)
+	for _, candidate := range candidates {
+		name := schemaTypeName(candidate)
+		key := name
+		for i := 2; byName[key] != nil; i++ {
+			key = fmt.Sprintf("%s#%d", name, i)
+		}
+		byName[key] = candidate
+	}
+	return byName
+}
+
+// unionKeys 返回两个映射中所有键，排序后保证输出稳定
+func unionKeys(oldMap, newMap map[string]*models.APISchema) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]*models.APISchema{oldMap, newMap} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func requiredLabel(required bool) string {
+	if required {
+		return "required"
+	}
+	return "optional"
+}
+
+func nullableLabel(nullable bool) string {
+	if nullable {
+		return "nullable"
+	}
+	return "non-null"
+}
+
+// schemaPropertiesByKey 以JSON标签为键返回属性
+func schemaPropertiesByKey(schema *models.APISchema) map[string]*models.APISchema {
+	props := make(map[string]*models.APISchema)
+	for name, prop := range schema.Properties {
+		key := name
+		if prop != nil && prop.JSONTag != "" && prop.JSONTag != "-" {
+			key = prop.JSONTag
+		}
+		props[key] = prop
+	}
+	return props
+}
+
+// schemaTypeName 用于比较的类型名，多个候选结构时合并各候选类型
+func schemaTypeName(schema *models.APISchema) string {
+	if schema == nil {
+		return ""
+	}
+	if len(schema.OneOf) > 0 {
+		var names []string
+		for _, candidate := range schema.OneOf {
+			names = append(names, schemaTypeName(candidate))
+		}
+		return "oneOf(" + strings.Join(names, "|") + ")"
+	}
+	return schema.Type
+}
+
+// DiffExporter 差异导出器，将两次分析结果的差异输出为Markdown或JSON
+type DiffExporter struct {
+	outputDir string
+	format    string // markdown 或 json
+	compact   bool   // json 格式输出紧凑的JSON
+}
+
+// NewDiffExporter 创建差异导出器
+func NewDiffExporter(outputDir, format string) *DiffExporter {
+	if format == "" {
+		format = "markdown"
+	}
+	return &DiffExporter{
+		outputDir: outputDir,
+		format:    format,
+	}
+}
+
+// SetCompact 设置 json 格式是否输出紧凑的JSON
+func (e *DiffExporter) SetCompact(compact bool) {
+	e.compact = compact
+}
+
+// Export 比较两次分析结果并导出差异文件，返回差异结果
+func (e *DiffExporter) Export(oldInfo, newInfo *models.APIInfo) (*APIDiff, error) {
+	diff := Diff(oldInfo, newInfo)
+
+	var content []byte
+	var ext string
+	switch e.format {
+	case "json":
+		data, err := MarshalOutput(diff, e.compact)
+		if err != nil {
+			return nil, fmt.Errorf("JSON序列化失败: %v", err)
+		}
+		content, ext = data, "json"
+	case "markdown", "md":
+		content, ext = []byte(RenderDiffMarkdown(diff)), "md"
+	default:
+		return nil, fmt.Errorf("不支持的差异输出格式: %s", e.format)
+	}
+
+	if err := os.MkdirAll(e.outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("创建输出目录失败: %v", err)
+	}
+
+	filename := fmt.Sprintf("api_diff_%d.%s", time.Now().Unix(), ext)
+	outputPath := filepath.Join(e.outputDir, filename)
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return nil, fmt.Errorf("保存文件失败: %v", err)
+	}
+
+	console.Printf("✅ 差异导出成功: %s\n", outputPath)
+	console.Printf("📊 新增 %d 个接口, 删除 %d 个接口, 变更 %d 个接口\n",
+		len(diff.AddedRoutes), len(diff.RemovedRoutes), len(diff.ChangedRoutes))
+
+	return diff, nil
+}
+
+// RenderDiffMarkdown 将差异渲染为Markdown
+func RenderDiffMarkdown(diff *APIDiff) string {
+	var md strings.Builder
+
+	md.WriteString("# API 变更报告\n\n")
+	if diff.IsEmpty() {
+		md.WriteString("没有检测到接口变更。\n")
+		return md.String()
+	}
+	if diff.HasBreakingChanges() {
+		md.WriteString("> ⚠️ 包含破坏性变更\n\n")
+	}
+
+	if len(diff.AddedRoutes) > 0 {
+		md.WriteString("## 新增接口\n\n")
+		for _, route := range diff.AddedRoutes {
+			md.WriteString(fmt.Sprintf("- `%s %s` (%s)\n", route.Method, route.Path, route.Handler))
+		}
+		md.WriteString("\n")
+	}
+
+	if len(diff.RemovedRoutes) > 0 {
+		md.WriteString("## 删除接口\n\n")
+		for _, route := range diff.RemovedRoutes {
+			md.WriteString(fmt.Sprintf("- `%s %s` (%s)\n", route.Method, route.Path, route.Handler))
+		}
+		md.WriteString("\n")
+	}
+
+	if len(diff.ChangedRoutes) > 0 {
+		md.WriteString("## 变更接口\n\n")
+		for _, route := range diff.ChangedRoutes {
+			md.WriteString(fmt.Sprintf("### `%s %s`\n\n", route.Method, route.Path))
+			md.WriteString("| 位置 | 字段 | 变更 | 旧类型 | 新类型 |\n")
+			md.WriteString("|------|------|------|--------|--------|\n")
+			for _, change := range route.Changes {
+				md.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+					change.Location, change.Field, change.Kind, change.OldType, change.NewType))
+			}
+			md.WriteString("\n")
+		}
+	}
+
+	return md.String()
+}
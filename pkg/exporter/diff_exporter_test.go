@@ -0,0 +1,157 @@
+package exporter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// diffFixtureRoute 返回用于比较的路由：POST /users，可选查询参数 dry_run，请求体 CreateUserReq，
+// 响应 data 为 User 或 Admin 两个候选结构
+func diffFixtureRoute() models.RouteInfo {
+	return models.RouteInfo{
+		Method:  "POST",
+		Path:    "/users",
+		Handler: "CreateUser",
+		RequestParams: []models.RequestParamInfo{
+			{ParamType: "query", ParamName: "dry_run", ParamSchema: &models.APISchema{Type: "boolean"}},
+			{ParamType: "body", ParamName: "request_body", ParamSchema: &models.APISchema{
+				Type: "CreateUserReq",
+				Properties: map[string]*models.APISchema{
+					"Name": {Type: "string", JSONTag: "name", Required: true},
+					"Age":  {Type: "integer", JSONTag: "age"},
+				},
+			}},
+		},
+		ResponseSchema: &models.APISchema{
+			Type: "object",
+			Properties: map[string]*models.APISchema{
+				"Code": {Type: "integer", JSONTag: "code"},
+				"Data": {JSONTag: "data", OneOf: []*models.APISchema{
+					{Type: "User", Properties: map[string]*models.APISchema{"Name": {Type: "string", JSONTag: "name"}}},
+					{Type: "Admin", Properties: map[string]*models.APISchema{
+						"Name":  {Type: "string", JSONTag: "name"},
+						"Level": {Type: "integer", JSONTag: "level"},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+// diffSummary 将差异概括为便于比较的字符串：新增路由 "+ 方法 路径"、删除路由 "- 方法 路径"，
+// 变更为 "位置 字段 变更类型"
+func diffSummary(diff *APIDiff) []string {
+	var summary []string
+	for _, route := range diff.AddedRoutes {
+		summary = append(summary, "+ "+route.Method+" "+route.Path)
+	}
+	for _, route := range diff.RemovedRoutes {
+		summary = append(summary, "- "+route.Method+" "+route.Path)
+	}
+	for _, route := range diff.ChangedRoutes {
+		for _, change := range route.Changes {
+			summary = append(summary, strings.Join(strings.Fields(change.Location+" "+change.Field+" "+change.Kind), " "))
+		}
+	}
+	return summary
+}
+
+func TestDiff(t *testing.T) {
+	body := func(route *models.RouteInfo) *models.APISchema { return route.RequestParams[1].ParamSchema }
+	response := func(route *models.RouteInfo) map[string]*models.APISchema { return route.ResponseSchema.Properties }
+
+	tests := []struct {
+		name     string
+		change   func(route *models.RouteInfo)
+		want     []string
+		breaking bool
+	}{
+		{"无变更", func(route *models.RouteInfo) {}, nil, false},
+		{"响应新增字段", func(route *models.RouteInfo) {
+			response(route)["Msg"] = &models.APISchema{Type: "string", JSONTag: "msg"}
+		}, []string{"response msg added"}, false},
+		{"响应删除字段", func(route *models.RouteInfo) {
+			delete(response(route), "Code")
+		}, []string{"response code removed"}, true},
+		{"响应字段类型变更", func(route *models.RouteInfo) {
+			response(route)["Code"].Type = "string"
+		}, []string{"response code type_changed"}, true},
+		{"请求体新增可选字段", func(route *models.RouteInfo) {
+			body(route).Properties["Note"] = &models.APISchema{Type: "string", JSONTag: "note"}
+		}, []string{"request.body note added"}, false},
+		{"请求体新增必填字段", func(route *models.RouteInfo) {
+			body(route).Properties["Email"] = &models.APISchema{Type: "string", JSONTag: "email", Required: true}
+		}, []string{"request.body email added"}, true},
+		{"新增可选查询参数", func(route *models.RouteInfo) {
+			route.RequestParams = append(route.RequestParams, models.RequestParamInfo{ParamType: "query", ParamName: "lang", ParamSchema: &models.APISchema{Type: "string"}})
+		}, []string{"request.query lang added"}, false},
+		{"新增必填查询参数", func(route *models.RouteInfo) {
+			route.RequestParams = append(route.RequestParams, models.RequestParamInfo{ParamType: "query", ParamName: "tenant", ParamSchema: &models.APISchema{Type: "string"}, IsRequired: true})
+		}, []string{"request.query tenant added"}, true},
+		{"查询参数变为必填", func(route *models.RouteInfo) {
+			route.RequestParams[0].IsRequired = true
+		}, []string{"request.query dry_run required_changed"}, true},
+		{"字段变为必填", func(route *models.RouteInfo) {
+			body(route).Properties["Age"].Required = true
+		}, []string{"request.body age required_changed"}, true},
+		{"字段变为可为 null", func(route *models.RouteInfo) {
+			response(route)["Code"].Nullable = true
+		}, []string{"response code nullable_changed"}, true},
+		{"候选结构删除字段", func(route *models.RouteInfo) {
+			delete(response(route)["Data"].OneOf[1].Properties, "Level")
+		}, []string{"response data<Admin>.level removed"}, true},
+		{"候选结构字段类型变更", func(route *models.RouteInfo) {
+			response(route)["Data"].OneOf[0].Properties["Name"].Type = "integer"
+		}, []string{"response data<User>.name type_changed"}, true},
+		{"新增候选结构", func(route *models.RouteInfo) {
+			data := response(route)["Data"]
+			data.OneOf = append(data.OneOf, &models.APISchema{Type: "Guest"})
+		}, []string{"response data<Guest> added"}, false},
+		{"HTTP方法变更", func(route *models.RouteInfo) {
+			route.Method = "PUT"
+		}, []string{"method method_changed"}, true},
+		{"路由替换为新路径", func(route *models.RouteInfo) {
+			route.Path, route.Handler = "/v2/users", "CreateUserV2"
+		}, []string{"+ POST /v2/users", "- POST /users"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newRoute := diffFixtureRoute()
+			tt.change(&newRoute)
+			diff := Diff(&models.APIInfo{Routes: []models.RouteInfo{diffFixtureRoute()}}, &models.APIInfo{Routes: []models.RouteInfo{newRoute}})
+
+			if got := diffSummary(diff); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("差异应为 %v，实际为 %v", tt.want, got)
+			}
+			if got := diff.HasBreakingChanges(); got != tt.breaking {
+				t.Errorf("HasBreakingChanges 应为 %v，实际为 %v", tt.breaking, got)
+			}
+			if diff.IsEmpty() != (tt.want == nil) {
+				t.Errorf("IsEmpty 应为 %v", tt.want == nil)
+			}
+		})
+	}
+}
+
+func TestDiffMethodChangeKeepsOldMethod(t *testing.T) {
+	newRoute := diffFixtureRoute()
+	newRoute.Method = "PUT"
+	newRoute.ResponseSchema.Properties["Code"].Type = "string"
+	diff := Diff(&models.APIInfo{Routes: []models.RouteInfo{diffFixtureRoute()}}, &models.APIInfo{Routes: []models.RouteInfo{newRoute}})
+
+	if len(diff.ChangedRoutes) != 1 {
+		t.Fatalf("仅方法不同的同一处理函数应视为方法变更，实际为 %+v", diff)
+	}
+	changed := diff.ChangedRoutes[0]
+	if changed.Method != "PUT" || changed.OldMethod != "POST" {
+		t.Errorf("应记录新旧方法 PUT 与 POST，实际为 %s 与 %s", changed.Method, changed.OldMethod)
+	}
+	// 方法变更的同时比较结构
+	if got := diffSummary(diff); !reflect.DeepEqual(got, []string{"method method_changed", "response code type_changed"}) {
+		t.Errorf("方法变更后应继续比较结构，实际为 %v", got)
+	}
+}
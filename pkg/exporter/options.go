@@ -0,0 +1,321 @@
+package exporter
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// 输出属性键的命名风格
+const (
+	FieldCaseNone   = "none"   // 原样使用JSON标签
+	FieldCaseCamel  = "camel"  // userName
+	FieldCaseSnake  = "snake"  // user_name
+	FieldCasePascal = "pascal" // UserName
+)
+
+// 拆分输出的分组方式
+const (
+	SplitByTag     = "tag"     // 按标签（路径第一段）拆分
+	SplitByPackage = "package" // 按处理函数所在的包拆分
+	SplitByServer  = "server"  // 按注册路由的根路由器拆分，每个服务输出一份完整的文档
+)
+
+// 路由的分组方式，统一决定Swagger标签、YAPI分类和Apifox目录
+const (
+	GroupByPackage    = "package"     // 按处理函数所在的包分组
+	GroupByPathPrefix = "path-prefix" // 按路由路径前缀分组，如 /equity/order/... -> Order
+)
+
+// 脱敏字段的处理方式
+const (
+	RedactModeMask = "mask" // 保留字段，说明替换为 [redacted]
+	RedactModeOmit = "omit" // 从输出中删除字段
+)
+
+// redactedDescription 脱敏字段的说明
+const redactedDescription = "[redacted]"
+
+// Options 导出器的通用配置
+type Options struct {
+	EnvelopeField string   // 响应封装中业务数据的字段名（如 data、result），为空时不解包
+	KeepEnvelope  bool     // 解包时是否保留 code/message 等其他封装字段
+	FieldCase     string   // 输出属性键的命名风格 (none/camel/snake/pascal)，为空时原样使用JSON标签
+	ModelPackages []string // 模型包路径，其中的类型名原样作为schema名称（以 /... 结尾时匹配所有子包）
+	StrictSchemas bool     // 为字段确定的对象schema设置 additionalProperties: false，便于严格校验
+	Locale        string   // 响应描述的语言 (en/zh)，为空时使用标准HTTP原因短语
+	SplitBy       string   // Swagger按标签、包或服务拆分为多个文件 (tag/package/server)，为空时输出单个文件
+	Compact       bool     // 输出紧凑的JSON（无缩进、无换行），便于机器处理
+	RedactFields  []string // 需脱敏的字段名模式（不区分大小写，支持 * 通配，如 password、*token*），按JSON键名或字段名匹配
+	RedactMode    string   // 脱敏字段的处理方式 (mask/omit)，为空时按 mask 处理
+	PrettyNames   bool     // 为Swagger的schema生成 title、为标签生成 x-displayName，按单词拆分名称，如 UserInfo -> User Info
+	DedupSchemas  bool     // Swagger中结构相同的匿名schema（不比较 title）复用最先生成的组件，减少重复的组件定义
+	Tags          []string // Swagger只导出标签（按路径计算，如 Order）在列表中的路由，不区分大小写，为空时导出全部
+	// GroupBy 路由的分组方式 (package/path-prefix)，各导出格式使用同一种分组；
+	// 为空时使用各格式的默认分组（Swagger标签按路径前缀，YAPI分类和Apifox目录按包）
+	GroupBy string
+	// SharedParameters 同一位置、同名且结构相同的参数在至少这么多个操作中出现时，提取到 components.parameters 并以 $ref 引用，0 表示不提取
+	SharedParameters int
+	// TrimPackagePrefix 推导分类/分组名称前从包路径中去掉的前缀（通常为模块路径），
+	// 如去掉 github.com/org/service/internal 后 github.com/org/service/internal/api/v1/order 按 api/v1/order 分组
+	TrimPackagePrefix string
+}
+
+// DefaultOptions 默认配置：按 data 字段解包并保留封装字段
+func DefaultOptions() Options {
+	return Options{
+		EnvelopeField: "data",
+		KeepEnvelope:  true,
+		FieldCase:     FieldCaseNone,
+		Locale:        LocaleEnglish,
+	}
+}
+
+// MarshalOutput 序列化输出的JSON文档：compact 为 true 时输出紧凑的JSON，否则按两个空格缩进
+func MarshalOutput(v interface{}, compact bool) ([]byte, error) {
+	if compact {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// packageGroup 按包分组时的分组名称：配置了包路径前缀时使用去掉前缀后的完整路径（如 api/v1/order），否则使用最后一段
+func (o Options) packageGroup(packagePath string) string {
+	if o.TrimPackagePrefix != "" && packagePath != "" {
+		return o.trimPackagePath(packagePath)
+	}
+	parts := strings.Split(packagePath, "/")
+	return parts[len(parts)-1]
+}
+
+// trimPackagePath 去掉包路径中配置的前缀，用于推导分类/分组名称；包路径恰好等于前缀时使用最后一段，
+// 未配置前缀或包路径不在前缀下时返回原路径
+func (o Options) trimPackagePath(packagePath string) string {
+	prefix := strings.TrimSuffix(o.TrimPackagePrefix, "/")
+	switch {
+	case prefix == "" || packagePath == "":
+		return packagePath
+	case packagePath == prefix:
+		return path.Base(packagePath)
+	case strings.HasPrefix(packagePath, prefix+"/"):
+		return strings.TrimPrefix(packagePath, prefix+"/")
+	}
+	return packagePath
+}
+
+// IsValidFieldCase 检查命名风格是否受支持
+func IsValidFieldCase(fieldCase string) bool {
+	switch fieldCase {
+	case "", FieldCaseNone, FieldCaseCamel, FieldCaseSnake, FieldCasePascal:
+		return true
+	}
+	return false
+}
+
+// IsValidSplitBy 检查拆分方式是否受支持
+func IsValidSplitBy(splitBy string) bool {
+	switch splitBy {
+	case "", SplitByTag, SplitByPackage, SplitByServer:
+		return true
+	}
+	return false
+}
+
+// IsValidGroupBy 检查分组方式是否受支持
+func IsValidGroupBy(groupBy string) bool {
+	switch groupBy {
+	case "", GroupByPackage, GroupByPathPrefix:
+		return true
+	}
+	return false
+}
+
+// IsValidRedactMode 检查脱敏方式是否受支持
+func IsValidRedactMode(mode string) bool {
+	switch mode {
+	case "", RedactModeMask, RedactModeOmit:
+		return true
+	}
+	return false
+}
+
+// findEnvelopeField 在响应结构中查找封装字段，按JSON标签或字段名匹配，返回属性键与字段结构
+func findEnvelopeField(schema *models.APISchema, field string) (string, *models.APISchema) {
+	if schema == nil || field == "" {
+		return "", nil
+	}
+	for key, prop := range schema.Properties {
+		if prop == nil {
+			continue
+		}
+		if prop.JSONTag == field || (prop.JSONTag == "" && key == field) {
+			return key, prop
+		}
+	}
+	return "", nil
+}
+
+// propertyJSONKey 属性在JSON中的键名：优先使用JSON标签
+func propertyJSONKey(key string, prop *models.APISchema) string {
+	if prop != nil && prop.JSONTag != "" && prop.JSONTag != "-" {
+		return prop.JSONTag
+	}
+	return key
+}
+
+// isModelPackage 检查包路径是否属于配置的模型包
+func (o Options) isModelPackage(pkgPath string) bool {
+	if pkgPath == "" {
+		return false
+	}
+	for _, modelPkg := range o.ModelPackages {
+		if prefix := strings.TrimSuffix(modelPkg, "/..."); prefix != modelPkg {
+			if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+				return true
+			}
+		} else if pkgPath == modelPkg {
+			return true
+		}
+	}
+	return false
+}
+
+// closeObject 严格模式下禁止对象schema出现未声明的字段。
+// 类型化的map（以 <key>/<value> 描述，包括 type Labels map[string]string 这样的命名类型）不是固定结构，不做限制；
+// 任意结构的对象没有properties，不会调用到这里
+func (o Options) closeObject(schema map[string]interface{}, apiSchema *models.APISchema) {
+	if !o.StrictSchemas || apiSchema == nil || apiSchema.Map {
+		return
+	}
+	schema["additionalProperties"] = false
+}
+
+// redactProperty 按脱敏配置处理属性：不需要脱敏时原样返回；omit 方式返回false表示不输出该属性，
+// mask 方式返回只保留键名标签的字符串结构，说明为 [redacted]，不暴露原类型和嵌套字段
+func (o Options) redactProperty(key string, prop *models.APISchema) (*models.APISchema, bool) {
+	if len(o.RedactFields) == 0 || !o.isRedactedField(key, prop) {
+		return prop, true
+	}
+	if o.RedactMode == RedactModeOmit {
+		return nil, false
+	}
+	masked := &models.APISchema{
+		Type:        "string",
+		Description: redactedDescription,
+	}
+	if prop != nil {
+		masked.JSONTag = prop.JSONTag
+		masked.FormTag = prop.FormTag
+		masked.Required = prop.Required
+	}
+	return masked, true
+}
+
+// isRedactedField 字段名或JSON键名是否匹配脱敏模式（不区分大小写）
+func (o Options) isRedactedField(key string, prop *models.APISchema) bool {
+	names := []string{strings.ToLower(key), strings.ToLower(propertyJSONKey(key, prop))}
+	for _, pattern := range o.RedactFields {
+		pattern = strings.ToLower(pattern)
+		for _, name := range names {
+			if matched, err := path.Match(pattern, name); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// propertyKey 输出的属性键名：JSON标签按配置的命名风格转换，不修改结构中的JSON标签
+func (o Options) propertyKey(key string, prop *models.APISchema) string {
+	return o.fieldName(propertyJSONKey(key, prop))
+}
+
+// fieldName 按配置的命名风格转换固定的键名（如默认响应结构中的 request_id）
+func (o Options) fieldName(name string) string {
+	return convertFieldCase(name, o.FieldCase)
+}
+
+// convertFieldCase 按命名风格转换键名，如 user_name -> userName
+func convertFieldCase(name, fieldCase string) string {
+	if fieldCase == "" || fieldCase == FieldCaseNone {
+		return name
+	}
+
+	words := splitFieldWords(name)
+	if len(words) == 0 {
+		return name
+	}
+
+	switch fieldCase {
+	case FieldCaseSnake:
+		for i, word := range words {
+			words[i] = strings.ToLower(word)
+		}
+		return strings.Join(words, "_")
+	case FieldCaseCamel, FieldCasePascal:
+		var b strings.Builder
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i == 0 && fieldCase == FieldCaseCamel {
+				b.WriteString(word)
+				continue
+			}
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			b.WriteString(string(runes))
+		}
+		return b.String()
+	}
+	return name
+}
+
+// displayName 启用 PrettyNames 时返回便于阅读的名称，否则返回空字符串
+func (o Options) displayName(name string) string {
+	if !o.PrettyNames {
+		return ""
+	}
+	return humanizeName(name)
+}
+
+// humanizeName 按驼峰和下划线拆分名称并首字母大写，如 UserInfo -> User Info、id_name -> Id Name；
+// 带包名前缀的名称（如 dto.UserInfo）只使用类型名部分
+func humanizeName(name string) string {
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	words := splitFieldWords(name)
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// splitFieldWords 按下划线、中划线、空格及大小写边界拆分单词，连续大写视为一个缩写（如 HTTPCode -> HTTP, Code）
+func splitFieldWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		if i == len(runes) || runes[i] == '_' || runes[i] == '-' || runes[i] == ' ' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i > start && unicode.IsUpper(runes[i]) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	return words
+}
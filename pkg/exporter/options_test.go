@@ -0,0 +1,283 @@
+package exporter
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+func TestConvertFieldCase(t *testing.T) {
+	for _, tc := range []struct {
+		name, fieldCase, want string
+	}{
+		{"user_name", FieldCaseNone, "user_name"},
+		{"user_name", FieldCaseCamel, "userName"},
+		{"user_name", FieldCasePascal, "UserName"},
+		{"userName", FieldCaseSnake, "user_name"},
+		{"UserID", FieldCaseSnake, "user_id"},
+	} {
+		if got := convertFieldCase(tc.name, tc.fieldCase); got != tc.want {
+			t.Errorf("convertFieldCase(%q, %s) 应为 %q，实际为 %q", tc.name, tc.fieldCase, tc.want, got)
+		}
+	}
+}
+
+func TestCamelCasePropertyKeys(t *testing.T) {
+	route := models.RouteInfo{
+		Method:  "GET",
+		Path:    "/users/:id",
+		Handler: "GetUser",
+		ResponseSchema: &models.APISchema{
+			Type: "object",
+			Properties: map[string]*models.APISchema{
+				"UserName": {Type: "string", JSONTag: "user_name"},
+			},
+		},
+	}
+	options := DefaultOptions()
+	options.EnvelopeField = ""
+	options.FieldCase = FieldCaseCamel
+	schema, schemas := successSchema(t, options, route)
+
+	ref, _ := schema["$ref"].(string)
+	if ref == "" {
+		t.Fatalf("成功响应应引用 schema，实际为 %#v", schema)
+	}
+	name := strings.TrimPrefix(ref, "#/components/schemas/")
+	properties, _ := schemas[name].(map[string]interface{})["properties"].(map[string]interface{})
+	if _, ok := properties["userName"]; !ok {
+		t.Errorf("user_name 应输出为 userName，实际为 %#v", properties)
+	}
+	// 只转换输出的键名，不修改分析结果中的JSON标签
+	if tag := route.ResponseSchema.Properties["UserName"].JSONTag; tag != "user_name" {
+		t.Errorf("不应修改JSON标签，实际为 %q", tag)
+	}
+}
+
+func TestStatusDescription(t *testing.T) {
+	english := DefaultOptions()
+	chinese := DefaultOptions()
+	chinese.Locale = LocaleChinese
+	for _, tc := range []struct {
+		options Options
+		status  int
+		want    string
+	}{
+		{english, 201, "Created"},
+		{english, 404, "Not Found"},
+		{chinese, 201, "创建成功"},
+		{chinese, 418, "I'm a teapot"}, // 未列出中文描述的状态码使用标准原因短语
+		{english, 599, "599"},
+	} {
+		if got := tc.options.statusDescription(tc.status); got != tc.want {
+			t.Errorf("状态码 %d (%s) 的描述应为 %q，实际为 %q", tc.status, tc.options.Locale, tc.want, got)
+		}
+	}
+}
+
+// credentialsRoute 返回响应中含有密码和令牌字段的路由
+func credentialsRoute() models.RouteInfo {
+	return models.RouteInfo{
+		Method:  "GET",
+		Path:    "/account",
+		Handler: "GetAccount",
+		ResponseSchema: &models.APISchema{
+			Type: "Account",
+			Properties: map[string]*models.APISchema{
+				"Name":        {Type: "string", JSONTag: "name"},
+				"Password":    {Type: "string", JSONTag: "password", Required: true},
+				"AccessToken": {Type: "Token", JSONTag: "access_token", Properties: map[string]*models.APISchema{"Value": {Type: "string", JSONTag: "value"}}},
+			},
+		},
+	}
+}
+
+func TestRedactFields(t *testing.T) {
+	options := DefaultOptions()
+	options.EnvelopeField = ""
+	options.RedactFields = []string{"PASSWORD", "*token*"}
+
+	// 默认保留字段并替换说明，不暴露原类型和嵌套字段
+	_, schemas := successSchema(t, options, credentialsRoute())
+	properties, _ := schemas["Account"].(map[string]interface{})["properties"].(map[string]interface{})
+	for _, key := range []string{"password", "access_token"} {
+		prop, _ := properties[key].(map[string]interface{})
+		if prop["type"] != "string" || prop["description"] != "[redacted]" || prop["properties"] != nil || prop["$ref"] != nil {
+			t.Errorf("脱敏字段 %s 应为说明为 [redacted] 的字符串，实际为 %#v", key, prop)
+		}
+	}
+	if name, _ := properties["name"].(map[string]interface{}); name["description"] == "[redacted]" {
+		t.Errorf("未匹配的字段不应脱敏")
+	}
+
+	// omit 方式删除字段
+	options.RedactMode = RedactModeOmit
+	_, schemas = successSchema(t, options, credentialsRoute())
+	properties, _ = schemas["Account"].(map[string]interface{})["properties"].(map[string]interface{})
+	if _, ok := properties["password"]; ok || len(properties) != 1 {
+		t.Errorf("omit 方式应只保留 name 字段，实际为 %#v", properties)
+	}
+
+	yapi := NewYAPIExporter("fixture", "", "")
+	yapi.SetOptions(options)
+	resBody := yapi.convertInterfaces([]models.RouteInfo{credentialsRoute()}, nil)[0].ResBody
+	if strings.Contains(resBody, "password") || strings.Contains(resBody, "access_token") || !strings.Contains(resBody, "name") {
+		t.Errorf("YAPI 响应中应删除脱敏字段，实际为 %s", resBody)
+	}
+}
+
+func TestHumanizeName(t *testing.T) {
+	for _, tc := range []struct {
+		name, want string
+	}{
+		{"UserInfo", "User Info"},
+		{"IdNameSchema", "Id Name Schema"},
+		{"id_name", "Id Name"},
+		{"dto.UserInfo", "User Info"},
+		{"HTTPCode", "HTTP Code"},
+	} {
+		if got := humanizeName(tc.name); got != tc.want {
+			t.Errorf("humanizeName(%q) 应为 %q，实际为 %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestPrettyNames(t *testing.T) {
+	route := models.RouteInfo{
+		Method:  "GET",
+		Path:    "/internal/users",
+		Handler: "GetUserInfo",
+		ResponseSchema: &models.APISchema{
+			Type: "UserInfo",
+			Properties: map[string]*models.APISchema{
+				"Name": {Type: "string", JSONTag: "name"},
+			},
+		},
+	}
+	generate := func(pretty bool) *SwaggerDoc {
+		options := DefaultOptions()
+		options.EnvelopeField = ""
+		options.PrettyNames = pretty
+		e := NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+		e.SetOptions(options)
+		return e.Generate(&models.APIInfo{Routes: []models.RouteInfo{route}})
+	}
+
+	doc := generate(true)
+	schemas, _ := doc.Components["schemas"].(map[string]interface{})
+	userInfo, _ := schemas["UserInfo"].(map[string]interface{})
+	if title := userInfo["title"]; title != "User Info" {
+		t.Errorf("UserInfo 的 title 应为 User Info，实际为 %v (schemas=%v)", title, schemas)
+	}
+	if len(doc.Tags) != 1 || doc.Tags[0].DisplayName != "Internal Users" {
+		t.Errorf("标签应设置便于阅读的显示名称，实际为 %+v", doc.Tags)
+	}
+
+	doc = generate(false)
+	schemas, _ = doc.Components["schemas"].(map[string]interface{})
+	if _, ok := schemas["UserInfo"].(map[string]interface{})["title"]; ok {
+		t.Errorf("未启用 PrettyNames 时不应设置 title")
+	}
+	if doc.Tags[0].DisplayName != "" {
+		t.Errorf("未启用 PrettyNames 时不应设置标签显示名称，实际为 %q", doc.Tags[0].DisplayName)
+	}
+}
+
+func TestTrimPackagePrefix(t *testing.T) {
+	const prefix = "github.com/org/service/internal"
+	options := Options{TrimPackagePrefix: prefix + "/"}
+	for packagePath, want := range map[string]string{
+		prefix + "/api/v1/order": "api/v1/order",
+		prefix:                   "internal",
+		"github.com/other/user":  "github.com/other/user",
+		"":                       "",
+	} {
+		if got := options.trimPackagePath(packagePath); got != want {
+			t.Errorf("trimPackagePath(%q) 应为 %q，实际为 %q", packagePath, want, got)
+		}
+	}
+
+	routes := []models.RouteInfo{
+		{Method: "GET", Path: "/orders", Handler: "ListOrders", PackagePath: prefix + "/api/v1/order"},
+		{Method: "GET", Path: "/users", Handler: "ListUsers", PackagePath: prefix + "/api/v1/user"},
+	}
+
+	yapi := NewYAPIExporter("fixture", "", "")
+	yapi.SetOptions(options)
+	for i, category := range yapi.createCategories(routes) {
+		if want := options.trimPackagePath(routes[i].PackagePath); category.Name != want {
+			t.Errorf("YAPI 分类名应去掉前缀为 %s，实际为 %s", want, category.Name)
+		}
+	}
+
+	swaggerOptions := options
+	swaggerOptions.GroupBy = GroupByPackage
+	swagger := NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+	swagger.SetOptions(swaggerOptions)
+	tags := make(map[string]bool)
+	for _, tag := range swagger.Generate(&models.APIInfo{Routes: routes}).Tags {
+		tags[tag.Name] = true
+	}
+	if !tags["api/v1/order"] || !tags["api/v1/user"] {
+		t.Errorf("Swagger 标签应去掉前缀，实际为 %v", tags)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	info := apifoxRoutes()
+	groups := func(groupBy string) (swaggerTags, yapiCategories, apifoxFolders []string) {
+		options := DefaultOptions()
+		options.GroupBy = groupBy
+
+		swagger := NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+		swagger.SetOptions(options)
+		for _, tag := range swagger.Generate(info).Tags {
+			swaggerTags = append(swaggerTags, tag.Name)
+		}
+
+		yapi := NewYAPIExporter("fixture", "", "")
+		yapi.SetOptions(options)
+		for _, category := range yapi.createCategories(info.Routes) {
+			yapiCategories = append(yapiCategories, category.Name)
+		}
+
+		apifox := NewApifoxExporter("fixture", "", "")
+		apifox.SetOptions(options)
+		apis := make(map[string]ApifoxAPI)
+		apifoxAPIs(apifox.Generate(info).APICollection[0], "", apis)
+		for folder := range apis {
+			apifoxFolders = append(apifoxFolders, folder)
+		}
+
+		sort.Strings(swaggerTags)
+		sort.Strings(yapiCategories)
+		sort.Strings(apifoxFolders)
+		return swaggerTags, yapiCategories, apifoxFolders
+	}
+
+	// 按路径前缀分组时三种格式都使用Swagger标签
+	swaggerTags, yapiCategories, apifoxFolders := groups(GroupByPathPrefix)
+	for name, got := range map[string][]string{"Swagger标签": swaggerTags, "YAPI分类": yapiCategories, "Apifox目录": apifoxFolders} {
+		if strings.Join(got, ",") != "Admin,Users" {
+			t.Errorf("按路径前缀分组时%s应为 Admin,Users，实际为 %v", name, got)
+		}
+	}
+
+	// 按包分组时Swagger标签与YAPI分类一致，使用包路径的最后一段
+	swaggerTags, yapiCategories, _ = groups(GroupByPackage)
+	if strings.Join(swaggerTags, ",") != "order,user" || strings.Join(yapiCategories, ",") != "order,user" {
+		t.Errorf("按包分组时Swagger标签和YAPI分类应为 order,user，实际为 %v 与 %v", swaggerTags, yapiCategories)
+	}
+
+	// 默认Swagger按路径前缀、YAPI按包分组
+	swaggerTags, yapiCategories, _ = groups("")
+	if strings.Join(swaggerTags, ",") != "Admin,Users" || strings.Join(yapiCategories, ",") != "order,user" {
+		t.Errorf("默认分组不应改变，实际为 %v 与 %v", swaggerTags, yapiCategories)
+	}
+
+	if IsValidGroupBy("module") {
+		t.Errorf("不支持的分组方式应无效")
+	}
+}
@@ -0,0 +1,44 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/YogeLiu/api-tool/pkg/console"
+)
+
+// 统一输出目录下各格式的子目录名
+const (
+	OutputFormatSwagger = "swagger"
+	OutputFormatYAPI    = "yapi"
+	OutputFormatApifox  = "apifox"
+	OutputFormatDiff    = "diff"
+	OutputFormatJSON    = "json"
+)
+
+// 未指定输出目录时各格式沿用的旧默认目录（已弃用，推荐使用统一输出目录）
+var legacyOutputDirs = map[string]string{
+	OutputFormatSwagger: "./swagger_exports",
+	OutputFormatYAPI:    "./yapi_exports",
+	OutputFormatApifox:  "./apifox_exports",
+	OutputFormatDiff:    "./diff_exports",
+}
+
+// ResolveOutputDir 计算某种格式的输出目录：
+// 显式指定的目录 (-output) 优先；其次为统一输出目录 (-output-dir) 下按格式划分的子目录，如 docs/swagger；
+// 都未指定时沿用旧的默认目录（如 ./swagger_exports）并提示该默认值已弃用
+func ResolveOutputDir(explicit, root, format string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if root != "" {
+		return filepath.Join(root, format)
+	}
+
+	legacy, ok := legacyOutputDirs[format]
+	if !ok {
+		legacy = "./" + format + "_exports"
+	}
+	console.Fprintf(os.Stderr, "⚠️  默认输出目录 %s 已弃用，请使用 -output-dir 指定统一的输出目录（各格式写入其中的 %s 子目录）\n", legacy, format)
+	return legacy
+}
@@ -0,0 +1,108 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+func TestResolveOutputDir(t *testing.T) {
+	tests := []struct {
+		explicit, root, format, want string
+	}{
+		{"./out", "./docs", OutputFormatSwagger, "./out"},
+		{"", "docs", OutputFormatSwagger, filepath.Join("docs", "swagger")},
+		{"", "docs", OutputFormatYAPI, filepath.Join("docs", "yapi")},
+		{"", "", OutputFormatYAPI, "./yapi_exports"},
+		{"", "", OutputFormatJSON, "./json_exports"},
+	}
+	for _, tt := range tests {
+		if got := ResolveOutputDir(tt.explicit, tt.root, tt.format); got != tt.want {
+			t.Errorf("ResolveOutputDir(%q, %q, %q) = %q，应为 %q", tt.explicit, tt.root, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestOutputDirSubfolders(t *testing.T) {
+	root := t.TempDir()
+	info := &models.APIInfo{Routes: []models.RouteInfo{envelopeRoute()}}
+
+	swagger := NewSwaggerExporter("fixture", "1.0.0", "", ResolveOutputDir("", root, OutputFormatSwagger), true)
+	if err := swagger.Export(info); err != nil {
+		t.Fatalf("Swagger 导出失败: %v", err)
+	}
+	yapi := NewYAPIExporter("fixture", "", ResolveOutputDir("", root, OutputFormatYAPI))
+	if err := yapi.Export(info); err != nil {
+		t.Fatalf("YAPI 导出失败: %v", err)
+	}
+	diff := NewDiffExporter(ResolveOutputDir("", root, OutputFormatDiff), "json")
+	if _, err := diff.Export(&models.APIInfo{}, info); err != nil {
+		t.Fatalf("差异导出失败: %v", err)
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatalf("读取输出目录失败: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("输出目录下应只有 swagger、yapi、diff 三个子目录，实际为 %v", entries)
+	}
+	for _, format := range []string{OutputFormatSwagger, OutputFormatYAPI, OutputFormatDiff} {
+		matches, _ := filepath.Glob(filepath.Join(root, format, "*.json"))
+		if len(matches) != 1 {
+			t.Errorf("%s 子目录中应有一个导出文件，实际为 %v", format, matches)
+		}
+	}
+}
+
+func TestCompactOutput(t *testing.T) {
+	info := &models.APIInfo{Routes: []models.RouteInfo{envelopeRoute()}}
+	for _, compact := range []bool{true, false} {
+		root := t.TempDir()
+		options := Options{Compact: compact}
+
+		swagger := NewSwaggerExporter("fixture", "1.0.0", "", filepath.Join(root, OutputFormatSwagger), true)
+		swagger.SetOptions(options)
+		if err := swagger.Export(info); err != nil {
+			t.Fatalf("Swagger 导出失败: %v", err)
+		}
+		split := NewSwaggerExporter("fixture", "1.0.0", "", filepath.Join(root, "split"), true)
+		split.SetOptions(Options{Compact: compact, SplitBy: SplitByTag})
+		if err := split.Export(info); err != nil {
+			t.Fatalf("Swagger 拆分导出失败: %v", err)
+		}
+		yapi := NewYAPIExporter("fixture", "", filepath.Join(root, OutputFormatYAPI))
+		yapi.SetOptions(options)
+		if err := yapi.Export(info); err != nil {
+			t.Fatalf("YAPI 导出失败: %v", err)
+		}
+		diff := NewDiffExporter(filepath.Join(root, OutputFormatDiff), "json")
+		diff.SetCompact(compact)
+		if _, err := diff.Export(&models.APIInfo{}, info); err != nil {
+			t.Fatalf("差异导出失败: %v", err)
+		}
+
+		count := 0
+		filepath.Walk(root, func(path string, fileInfo os.FileInfo, err error) error {
+			if err != nil || fileInfo.IsDir() {
+				return err
+			}
+			count++
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("读取 %s 失败: %v", path, err)
+			}
+			if hasNewline := strings.Contains(strings.TrimSpace(string(data)), "\n"); hasNewline == compact {
+				t.Errorf("compact=%v 时 %s 的换行不符合预期", compact, path)
+			}
+			return nil
+		})
+		// swagger、yapi、diff 各一个文件，拆分导出为 index、schemas 与一个分组文件
+		if count != 6 {
+			t.Errorf("应导出 6 个文件，实际为 %d", count)
+		}
+	}
+}
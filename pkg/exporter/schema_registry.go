@@ -0,0 +1,129 @@
+package exporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// schemaRegistry 导出过程中收集的schema定义（名称 -> 定义），可在多个goroutine中同时转换路由时使用。
+// 名称的冲突检查与登记在同一把锁内完成，不会出现两个不同的定义占用同一名称；
+// 名称按登记顺序分配（先登记的使用原名，之后冲突的追加序号），Generate 按路由顺序单线程转换，因此输出稳定
+type schemaRegistry struct {
+	mu      sync.Mutex
+	schemas map[string]interface{}
+	shapes  map[string]string // 定义结构的哈希（不含 title） -> 最先登记的名称，用于结构去重
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]interface{}),
+		shapes:  make(map[string]string),
+	}
+}
+
+// has 名称是否已登记
+func (r *schemaRegistry) has(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.schemas[name]
+	return exists
+}
+
+// set 登记定义，已存在时覆盖
+func (r *schemaRegistry) set(name string, schema interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[name] = schema
+	r.recordShape(name, schema)
+}
+
+// register 以 name 为基础登记定义并返回实际使用的名称：名称未被占用或已登记的定义与之相同时复用该名称，
+// 否则依次尝试 name2、name3……；title 不为nil时按最终名称设置定义的 title（比较时也带上对应名称的 title）
+func (r *schemaRegistry) register(name string, schema map[string]interface{}, title func(string) string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.registerLocked(name, schema, title)
+}
+
+// registerShape 与 register 相同，但已登记了结构相同（不比较 title）的定义时直接复用最先登记的名称，
+// 如不同处理函数中字段完全相同的匿名结构体合并为一个组件
+func (r *schemaRegistry) registerShape(name string, schema map[string]interface{}, title func(string) string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.shapes[schemaShape(schema)]; ok {
+		return existing
+	}
+	return r.registerLocked(name, schema, title)
+}
+
+func (r *schemaRegistry) registerLocked(name string, schema map[string]interface{}, title func(string) string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		setTitle(schema, candidate, title)
+		existing, exists := r.schemas[candidate]
+		if !exists {
+			r.schemas[candidate] = schema
+			r.recordShape(candidate, schema)
+			return candidate
+		}
+		if reflect.DeepEqual(existing, schema) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+// recordShape 记录定义结构对应的名称，同一结构只保留最先登记的名称；调用方需持有锁
+func (r *schemaRegistry) recordShape(name string, schema interface{}) {
+	shape := schemaShape(schema)
+	if _, exists := r.shapes[shape]; !exists && shape != "" {
+		r.shapes[shape] = name
+	}
+}
+
+// schemaShape 定义结构的规范哈希：去掉 title 后按JSON序列化（map 的键有序）取 SHA-256，无法序列化时返回空字符串
+func schemaShape(schema interface{}) string {
+	if m, ok := schema.(map[string]interface{}); ok {
+		if _, hasTitle := m["title"]; hasTitle {
+			copied := make(map[string]interface{}, len(m))
+			for key, value := range m {
+				if key != "title" {
+					copied[key] = value
+				}
+			}
+			schema = copied
+		}
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// snapshot 当前所有定义的副本，用于输出 components.schemas
+func (r *schemaRegistry) snapshot() map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	schemas := make(map[string]interface{}, len(r.schemas))
+	for name, schema := range r.schemas {
+		schemas[name] = schema
+	}
+	return schemas
+}
+
+// setTitle 按名称设置定义的 title，title 为nil或生成空字符串时去掉 title
+func setTitle(schema map[string]interface{}, name string, title func(string) string) {
+	if title != nil {
+		if value := title(name); value != "" {
+			schema["title"] = value
+			return
+		}
+	}
+	delete(schema, "title")
+}
@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+func TestSchemaRegistryConcurrentRegister(t *testing.T) {
+	const variants, workers = 20, 8
+	r := newSchemaRegistry()
+
+	// 每个goroutine按不同顺序登记同名的 20 种不同定义，每种定义各登记 8 次
+	names := make([][]string, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			names[w] = make([]string, variants)
+			for i := 0; i < variants; i++ {
+				v := (i + w*3) % variants
+				schema := map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{fmt.Sprintf("field%d", v): map[string]interface{}{"type": "string"}},
+				}
+				names[w][v] = r.register("User", schema, nil)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// 同一定义在所有goroutine中得到相同名称，不同定义的名称互不相同
+	seen := make(map[string]int)
+	for v := 0; v < variants; v++ {
+		name := names[0][v]
+		for w := 1; w < workers; w++ {
+			if names[w][v] != name {
+				t.Fatalf("定义 %d 在不同goroutine中得到不同名称: %s 与 %s", v, name, names[w][v])
+			}
+		}
+		if other, ok := seen[name]; ok {
+			t.Fatalf("定义 %d 与 %d 占用了同一名称 %s", v, other, name)
+		}
+		seen[name] = v
+	}
+
+	schemas := r.snapshot()
+	if len(schemas) != variants {
+		t.Fatalf("应登记 %d 个定义，实际为 %d", variants, len(schemas))
+	}
+	for i := 2; i <= variants; i++ {
+		if _, ok := schemas[fmt.Sprintf("User%d", i)]; !ok {
+			t.Errorf("冲突的名称应依次追加序号，缺少 User%d", i)
+		}
+	}
+}
+
+func TestSwaggerSchemaNamesStable(t *testing.T) {
+	// 大量同名不同结构的路由多次生成，组件名称的分配应保持一致
+	info := splitRoutes()
+	for i := 0; i < 50; i++ {
+		user := *info.Routes[0].ResponseSchema
+		user.Properties = map[string]*models.APISchema{
+			fmt.Sprintf("Field%d", i): {Type: "string", JSONTag: fmt.Sprintf("field%d", i)},
+		}
+		info.Routes = append(info.Routes, models.RouteInfo{
+			Method: "GET", Path: fmt.Sprintf("/items/%d", i), Handler: fmt.Sprintf("Get%d", i), ResponseSchema: &user,
+		})
+	}
+
+	generate := func(e *SwaggerExporter) map[string]interface{} {
+		doc := e.Generate(info)
+		refs := make(map[string]interface{})
+		for path, item := range doc.Paths {
+			refs[path] = item.Get.Responses["200"].Content["application/json"].Schema["$ref"]
+		}
+		refs["components"] = doc.Components["schemas"]
+		return refs
+	}
+
+	want := generate(NewSwaggerExporter("fixture", "1.0.0", "", "", true))
+	// 同一导出器在多个goroutine中同时生成（go test -race 下检查数据竞争）
+	shared := NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+	var wg sync.WaitGroup
+	results := make([]map[string]interface{}, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = generate(shared)
+		}(i)
+	}
+	wg.Wait()
+	for _, got := range results {
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("多次生成的组件名称和定义应一致，实际为 %v 与 %v", got, want)
+		}
+	}
+}
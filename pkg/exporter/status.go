@@ -0,0 +1,76 @@
+package exporter
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// 响应描述的语言
+const (
+	LocaleEnglish = "en" // 标准HTTP原因短语，如 201 Created
+	LocaleChinese = "zh"
+)
+
+// 中文响应描述，未列出的状态码使用标准原因短语
+var chineseStatusDescriptions = map[int]string{
+	http.StatusSwitchingProtocols:  "切换协议",
+	http.StatusOK:                  "成功响应",
+	http.StatusCreated:             "创建成功",
+	http.StatusAccepted:            "已接受",
+	http.StatusNoContent:           "无内容",
+	http.StatusMovedPermanently:    "永久重定向",
+	http.StatusFound:               "临时重定向",
+	http.StatusNotModified:         "未修改",
+	http.StatusBadRequest:          "请求错误",
+	http.StatusUnauthorized:        "未认证",
+	http.StatusForbidden:           "禁止访问",
+	http.StatusNotFound:            "资源不存在",
+	http.StatusMethodNotAllowed:    "方法不允许",
+	http.StatusConflict:            "资源冲突",
+	http.StatusUnprocessableEntity: "无法处理的请求",
+	http.StatusTooManyRequests:     "请求过多",
+	http.StatusInternalServerError: "服务器错误",
+	http.StatusBadGateway:          "网关错误",
+	http.StatusServiceUnavailable:  "服务不可用",
+	http.StatusGatewayTimeout:      "网关超时",
+}
+
+// IsValidLocale 检查响应描述语言是否受支持
+func IsValidLocale(locale string) bool {
+	switch locale {
+	case "", LocaleEnglish, LocaleChinese:
+		return true
+	}
+	return false
+}
+
+// statusDescription 按配置的语言返回状态码的描述，如 201 -> Created
+func (o Options) statusDescription(status int) string {
+	if o.Locale == LocaleChinese {
+		if desc, ok := chineseStatusDescriptions[status]; ok {
+			return desc
+		}
+	}
+	if desc := http.StatusText(status); desc != "" {
+		return desc
+	}
+	return strconv.Itoa(status)
+}
+
+// successStatus 路由成功响应的状态码，无法静态确定时为200
+func successStatus(route models.RouteInfo) int {
+	if route.SuccessStatus != 0 {
+		return route.SuccessStatus
+	}
+	return http.StatusOK
+}
+
+// successDescription 路由成功响应的描述：优先使用处理函数注释中的 "Response:" 说明
+func (o Options) successDescription(route models.RouteInfo) string {
+	if route.ResponseDescription != "" {
+		return route.ResponseDescription
+	}
+	return o.statusDescription(successStatus(route))
+}
@@ -1,13 +1,16 @@
 package exporter
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/YogeLiu/api-tool/pkg/console"
 	"github.com/YogeLiu/api-tool/pkg/models"
 )
 
@@ -33,12 +36,14 @@ type SwaggerServer struct {
 type SwaggerTag struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	DisplayName string `json:"x-displayName,omitempty"` // 便于阅读的标签名称，仅启用 PrettyNames 时设置
 }
 
-// SwaggerParameter 参数信息
+// SwaggerParameter 参数信息，Ref 不为空时为对 components.parameters 中公共参数的引用
 type SwaggerParameter struct {
-	Name        string                 `json:"name"`
-	In          string                 `json:"in"` // query, header, path, cookie
+	Ref         string                 `json:"$ref,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	In          string                 `json:"in,omitempty"` // query, header, path, cookie
 	Description string                 `json:"description,omitempty"`
 	Required    bool                   `json:"required,omitempty"`
 	Schema      map[string]interface{} `json:"schema,omitempty"`
@@ -71,6 +76,10 @@ type SwaggerOperation struct {
 	Parameters  []SwaggerParameter         `json:"parameters,omitempty"`
 	RequestBody *SwaggerRequestBody        `json:"requestBody,omitempty"`
 	Responses   map[string]SwaggerResponse `json:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	WebSocket   bool                       `json:"x-websocket,omitempty"`   // WebSocket 升级接口
+	Middlewares []string                   `json:"x-middlewares,omitempty"` // 路由经过的中间件
+	Since       string                     `json:"x-since,omitempty"`       // 接口的引入日期
 }
 
 // SwaggerPath 路径信息
@@ -99,7 +108,9 @@ type SwaggerExporter struct {
 	baseURL     string
 	outputDir   string
 	successOnly bool
-	schemas     map[string]interface{} // 收集的schema定义
+	options     Options
+	schemas     *schemaRegistry   // 本次生成收集的schema定义，仅在 convertToSwaggerDoc 的副本上设置
+	typeNames   map[string]string // 本次生成中需要区分包的类型: 包路径.类型名 -> schema名称
 }
 
 // NewSwaggerExporter 创建Swagger导出器
@@ -116,13 +127,24 @@ func NewSwaggerExporter(projectName, version, baseURL, outputDir string, success
 		baseURL:     baseURL,
 		outputDir:   outputDir,
 		successOnly: successOnly,
-		schemas:     make(map[string]interface{}),
+		options:     DefaultOptions(),
 	}
 }
 
+// SetOptions 设置导出配置
+func (e *SwaggerExporter) SetOptions(options Options) {
+	e.options = options
+}
+
+// Generate 在内存中生成Swagger文档，不写入文件
+func (e *SwaggerExporter) Generate(apiInfo *models.APIInfo) *SwaggerDoc {
+	return e.convertToSwaggerDoc(e.filterByTag(apiInfo))
+}
+
 // Export 导出API信息为Swagger格式
 func (e *SwaggerExporter) Export(apiInfo *models.APIInfo) error {
-	// 创建Swagger文档结构
+	// 创建Swagger文档结构（配置了标签过滤时只保留对应标签的路由）
+	apiInfo = e.filterByTag(apiInfo)
 	swaggerDoc := e.convertToSwaggerDoc(apiInfo)
 
 	// 确保输出目录存在
@@ -130,8 +152,18 @@ func (e *SwaggerExporter) Export(apiInfo *models.APIInfo) error {
 		return fmt.Errorf("创建输出目录失败: %v", err)
 	}
 
+	// 按服务拆分时每个根路由器输出一份独立文档，不同服务的相同路径互不覆盖
+	if e.options.SplitBy == SplitByServer {
+		return e.exportByServer(apiInfo)
+	}
+
+	// 按标签或包拆分为多个文件
+	if e.options.SplitBy != "" {
+		return e.exportSplit(swaggerDoc, apiInfo.Routes)
+	}
+
 	// 生成JSON文件
-	jsonData, err := json.MarshalIndent(swaggerDoc, "", "  ")
+	jsonData, err := MarshalOutput(swaggerDoc, e.options.Compact)
 	if err != nil {
 		return fmt.Errorf("JSON序列化失败: %v", err)
 	}
@@ -147,19 +179,29 @@ func (e *SwaggerExporter) Export(apiInfo *models.APIInfo) error {
 		return fmt.Errorf("保存文件失败: %v", err)
 	}
 
-	fmt.Printf("✅ Swagger格式导出成功: %s\n", filepath)
-	fmt.Printf("📊 导出统计: %d个接口, %d个标签\n",
+	console.Printf("✅ Swagger格式导出成功: %s\n", filepath)
+	console.Printf("📊 导出统计: %d个接口, %d个标签\n",
 		len(swaggerDoc.Paths), len(swaggerDoc.Tags))
 
 	if e.successOnly {
-		fmt.Println("📝 注意: 仅包含成功响应，已过滤错误响应")
+		console.Println("📝 注意: 仅包含成功响应，已过滤错误响应")
 	}
 
 	return nil
 }
 
-// convertToSwaggerDoc 转换API信息为Swagger文档格式
+// convertToSwaggerDoc 转换API信息为Swagger文档格式。schema定义和带包名的类型名称收集在导出器的副本上，
+// 同一导出器多次或在多个goroutine中同时生成时互不影响
 func (e *SwaggerExporter) convertToSwaggerDoc(apiInfo *models.APIInfo) *SwaggerDoc {
+	g := *e
+	g.schemas = newSchemaRegistry()
+	// 收集不同包中的同名类型，生成带包名的schema名称
+	g.typeNames = g.collectAmbiguousTypeNames(apiInfo.Routes)
+	return g.buildSwaggerDoc(apiInfo)
+}
+
+// buildSwaggerDoc 在已初始化 schemas、typeNames 的导出器上生成文档
+func (e *SwaggerExporter) buildSwaggerDoc(apiInfo *models.APIInfo) *SwaggerDoc {
 	// 创建文档信息
 	info := SwaggerInfo{
 		Title:   e.projectName,
@@ -187,7 +229,7 @@ func (e *SwaggerExporter) convertToSwaggerDoc(apiInfo *models.APIInfo) *SwaggerD
 	paths := e.convertPaths(apiInfo.Routes)
 
 	// 添加默认的错误schema
-	e.schemas["Error"] = map[string]interface{}{
+	e.schemas.set("Error", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"code": map[string]interface{}{
@@ -196,21 +238,27 @@ func (e *SwaggerExporter) convertToSwaggerDoc(apiInfo *models.APIInfo) *SwaggerD
 			"message": map[string]interface{}{
 				"type": "string",
 			},
-			"request_id": map[string]interface{}{
+			e.options.fieldName("request_id"): map[string]interface{}{
 				"type": "string",
 			},
 		},
+	})
+
+	components := map[string]interface{}{
+		"schemas": e.schemas.snapshot(),
+	}
+	// 多个操作中重复出现的参数提取为公共参数
+	if parameters := e.extractSharedParameters(paths); parameters != nil {
+		components["parameters"] = parameters
 	}
 
 	return &SwaggerDoc{
-		OpenAPI: "3.0.3",
-		Info:    info,
-		Servers: servers,
-		Tags:    tags,
-		Paths:   paths,
-		Components: map[string]interface{}{
-			"schemas": e.schemas,
-		},
+		OpenAPI:    "3.0.3",
+		Info:       info,
+		Servers:    servers,
+		Tags:       tags,
+		Paths:      paths,
+		Components: components,
 	}
 }
 
@@ -221,7 +269,7 @@ func (e *SwaggerExporter) createTags(routes []models.RouteInfo) []SwaggerTag {
 
 	// 基于路径进行智能分组
 	for _, route := range routes {
-		tagName := e.extractTagFromPath(route.Path)
+		tagName := e.extractTag(route)
 		if _, exists := tagMap[tagName]; !exists {
 			tagMap[tagName] = []string{}
 		}
@@ -237,14 +285,41 @@ func (e *SwaggerExporter) createTags(routes []models.RouteInfo) []SwaggerTag {
 		tags = append(tags, SwaggerTag{
 			Name:        tagName,
 			Description: description,
+			DisplayName: e.options.displayName(tagName),
 		})
 	}
 
 	return tags
 }
 
+// filterByTag 按配置的标签过滤路由：只保留计算出的标签（不区分大小写）在 Options.Tags 中的路由，未配置时原样返回
+func (e *SwaggerExporter) filterByTag(apiInfo *models.APIInfo) *models.APIInfo {
+	if len(e.options.Tags) == 0 {
+		return apiInfo
+	}
+	var routes []models.RouteInfo
+	for _, route := range apiInfo.Routes {
+		tagName := e.extractTag(route)
+		for _, tag := range e.options.Tags {
+			if strings.EqualFold(tag, tagName) {
+				routes = append(routes, route)
+				break
+			}
+		}
+	}
+	return &models.APIInfo{Routes: routes, Warnings: apiInfo.Warnings}
+}
+
+// extractTag 路由的标签：按包分组（GroupBy 为 package）时使用包路径的分组名称，否则从路径前缀中提取
+func (e *SwaggerExporter) extractTag(route models.RouteInfo) string {
+	if e.options.GroupBy == GroupByPackage {
+		return e.options.packageGroup(route.PackagePath)
+	}
+	return extractTagFromPath(route.Path)
+}
+
 // extractTagFromPath 从路径中提取标签名称
-func (e *SwaggerExporter) extractTagFromPath(path string) string {
+func extractTagFromPath(path string) string {
 	// 去除开头的斜杠
 	path = strings.TrimPrefix(path, "/")
 
@@ -260,7 +335,7 @@ func (e *SwaggerExporter) extractTagFromPath(path string) string {
 		return "Test"
 	case strings.HasPrefix(path, "internal/"):
 		if len(parts) >= 2 {
-			return "Internal-" + e.capitalize(parts[1])
+			return "Internal-" + capitalize(parts[1])
 		}
 		return "Internal"
 	case strings.HasPrefix(path, "equity/member"):
@@ -280,13 +355,13 @@ func (e *SwaggerExporter) extractTagFromPath(path string) string {
 	case strings.HasPrefix(path, "equity/"):
 		// 其他 equity 下的接口，按第二段分组
 		if len(parts) >= 2 {
-			return "Equity-" + e.capitalize(parts[1])
+			return "Equity-" + capitalize(parts[1])
 		}
 		return "Equity"
 	default:
 		// 默认按第一段分组
 		if len(parts) >= 1 {
-			return e.capitalize(parts[0])
+			return capitalize(parts[0])
 		}
 		return "Default"
 	}
@@ -321,7 +396,7 @@ func (e *SwaggerExporter) generateTagDescription(tagName string, paths []string)
 }
 
 // capitalize 首字母大写
-func (e *SwaggerExporter) capitalize(s string) string {
+func capitalize(s string) string {
 	if len(s) == 0 {
 		return s
 	}
@@ -380,11 +455,31 @@ func (e *SwaggerExporter) convertPaths(routes []models.RouteInfo) map[string]Swa
 // convertOperation 转换操作
 func (e *SwaggerExporter) convertOperation(route models.RouteInfo) *SwaggerOperation {
 	operation := &SwaggerOperation{
-		Tags:        []string{e.extractTagFromPath(route.Path)},
+		Tags:        []string{e.extractTag(route)},
 		Summary:     fmt.Sprintf("%s %s", strings.ToUpper(route.Method), route.Path),
 		Description: fmt.Sprintf("Handler: %s\n包路径: %s", route.Handler, route.PackagePath),
 		OperationID: e.generateOperationID(route),
 		Responses:   make(map[string]SwaggerResponse),
+		Deprecated:  route.Deprecated,
+		Middlewares: route.Middlewares,
+		Since:       route.Since,
+	}
+
+	if route.HandlerAdapter != "" {
+		operation.Description += fmt.Sprintf("\n标准库处理函数 (通过 %s 适配)", route.HandlerAdapter)
+	}
+	if route.HandlerUnresolved {
+		operation.Description += "\n⚠️ 无法解析处理函数，请求和响应信息缺失"
+	}
+	if route.MiddlewareRendered {
+		operation.Description += "\n响应由中间件渲染 (处理函数通过 c.Set 存储数据)"
+	}
+	// 文档注释或注解中的摘要和描述优先
+	if route.Summary != "" {
+		operation.Summary = route.Summary
+	}
+	if route.Description != "" {
+		operation.Description = route.Description + "\n\n" + operation.Description
 	}
 
 	// 转换参数
@@ -393,12 +488,25 @@ func (e *SwaggerExporter) convertOperation(route models.RouteInfo) *SwaggerOpera
 	// 转换请求体
 	operation.RequestBody = e.convertRequestBody(route.RequestParams)
 
-	// 转换响应
-	operation.Responses = e.convertResponses(route.ResponseSchema)
+	// WebSocket 接口没有JSON响应体，只描述协议切换
+	if route.Kind == models.RouteKindWebSocket {
+		operation.WebSocket = true
+		operation.Description += "\nWebSocket 接口 (连接升级后通过 WebSocket 收发消息)"
+		operation.Responses["101"] = SwaggerResponse{Description: e.options.statusDescription(http.StatusSwitchingProtocols)}
+		return operation
+	}
+
+	// 转换响应（匿名结构体以处理函数名生成schema名称）
+	operation.Responses = e.convertResponses(route, e.generateResponseSchemaName(route))
 
 	return operation
 }
 
+// generateResponseSchemaName 生成响应中匿名结构体的schema名称，如 UserGetUserResponse
+func (e *SwaggerExporter) generateResponseSchemaName(route models.RouteInfo) string {
+	return e.cleanSchemaName(route.PackageName) + e.cleanSchemaName(route.Handler) + "Response"
+}
+
 // generateOperationID 生成操作ID
 func (e *SwaggerExporter) generateOperationID(route models.RouteInfo) string {
 	return fmt.Sprintf("%s_%s_%s",
@@ -412,13 +520,14 @@ func (e *SwaggerExporter) convertParameters(requestParams []models.RequestParamI
 	var parameters []SwaggerParameter
 
 	for _, param := range requestParams {
-		if param.ParamType == "query" || param.ParamType == "path" {
+		if param.ParamType == "query" || param.ParamType == "path" || param.ParamType == "header" {
 			swaggerParam := SwaggerParameter{
 				Name:        param.ParamName,
 				In:          param.ParamType,
 				Description: fmt.Sprintf("来源: %s", param.Source),
-				Required:    param.IsRequired,
-				Schema:      e.convertSchemaToSwagger(param.ParamSchema),
+				// OpenAPI 要求路径参数必须为 required
+				Required: param.IsRequired || param.ParamType == "path",
+				Schema:   e.convertSchemaToSwagger(param.ParamSchema),
 			}
 			parameters = append(parameters, swaggerParam)
 		}
@@ -427,8 +536,10 @@ func (e *SwaggerExporter) convertParameters(requestParams []models.RequestParamI
 	return parameters
 }
 
-// convertRequestBody 转换请求体
+// convertRequestBody 转换请求体：同一请求体按不同格式多次绑定时（如 c.ShouldBindBodyWith 分别使用 binding.JSON 和 binding.YAML），
+// 每种格式作为一个 content 条目，同一格式以第一次绑定为准
 func (e *SwaggerExporter) convertRequestBody(requestParams []models.RequestParamInfo) *SwaggerRequestBody {
+	var requestBody *SwaggerRequestBody
 	for _, param := range requestParams {
 		if param.ParamType == "body" {
 			// 为请求体生成更好的schema名称
@@ -437,47 +548,81 @@ func (e *SwaggerExporter) convertRequestBody(requestParams []models.RequestParam
 				schemaName = param.ParamName
 			}
 
-			return &SwaggerRequestBody{
-				Description: fmt.Sprintf("请求体 (来源: %s)", param.Source),
-				Content: map[string]SwaggerMediaType{
-					"application/json": {
-						Schema: e.convertSchemaToSwaggerWithName(param.ParamSchema, schemaName),
-					},
-				},
-				Required: param.IsRequired,
+			// 按绑定方法确定的请求体格式，未知时默认JSON
+			contentType := param.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+
+			if requestBody == nil {
+				requestBody = &SwaggerRequestBody{
+					Description: fmt.Sprintf("请求体 (来源: %s)", param.Source),
+					Content:     make(map[string]SwaggerMediaType),
+				}
 			}
+			if _, exists := requestBody.Content[contentType]; !exists {
+				requestBody.Content[contentType] = SwaggerMediaType{
+					Schema: e.convertSchemaToSwaggerWithName(param.ParamSchema, schemaName),
+				}
+			}
+			requestBody.Required = requestBody.Required || param.IsRequired
 		}
 	}
-	return nil
+	return requestBody
 }
 
 // convertResponses 转换响应
-func (e *SwaggerExporter) convertResponses(responseSchema *models.APISchema) map[string]SwaggerResponse {
+func (e *SwaggerExporter) convertResponses(route models.RouteInfo, schemaName string) map[string]SwaggerResponse {
 	responses := make(map[string]SwaggerResponse)
-
-	if responseSchema != nil {
+	responseSchema := route.ResponseSchema
+	successCode := strconv.Itoa(successStatus(route))
+	successDesc := e.options.successDescription(route)
+
+	if responseSchema != nil && responseSchema.Format == "binary" {
+		// 二进制/文件响应 (c.Data、c.File)，不生成JSON结构
+		contentType := responseSchema.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		responses[successCode] = SwaggerResponse{
+			Description: successDesc,
+			Content: map[string]SwaggerMediaType{
+				contentType: {
+					Schema: map[string]interface{}{
+						"type":   "string",
+						"format": "binary",
+					},
+				},
+			},
+		}
+	} else if responseSchema != nil {
 		var schema map[string]interface{}
 
 		if e.successOnly {
 			// 只显示成功响应的data字段
-			schema = e.extractSuccessDataSchema(responseSchema)
+			schema = e.extractSuccessDataSchema(responseSchema, schemaName)
 		} else {
 			// 显示完整响应
-			schema = e.convertSchemaToSwagger(responseSchema)
+			schema = e.convertSchemaToSwaggerWithName(responseSchema, schemaName)
 		}
 
-		responses["200"] = SwaggerResponse{
-			Description: "成功响应",
+		// JSONP响应为 application/javascript，自定义渲染器 (c.Render) 的内容类型无法确定时为 */*
+		contentType := "application/json"
+		if responseSchema.ContentType != "" {
+			contentType = responseSchema.ContentType
+		}
+		responses[successCode] = SwaggerResponse{
+			Description: successDesc,
 			Content: map[string]SwaggerMediaType{
-				"application/json": {
+				contentType: {
 					Schema: schema,
 				},
 			},
 		}
 	} else {
 		// 默认响应
-		responses["200"] = SwaggerResponse{
-			Description: "成功响应",
+		responses[successCode] = SwaggerResponse{
+			Description: successDesc,
 			Content: map[string]SwaggerMediaType{
 				"application/json": {
 					Schema: map[string]interface{}{
@@ -490,7 +635,7 @@ func (e *SwaggerExporter) convertResponses(responseSchema *models.APISchema) map
 								"type": "string",
 							},
 							"data": map[string]interface{}{},
-							"request_id": map[string]interface{}{
+							e.options.fieldName("request_id"): map[string]interface{}{
 								"type": "string",
 							},
 						},
@@ -503,7 +648,7 @@ func (e *SwaggerExporter) convertResponses(responseSchema *models.APISchema) map
 	// 添加错误响应（如果不是仅成功模式）
 	if !e.successOnly {
 		responses["400"] = SwaggerResponse{
-			Description: "请求错误",
+			Description: e.options.statusDescription(http.StatusBadRequest),
 			Content: map[string]SwaggerMediaType{
 				"application/json": {
 					Schema: map[string]interface{}{
@@ -513,7 +658,7 @@ func (e *SwaggerExporter) convertResponses(responseSchema *models.APISchema) map
 			},
 		}
 		responses["500"] = SwaggerResponse{
-			Description: "服务器错误",
+			Description: e.options.statusDescription(http.StatusInternalServerError),
 			Content: map[string]SwaggerMediaType{
 				"application/json": {
 					Schema: map[string]interface{}{
@@ -527,30 +672,40 @@ func (e *SwaggerExporter) convertResponses(responseSchema *models.APISchema) map
 	return responses
 }
 
-// extractSuccessDataSchema 提取成功响应的data字段
-func (e *SwaggerExporter) extractSuccessDataSchema(responseSchema *models.APISchema) map[string]interface{} {
-	if responseSchema != nil && responseSchema.Type == "object" && responseSchema.Properties != nil {
-		if dataField, exists := responseSchema.Properties["data"]; exists {
-			// 创建包含data字段的成功响应
-			return map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"code": map[string]interface{}{
-						"type":    "integer",
-						"example": 0,
-					},
-					"message": map[string]interface{}{
-						"type":    "string",
-						"example": "success",
-					},
-					"data": e.convertSchemaToSwaggerWithName(dataField, "ResponseData"),
-					"request_id": map[string]interface{}{
-						"type":    "string",
-						"example": "uuid",
-					},
-				},
+// extractSuccessDataSchema 按配置的封装字段（默认data）提取成功响应
+func (e *SwaggerExporter) extractSuccessDataSchema(responseSchema *models.APISchema, schemaName string) map[string]interface{} {
+	field := e.options.EnvelopeField
+	if field == "" {
+		// 未配置封装字段，不解包
+		return e.convertSchemaToSwaggerWithName(responseSchema, schemaName)
+	}
+
+	if key, dataField := findEnvelopeField(responseSchema, field); dataField != nil {
+		dataSchema := e.convertSchemaToSwaggerWithName(dataField, strings.TrimSuffix(schemaName, "Response")+"Data")
+		if !e.options.KeepEnvelope {
+			return dataSchema
+		}
+
+		// 保留封装中的其他字段（如code/message）
+		properties := make(map[string]interface{})
+		for propKey, prop := range responseSchema.Properties {
+			if propKey == key {
+				continue
 			}
+			prop, ok := e.options.redactProperty(propKey, prop)
+			if !ok {
+				continue
+			}
+			properties[e.options.propertyKey(propKey, prop)] = e.convertSchemaToSwaggerWithName(prop, propKey)
+		}
+		properties[e.options.fieldName(field)] = dataSchema
+
+		envelope := map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
 		}
+		e.options.closeObject(envelope, responseSchema)
+		return envelope
 	}
 
 	// 默认成功响应
@@ -565,8 +720,8 @@ func (e *SwaggerExporter) extractSuccessDataSchema(responseSchema *models.APISch
 				"type":    "string",
 				"example": "success",
 			},
-			"data": map[string]interface{}{},
-			"request_id": map[string]interface{}{
+			e.options.fieldName(field): map[string]interface{}{},
+			e.options.fieldName("request_id"): map[string]interface{}{
 				"type":    "string",
 				"example": "uuid",
 			},
@@ -587,41 +742,87 @@ func (e *SwaggerExporter) convertSchemaToSwaggerWithName(apiSchema *models.APISc
 		}
 	}
 
+	// 只读/只写字段：先转换实际结构再标记 readOnly/writeOnly
+	if apiSchema.ReadOnly || apiSchema.WriteOnly {
+		plain := *apiSchema
+		plain.ReadOnly, plain.WriteOnly = false, false
+		return accessSchema(e.convertSchemaToSwaggerWithName(&plain, suggestedName), apiSchema.ReadOnly, apiSchema.WriteOnly)
+	}
+
+	// 可为 null 的值：先转换实际结构再标记 nullable
+	if apiSchema.Nullable {
+		nonNull := *apiSchema
+		nonNull.Nullable = false
+		return nullableSchema(e.convertSchemaToSwaggerWithName(&nonNull, suggestedName))
+	}
+
+	// 多个候选结构，生成oneOf
+	if len(apiSchema.OneOf) > 0 {
+		var oneOf []interface{}
+		for _, candidate := range apiSchema.OneOf {
+			oneOf = append(oneOf, e.convertSchemaToSwaggerWithName(candidate, suggestedName))
+		}
+		schema := map[string]interface{}{
+			"oneOf": oneOf,
+		}
+		if apiSchema.Description != "" {
+			schema["description"] = apiSchema.Description
+		}
+		return schema
+	}
+
 	// 对于简单类型，直接返回
+	var simpleSchema map[string]interface{}
 	switch apiSchema.Type {
 	case "string":
-		return map[string]interface{}{
+		simpleSchema = map[string]interface{}{
 			"type":    "string",
 			"example": "string",
 		}
 	case "integer":
-		return map[string]interface{}{
+		simpleSchema = map[string]interface{}{
 			"type":    "integer",
 			"example": 0,
 		}
 	case "number":
-		return map[string]interface{}{
+		simpleSchema = map[string]interface{}{
 			"type":    "number",
 			"example": 0.0,
 		}
 	case "boolean":
-		return map[string]interface{}{
+		simpleSchema = map[string]interface{}{
 			"type":    "boolean",
 			"example": false,
 		}
 	case "any", "unknown":
-		return map[string]interface{}{
+		simpleSchema = map[string]interface{}{
 			"type": "object",
 		}
 	}
+	if simpleSchema != nil {
+		if apiSchema.Description != "" {
+			simpleSchema["description"] = apiSchema.Description
+		}
+		if apiSchema.Format != "" {
+			simpleSchema["format"] = apiSchema.Format
+		}
+		if apiSchema.Default != nil {
+			simpleSchema["default"] = apiSchema.Default
+		}
+		if apiSchema.Example != nil {
+			simpleSchema["example"] = apiSchema.Example
+		}
+		return simpleSchema
+	}
 
 	// 对于有properties的复杂类型，提取为组件（不管type是什么）
 	if apiSchema.Properties != nil && len(apiSchema.Properties) > 0 {
 		// 生成schema名称
 		schemaName := e.generateSchemaName(apiSchema, suggestedName)
+		anonymous := e.isAnonymousSchema(apiSchema)
 
-		// 检查是否已经定义过
-		if _, exists := e.schemas[schemaName]; !exists {
+		// 检查是否已经定义过（匿名结构体的名称是合成的，需要重新比对定义）
+		if !e.schemas.has(schemaName) || anonymous {
 			// 创建schema定义
 			schema := map[string]interface{}{
 				"type": "object",
@@ -633,17 +834,26 @@ func (e *SwaggerExporter) convertSchemaToSwaggerWithName(apiSchema *models.APISc
 
 			properties := make(map[string]interface{})
 			for key, prop := range apiSchema.Properties {
-				// 使用JSON标签作为键名，如果没有则使用字段名
-				jsonKey := key
-				if prop.JSONTag != "" && prop.JSONTag != "-" {
-					jsonKey = prop.JSONTag
+				prop, ok := e.options.redactProperty(key, prop)
+				if !ok {
+					continue
 				}
-				properties[jsonKey] = e.convertSchemaToSwaggerWithName(prop, key)
+				// 使用JSON标签作为键名，如果没有则使用字段名，再按配置的命名风格转换
+				properties[e.options.propertyKey(key, prop)] = e.convertSchemaToSwaggerWithName(prop, key)
 			}
 			schema["properties"] = properties
-
-			// 添加到schemas集合
-			e.schemas[schemaName] = schema
+			e.options.closeObject(schema, apiSchema)
+
+			// 添加到schemas集合，不同的匿名结构体合成了相同名称时追加序号区分；
+			// 开启结构去重时，与已有组件结构相同的匿名结构体直接引用该组件
+			if anonymous && e.options.DedupSchemas {
+				schemaName = e.schemas.registerShape(schemaName, schema, e.options.displayName)
+			} else if anonymous {
+				schemaName = e.schemas.register(schemaName, schema, e.options.displayName)
+			} else {
+				setTitle(schema, schemaName, e.options.displayName)
+				e.schemas.set(schemaName, schema)
+			}
 		}
 
 		// 返回引用
@@ -702,6 +912,36 @@ func (e *SwaggerExporter) convertSchemaToSwaggerWithName(apiSchema *models.APISc
 	return schema
 }
 
+// nullableSchema 标记schema可为 null
+// OpenAPI 3.0 中 $ref 的同级属性会被忽略，引用需要包装在 allOf 中
+func nullableSchema(schema map[string]interface{}) map[string]interface{} {
+	if _, isRef := schema["$ref"]; isRef {
+		return map[string]interface{}{
+			"allOf":    []interface{}{schema},
+			"nullable": true,
+		}
+	}
+	schema["nullable"] = true
+	return schema
+}
+
+// accessSchema 标记schema只读（只出现在响应中）或只写（只出现在请求中）
+// 与 nullableSchema 相同，$ref 的同级属性会被忽略，引用需要包装在 allOf 中
+func accessSchema(schema map[string]interface{}, readOnly, writeOnly bool) map[string]interface{} {
+	if _, isRef := schema["$ref"]; isRef {
+		schema = map[string]interface{}{
+			"allOf": []interface{}{schema},
+		}
+	}
+	if readOnly {
+		schema["readOnly"] = true
+	}
+	if writeOnly {
+		schema["writeOnly"] = true
+	}
+	return schema
+}
+
 // generateSchemaName 生成schema名称
 func (e *SwaggerExporter) generateSchemaName(apiSchema *models.APISchema, suggestedName string) string {
 	// 尝试从类型名称生成（优先使用自定义类型名）
@@ -715,6 +955,16 @@ func (e *SwaggerExporter) generateSchemaName(apiSchema *models.APISchema, sugges
 	}
 
 	if !isStandardType && apiSchema.Type != "" {
+		// 不同包中存在同名类型时，使用带包名的名称
+		if qualifiedName, ok := e.typeNames[apiSchema.Package+"."+apiSchema.Type]; ok {
+			return qualifiedName
+		}
+
+		// 模型包中的类型名原样使用
+		if e.options.isModelPackage(apiSchema.Package) {
+			return apiSchema.Type
+		}
+
 		// 自定义类型名，直接使用
 		typeName := e.cleanSchemaName(apiSchema.Type)
 		if typeName != "" {
@@ -739,13 +989,15 @@ func (e *SwaggerExporter) generateSchemaName(apiSchema *models.APISchema, sugges
 		}
 	}
 
-	// 基于属性生成名称
+	// 基于属性生成名称（按属性名排序保证名称稳定）
 	if apiSchema.Properties != nil && len(apiSchema.Properties) > 0 {
 		var keyNames []string
 		for key := range apiSchema.Properties {
-			if len(keyNames) < 3 { // 只取前3个属性名
-				keyNames = append(keyNames, key)
-			}
+			keyNames = append(keyNames, key)
+		}
+		sort.Strings(keyNames)
+		if len(keyNames) > 3 { // 只取前3个属性名
+			keyNames = keyNames[:3]
 		}
 		if len(keyNames) > 0 {
 			baseName := strings.Join(keyNames, "")
@@ -757,6 +1009,74 @@ func (e *SwaggerExporter) generateSchemaName(apiSchema *models.APISchema, sugges
 	return "ObjectSchema"
 }
 
+// isAnonymousSchema 判断是否为匿名结构（匿名结构体、gin.H字面量等），其schema名称由导出器合成
+func (e *SwaggerExporter) isAnonymousSchema(apiSchema *models.APISchema) bool {
+	return apiSchema.Type == "" || apiSchema.Type == "object"
+}
+
+// collectAmbiguousTypeNames 找出在多个包中同名的类型，为其生成带包名的schema名称
+// 优先使用包路径最后一段作为前缀（如 AdminUserInfo），仍冲突时使用完整包路径
+// 模型包中的类型保留原名（多个模型包同名时按包路径排序，第一个保留原名）
+func (e *SwaggerExporter) collectAmbiguousTypeNames(routes []models.RouteInfo) map[string]string {
+	packagesByType := make(map[string]map[string]bool) // 类型名 -> 包路径集合
+	var collect func(schema *models.APISchema)
+	collect = func(schema *models.APISchema) {
+		if schema == nil {
+			return
+		}
+		if schema.Package != "" && schema.Type != "" {
+			if packagesByType[schema.Type] == nil {
+				packagesByType[schema.Type] = make(map[string]bool)
+			}
+			packagesByType[schema.Type][schema.Package] = true
+		}
+		for _, prop := range schema.Properties {
+			collect(prop)
+		}
+		collect(schema.Items)
+		for _, candidate := range schema.OneOf {
+			collect(candidate)
+		}
+	}
+	for _, route := range routes {
+		for _, param := range route.RequestParams {
+			collect(param.ParamSchema)
+		}
+		collect(route.ResponseSchema)
+	}
+
+	typeNames := make(map[string]string)
+	for typeName, pkgPaths := range packagesByType {
+		if len(pkgPaths) < 2 {
+			continue
+		}
+
+		shortNames := make(map[string]int)
+		for pkgPath := range pkgPaths {
+			shortNames[e.cleanSchemaName(filepath.Base(pkgPath))]++
+		}
+		sortedPaths := make([]string, 0, len(pkgPaths))
+		for pkgPath := range pkgPaths {
+			sortedPaths = append(sortedPaths, pkgPath)
+		}
+		sort.Strings(sortedPaths)
+
+		modelClaimed := false
+		for _, pkgPath := range sortedPaths {
+			if !modelClaimed && e.options.isModelPackage(pkgPath) {
+				modelClaimed = true
+				continue
+			}
+			prefix := e.cleanSchemaName(filepath.Base(pkgPath))
+			if shortNames[prefix] > 1 {
+				prefix = e.cleanSchemaName(pkgPath)
+			}
+			typeNames[pkgPath+"."+typeName] = prefix + e.cleanSchemaName(typeName)
+		}
+	}
+	return typeNames
+}
+
 // cleanSchemaName 清理schema名称
 func (e *SwaggerExporter) cleanSchemaName(name string) string {
 	// 移除路径分隔符
@@ -776,7 +1096,7 @@ func (e *SwaggerExporter) cleanSchemaName(name string) string {
 // ensureOutputDir 确保输出目录存在
 func (e *SwaggerExporter) ensureOutputDir() error {
 	if e.outputDir == "" {
-		e.outputDir = "./swagger_exports"
+		e.outputDir = ResolveOutputDir("", "", OutputFormatSwagger)
 	}
 
 	return os.MkdirAll(e.outputDir, 0755)
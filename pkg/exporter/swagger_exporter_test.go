@@ -0,0 +1,164 @@
+package exporter
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// envelopeRoute 返回 {code, message, result: User} 封装响应的路由
+func envelopeRoute() models.RouteInfo {
+	return models.RouteInfo{
+		Method:  "GET",
+		Path:    "/users/:id",
+		Handler: "GetUser",
+		ResponseSchema: &models.APISchema{
+			Type: "object",
+			Properties: map[string]*models.APISchema{
+				"Code":    {Type: "integer", JSONTag: "code"},
+				"Message": {Type: "string", JSONTag: "message"},
+				"Result": {
+					Type:    "object",
+					JSONTag: "result",
+					Properties: map[string]*models.APISchema{
+						"Name": {Type: "string", JSONTag: "name"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// successSchema 生成文档，返回 GET 接口 200 响应的 schema 与 components.schemas
+func successSchema(t *testing.T, options Options, route models.RouteInfo) (map[string]interface{}, map[string]interface{}) {
+	t.Helper()
+	e := NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+	e.SetOptions(options)
+	doc := e.Generate(&models.APIInfo{Routes: []models.RouteInfo{route}})
+	schemas, _ := doc.Components["schemas"].(map[string]interface{})
+	path, ok := doc.Paths[route.Path]
+	if !ok || path.Get == nil {
+		t.Fatalf("文档中没有 GET %s", route.Path)
+	}
+	response, ok := path.Get.Responses["200"]
+	if !ok {
+		t.Fatalf("GET %s 没有 200 响应", route.Path)
+	}
+	return response.Content["application/json"].Schema, schemas
+}
+
+func TestUnwrapResultEnvelope(t *testing.T) {
+	options := DefaultOptions()
+	options.EnvelopeField = "result"
+	options.KeepEnvelope = false
+	schema, schemas := successSchema(t, options, envelopeRoute())
+
+	// 解包后成功响应直接是 result 字段的结构，不再包含 code/message
+	ref, _ := schema["$ref"].(string)
+	if ref != "#/components/schemas/GetUserData" {
+		t.Fatalf("成功响应应引用 result 字段的结构, 实际为 %#v", schema)
+	}
+	data, _ := schemas["GetUserData"].(map[string]interface{})
+	properties, _ := data["properties"].(map[string]interface{})
+	if _, ok := properties["name"]; !ok || len(properties) != 1 {
+		t.Errorf("GetUserData 应只包含 name 字段, 实际为 %#v", data)
+	}
+}
+
+func TestKeepResultEnvelope(t *testing.T) {
+	options := DefaultOptions()
+	options.EnvelopeField = "result"
+	schema, _ := successSchema(t, options, envelopeRoute())
+
+	// 保留封装时 code/message 保持原样，result 替换为提取出的数据结构
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, key := range []string{"code", "message", "result"} {
+		if _, ok := properties[key]; !ok {
+			t.Errorf("封装响应缺少字段 %s, 实际为 %#v", key, schema)
+		}
+	}
+	result, _ := properties["result"].(map[string]interface{})
+	if result["$ref"] != "#/components/schemas/GetUserData" {
+		t.Errorf("result 字段应引用 GetUserData, 实际为 %#v", result)
+	}
+}
+
+func TestStrictSchemas(t *testing.T) {
+	route := models.RouteInfo{
+		Method:  "GET",
+		Path:    "/profile",
+		Handler: "GetProfile",
+		ResponseSchema: &models.APISchema{
+			Type: "Profile",
+			Properties: map[string]*models.APISchema{
+				"Name":  {Type: "string", JSONTag: "name"},
+				"Extra": {Type: "object", JSONTag: "extra", Description: "free-form object"},
+				// type Labels map[string]string
+				"Labels": {Type: "Labels", JSONTag: "labels", Map: true, Properties: map[string]*models.APISchema{
+					"<key>":   {Type: "string"},
+					"<value>": {Type: "string"},
+				}},
+			},
+		},
+	}
+	options := DefaultOptions()
+	options.EnvelopeField = ""
+	options.StrictSchemas = true
+	_, schemas := successSchema(t, options, route)
+
+	profile, _ := schemas["Profile"].(map[string]interface{})
+	if profile["additionalProperties"] != false {
+		t.Errorf("字段确定的对象应设置 additionalProperties: false，实际为 %#v", profile)
+	}
+	extra, _ := profile["properties"].(map[string]interface{})["extra"].(map[string]interface{})
+	if _, ok := extra["additionalProperties"]; ok {
+		t.Errorf("map[string]interface{} 等任意对象不应限制字段，实际为 %#v", extra)
+	}
+	if labels, _ := schemas["Labels"].(map[string]interface{}); labels == nil || labels["additionalProperties"] == false {
+		t.Errorf("命名的 map 类型不应限制字段，实际为 %#v", labels)
+	}
+
+	// 默认不开启
+	options.StrictSchemas = false
+	_, schemas = successSchema(t, options, route)
+	if _, ok := schemas["Profile"].(map[string]interface{})["additionalProperties"]; ok {
+		t.Errorf("未开启严格模式时不应设置 additionalProperties")
+	}
+}
+
+func TestFilterByTag(t *testing.T) {
+	info := &models.APIInfo{Routes: []models.RouteInfo{
+		{Method: "POST", Path: "/equity/order/create", Handler: "CreateOrder", PackagePath: "example.com/app/order"},
+		{Method: "GET", Path: "/order/list", Handler: "ListOrders", PackagePath: "example.com/app/order"},
+		{Method: "GET", Path: "/users", Handler: "ListUsers", PackagePath: "example.com/app/user"},
+	}}
+	generate := func(options Options) []string {
+		e := NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+		e.SetOptions(options)
+		var paths []string
+		for path := range e.Generate(info).Paths {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	options := DefaultOptions()
+	options.Tags = []string{"order"}
+	if got := generate(options); !reflect.DeepEqual(got, []string{"/equity/order/create", "/order/list"}) {
+		t.Errorf("标签过滤 Order 应只保留订单路由，实际为 %v", got)
+	}
+
+	// 按包分组时使用包名作为标签过滤
+	options.GroupBy = GroupByPackage
+	options.Tags = []string{"user"}
+	if got := generate(options); !reflect.DeepEqual(got, []string{"/users"}) {
+		t.Errorf("按包分组时标签过滤 user 应只保留 /users，实际为 %v", got)
+	}
+
+	if got := generate(DefaultOptions()); len(got) != 3 {
+		t.Errorf("未配置标签过滤时应导出全部路由，实际为 %v", got)
+	}
+}
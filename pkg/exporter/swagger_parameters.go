@@ -0,0 +1,86 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// invalidComponentChars 组件名称中不允许的字符 (OpenAPI 要求匹配 ^[a-zA-Z0-9.\-_]+$)
+var invalidComponentChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]`)
+
+// sharedParameter 提取为公共参数的候选：同一位置、同名且结构相同的参数
+type sharedParameter struct {
+	param SwaggerParameter
+	count int
+	name  string // 组件名称，出现次数达到阈值时分配
+}
+
+// extractSharedParameters 将在至少 SharedParameters 个操作中出现的参数（位置、名称、是否必填和schema相同）
+// 提取到 components.parameters，操作中改为 $ref 引用；各处说明（来源）不同时公共参数不带说明。
+// 按路径排序遍历，组件名称稳定：位置_参数名，如 query_page，同名不同结构时追加序号
+func (e *SwaggerExporter) extractSharedParameters(paths map[string]SwaggerPath) map[string]interface{} {
+	if e.options.SharedParameters <= 0 {
+		return nil
+	}
+
+	pathKeys := make([]string, 0, len(paths))
+	for path := range paths {
+		pathKeys = append(pathKeys, path)
+	}
+	sort.Strings(pathKeys)
+
+	candidates := make(map[string]*sharedParameter)
+	var order []string
+	for _, path := range pathKeys {
+		for _, operation := range paths[path].operations() {
+			for _, param := range operation.Parameters {
+				key := sharedParameterKey(param)
+				candidate, ok := candidates[key]
+				if !ok {
+					candidate = &sharedParameter{param: param}
+					candidates[key] = candidate
+					order = append(order, key)
+				} else if candidate.param.Description != param.Description {
+					candidate.param.Description = ""
+				}
+				candidate.count++
+			}
+		}
+	}
+
+	components := make(map[string]interface{})
+	for _, key := range order {
+		candidate := candidates[key]
+		if candidate.count < e.options.SharedParameters {
+			continue
+		}
+		base := candidate.param.In + "_" + invalidComponentChars.ReplaceAllString(candidate.param.Name, "_")
+		candidate.name = base
+		for i := 2; components[candidate.name] != nil; i++ {
+			candidate.name = fmt.Sprintf("%s%d", base, i)
+		}
+		components[candidate.name] = candidate.param
+	}
+	if len(components) == 0 {
+		return nil
+	}
+
+	for _, path := range pathKeys {
+		for _, operation := range paths[path].operations() {
+			for i, param := range operation.Parameters {
+				if candidate := candidates[sharedParameterKey(param)]; candidate.name != "" {
+					operation.Parameters[i] = SwaggerParameter{Ref: "#/components/parameters/" + candidate.name}
+				}
+			}
+		}
+	}
+	return components
+}
+
+// sharedParameterKey 判断参数是否相同的键：位置、名称、是否必填及schema，不含说明
+func sharedParameterKey(param SwaggerParameter) string {
+	schema, _ := json.Marshal(param.Schema)
+	return fmt.Sprintf("%s:%s:%t:%s", param.In, param.Name, param.Required, schema)
+}
@@ -0,0 +1,67 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// pagedRoute 返回带 page 查询参数的 GET 路由，source 为参数的来源方法
+func pagedRoute(path, handler, source string, params ...models.RequestParamInfo) models.RouteInfo {
+	return models.RouteInfo{
+		Method:  "GET",
+		Path:    path,
+		Handler: handler,
+		RequestParams: append([]models.RequestParamInfo{
+			{ParamType: "query", ParamName: "page", ParamSchema: &models.APISchema{Type: "integer"}, Source: source},
+		}, params...),
+	}
+}
+
+func TestSharedParameters(t *testing.T) {
+	info := &models.APIInfo{Routes: []models.RouteInfo{
+		pagedRoute("/users", "ListUsers", "c.Query"),
+		pagedRoute("/orders", "ListOrders", "c.DefaultQuery",
+			models.RequestParamInfo{ParamType: "query", ParamName: "status", ParamSchema: &models.APISchema{Type: "string"}, Source: "c.Query"}),
+		// 同名但结构不同，不与 page 合并
+		{Method: "GET", Path: "/items", Handler: "ListItems", RequestParams: []models.RequestParamInfo{
+			{ParamType: "query", ParamName: "page", ParamSchema: &models.APISchema{Type: "string"}, Source: "c.Query"},
+		}},
+	}}
+
+	options := DefaultOptions()
+	options.SharedParameters = 2
+	e := NewSwaggerExporter("fixture", "1.0.0", "", "", true)
+	e.SetOptions(options)
+	doc := e.Generate(info)
+
+	parameters, _ := doc.Components["parameters"].(map[string]interface{})
+	if len(parameters) != 1 {
+		t.Fatalf("只有出现在两个操作中的 page 应提取为公共参数，实际为 %v", parameters)
+	}
+	page, ok := parameters["query_page"].(SwaggerParameter)
+	if !ok || page.Name != "page" || page.In != "query" {
+		t.Fatalf("公共参数 query_page 应为查询参数 page，实际为 %#v", parameters["query_page"])
+	}
+	// 两处来源不同，公共参数不带说明
+	if page.Description != "" {
+		t.Errorf("说明不同时公共参数不应带说明，实际为 %q", page.Description)
+	}
+
+	for _, path := range []string{"/users", "/orders"} {
+		if ref := doc.Paths[path].Get.Parameters[0].Ref; ref != "#/components/parameters/query_page" {
+			t.Errorf("%s 的 page 参数应引用公共参数，实际为 %q", path, ref)
+		}
+	}
+	if status := doc.Paths["/orders"].Get.Parameters[1]; status.Ref != "" || status.Name != "status" {
+		t.Errorf("只出现一次的 status 应保持内联，实际为 %#v", status)
+	}
+	if items := doc.Paths["/items"].Get.Parameters[0]; items.Ref != "" {
+		t.Errorf("结构不同的同名参数应保持内联，实际为 %#v", items)
+	}
+
+	// 默认不提取
+	if _, ok := NewSwaggerExporter("fixture", "1.0.0", "", "", true).Generate(info).Components["parameters"]; ok {
+		t.Errorf("未开启时不应生成 components.parameters")
+	}
+}
@@ -0,0 +1,228 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YogeLiu/api-tool/pkg/console"
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// 拆分导出时入口文档与共享schema文件的名称
+const (
+	splitIndexFile   = "index.json"
+	splitSchemasFile = "schemas.json"
+)
+
+// swaggerIndexDoc 拆分导出的入口文档，路径通过 $ref 引用各分组文件中的定义
+type swaggerIndexDoc struct {
+	OpenAPI string                       `json:"openapi"`
+	Info    SwaggerInfo                  `json:"info"`
+	Servers []SwaggerServer              `json:"servers,omitempty"`
+	Tags    []SwaggerTag                 `json:"tags,omitempty"`
+	Paths   map[string]map[string]string `json:"paths"`
+}
+
+// exportSplit 按标签或包拆分导出到单独的目录：
+// index.json 为入口文档，每个分组一个文件，共享的schema定义放在 schemas.json 中，分组文件通过跨文件 $ref 引用
+func (e *SwaggerExporter) exportSplit(doc *SwaggerDoc, routes []models.RouteInfo) error {
+	dir := filepath.Join(e.outputDir, fmt.Sprintf("%s_swagger_%d",
+		e.sanitizeFilename(e.projectName),
+		time.Now().Unix()))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %v", err)
+	}
+
+	// 同一路径的所有方法放在同一个文件中，以第一个注册的路由所属分组为准
+	groupPaths := make(map[string][]string)
+	assigned := make(map[string]bool)
+	for _, route := range routes {
+		if assigned[route.Path] {
+			continue
+		}
+		assigned[route.Path] = true
+		group := e.splitGroup(route)
+		groupPaths[group] = append(groupPaths[group], route.Path)
+	}
+
+	groups := make([]string, 0, len(groupPaths))
+	for group := range groupPaths {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	index := swaggerIndexDoc{
+		OpenAPI: doc.OpenAPI,
+		Info:    doc.Info,
+		Servers: doc.Servers,
+		Tags:    doc.Tags,
+		Paths:   make(map[string]map[string]string),
+	}
+
+	// 文件名忽略大小写去重，避免在大小写不敏感的文件系统上互相覆盖
+	usedNames := map[string]bool{splitIndexFile: true, splitSchemasFile: true}
+	for _, group := range groups {
+		filename := e.splitFilename(group, usedNames)
+
+		groupDoc := &SwaggerDoc{
+			OpenAPI: doc.OpenAPI,
+			Info:    doc.Info,
+			Servers: doc.Servers,
+			Paths:   make(map[string]SwaggerPath),
+		}
+		groupDoc.Info.Title = fmt.Sprintf("%s - %s", doc.Info.Title, group)
+
+		usedTags := make(map[string]bool)
+		for _, path := range groupPaths[group] {
+			swaggerPath := doc.Paths[path]
+			groupDoc.Paths[path] = swaggerPath
+			for _, operation := range swaggerPath.operations() {
+				for _, tag := range operation.Tags {
+					usedTags[tag] = true
+				}
+			}
+			index.Paths[path] = map[string]string{
+				"$ref": filename + "#/paths/" + jsonPointerToken(path),
+			}
+		}
+		for _, tag := range doc.Tags {
+			if usedTags[tag.Name] {
+				groupDoc.Tags = append(groupDoc.Tags, tag)
+			}
+		}
+
+		if err := writeSplitFile(filepath.Join(dir, filename), groupDoc, true, e.options.Compact); err != nil {
+			return err
+		}
+	}
+
+	// 共享schema文件本身也是合法的OpenAPI文档，内部引用保持为本文件内的引用
+	schemasDoc := &SwaggerDoc{
+		OpenAPI:    doc.OpenAPI,
+		Info:       doc.Info,
+		Paths:      map[string]SwaggerPath{},
+		Components: doc.Components,
+	}
+	if err := writeSplitFile(filepath.Join(dir, splitSchemasFile), schemasDoc, false, e.options.Compact); err != nil {
+		return err
+	}
+	if err := writeSplitFile(filepath.Join(dir, splitIndexFile), index, false, e.options.Compact); err != nil {
+		return err
+	}
+
+	console.Printf("✅ Swagger格式拆分导出成功: %s\n", filepath.Join(dir, splitIndexFile))
+	console.Printf("📊 导出统计: %d个接口, %d个标签, %d个分组文件 (按%s拆分)\n",
+		len(doc.Paths), len(doc.Tags), len(groups), e.options.SplitBy)
+
+	if e.successOnly {
+		console.Println("📝 注意: 仅包含成功响应，已过滤错误响应")
+	}
+
+	return nil
+}
+
+// exportByServer 按注册路由的根路由器分组，每个服务生成一份完整的Swagger文档，
+// 未区分服务的路由（只有一个根路由器或通过包级函数注册）归入 default
+func (e *SwaggerExporter) exportByServer(apiInfo *models.APIInfo) error {
+	serverRoutes := make(map[string][]models.RouteInfo)
+	for _, route := range apiInfo.Routes {
+		server := route.Server
+		if server == "" {
+			server = "default"
+		}
+		serverRoutes[server] = append(serverRoutes[server], route)
+	}
+
+	servers := make([]string, 0, len(serverRoutes))
+	for server := range serverRoutes {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+
+	timestamp := time.Now().Unix()
+	usedNames := make(map[string]bool)
+	for _, server := range servers {
+		doc := e.Generate(&models.APIInfo{
+			Routes:   serverRoutes[server],
+			Warnings: apiInfo.Warnings,
+		})
+		doc.Info.Title = fmt.Sprintf("%s - %s", doc.Info.Title, server)
+
+		filename := e.splitFilename(fmt.Sprintf("%s_%s_swagger_%d", e.projectName, server, timestamp), usedNames)
+		path := filepath.Join(e.outputDir, filename)
+		if err := writeSplitFile(path, doc, false, e.options.Compact); err != nil {
+			return err
+		}
+		console.Printf("✅ Swagger格式导出成功: %s\n", path)
+		console.Printf("📊 导出统计 (%s): %d个接口, %d个标签\n", server, len(doc.Paths), len(doc.Tags))
+	}
+
+	if e.successOnly {
+		console.Println("📝 注意: 仅包含成功响应，已过滤错误响应")
+	}
+
+	return nil
+}
+
+// splitGroup 路由所属的分组：按包拆分时使用包路径，否则使用标签
+func (e *SwaggerExporter) splitGroup(route models.RouteInfo) string {
+	if e.options.SplitBy == SplitByPackage {
+		if route.PackagePath == "" {
+			return "default"
+		}
+		return e.options.trimPackagePath(route.PackagePath)
+	}
+	return e.extractTag(route)
+}
+
+// splitFilename 分组对应的文件名，与已使用的文件名冲突时追加序号
+func (e *SwaggerExporter) splitFilename(group string, usedNames map[string]bool) string {
+	base := e.sanitizeFilename(group)
+	filename := base + ".json"
+	for i := 2; usedNames[strings.ToLower(filename)]; i++ {
+		filename = fmt.Sprintf("%s_%d.json", base, i)
+	}
+	usedNames[strings.ToLower(filename)] = true
+	return filename
+}
+
+// operations 返回路径下定义的所有操作
+func (p SwaggerPath) operations() []*SwaggerOperation {
+	var operations []*SwaggerOperation
+	for _, operation := range []*SwaggerOperation{p.Get, p.Post, p.Put, p.Delete, p.Patch} {
+		if operation != nil {
+			operations = append(operations, operation)
+		}
+	}
+	return operations
+}
+
+// jsonPointerToken 将路径转义为JSON Pointer片段 (RFC 6901)，如 /users/{id} -> ~1users~1%7Bid%7D
+func jsonPointerToken(path string) string {
+	token := strings.ReplaceAll(path, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	return url.PathEscape(token)
+}
+
+// writeSplitFile 写入拆分后的文件，refSchemas 为 true 时组件（schema、公共参数）引用改为指向共享schema文件
+func writeSplitFile(path string, doc interface{}, refSchemas, compact bool) error {
+	jsonData, err := MarshalOutput(doc, compact)
+	if err != nil {
+		return fmt.Errorf("JSON序列化失败: %v", err)
+	}
+	if refSchemas {
+		jsonData = bytes.ReplaceAll(jsonData,
+			[]byte(`"#/components/`),
+			[]byte(`"`+splitSchemasFile+`#/components/`))
+	}
+	if err := os.WriteFile(path, jsonData, 0644); err != nil {
+		return fmt.Errorf("保存文件失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// splitRoutes 返回分属 users、orders 两个标签的路由
+func splitRoutes() *models.APIInfo {
+	user := &models.APISchema{
+		Type: "User",
+		Properties: map[string]*models.APISchema{
+			"Name": {Type: "string", JSONTag: "name"},
+		},
+	}
+	return &models.APIInfo{Routes: []models.RouteInfo{
+		{Method: "GET", Path: "/users/{id}", Handler: "GetUser", ResponseSchema: user},
+		{Method: "GET", Path: "/orders", Handler: "ListOrders", ResponseSchema: &models.APISchema{Type: "array", Items: user}},
+	}}
+}
+
+// exportFiles 将 splitRoutes 导出到临时目录，返回生成的所有文件（相对路径）
+func exportFiles(t *testing.T, options Options) (string, []string) {
+	t.Helper()
+	return exportInfoFiles(t, options, splitRoutes())
+}
+
+// exportInfoFiles 将 apiInfo 导出到临时目录，返回生成的所有文件（相对路径）
+func exportInfoFiles(t *testing.T, options Options, apiInfo *models.APIInfo) (string, []string) {
+	t.Helper()
+	dir := t.TempDir()
+	e := NewSwaggerExporter("fixture", "1.0.0", "", dir, true)
+	e.SetOptions(options)
+	if err := e.Export(apiInfo); err != nil {
+		t.Fatalf("导出失败: %v", err)
+	}
+	var files []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return dir, files
+}
+
+func TestSingleFileByDefault(t *testing.T) {
+	_, files := exportFiles(t, Options{})
+	if len(files) != 1 || !strings.HasSuffix(files[0], ".json") {
+		t.Errorf("默认应只输出一个 Swagger 文件，实际为 %v", files)
+	}
+}
+
+func TestSplitByTag(t *testing.T) {
+	dir, files := exportFiles(t, Options{SplitBy: SplitByTag})
+	if len(files) != 4 {
+		t.Fatalf("按标签拆分应输出 index、schemas 与两个分组文件，实际为 %v", files)
+	}
+	split := filepath.Dir(filepath.Join(dir, files[0]))
+
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(split, name))
+		if err != nil {
+			t.Fatalf("缺少拆分文件 %s: %v", name, err)
+		}
+		return string(data)
+	}
+
+	var index swaggerIndexDoc
+	if err := json.Unmarshal([]byte(read(splitIndexFile)), &index); err != nil {
+		t.Fatalf("解析入口文档失败: %v", err)
+	}
+	if ref := index.Paths["/users/{id}"]["$ref"]; ref != "Users.json#/paths/~1users~1%7Bid%7D" {
+		t.Errorf("入口文档应通过 $ref 引用分组文件中的路径，实际为 %q", ref)
+	}
+	if ref := index.Paths["/orders"]["$ref"]; ref != "Orders.json#/paths/~1orders" {
+		t.Errorf("入口文档应通过 $ref 引用分组文件中的路径，实际为 %q", ref)
+	}
+
+	for _, name := range []string{"Users.json", "Orders.json"} {
+		if content := read(name); !strings.Contains(content, `"schemas.json#/components/schemas/User"`) {
+			t.Errorf("%s 应通过跨文件 $ref 引用共享的 User 定义", name)
+		}
+	}
+	if !strings.Contains(read(splitSchemasFile), `"User"`) {
+		t.Errorf("共享 schema 文件应包含 User 定义")
+	}
+}
+
+func TestSplitByServer(t *testing.T) {
+	info := &models.APIInfo{Routes: []models.RouteInfo{
+		{Method: "GET", Path: "/health", Handler: "Health", Server: "api"},
+		{Method: "GET", Path: "/users", Handler: "ListUsers", Server: "api"},
+		{Method: "GET", Path: "/health", Handler: "Health", Server: "admin"},
+	}}
+	dir, files := exportInfoFiles(t, Options{SplitBy: SplitByServer}, info)
+	if len(files) != 2 {
+		t.Fatalf("按服务拆分应为每个服务输出一份文档，实际为 %v", files)
+	}
+
+	paths := make(map[string]int)
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("读取 %s 失败: %v", name, err)
+		}
+		var doc SwaggerDoc
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("解析 %s 失败: %v", name, err)
+		}
+		for _, server := range []string{"api", "admin"} {
+			if strings.Contains(name, "_"+server+"_") {
+				paths[server] = len(doc.Paths)
+			}
+		}
+	}
+	if paths["api"] != 2 || paths["admin"] != 1 {
+		t.Errorf("各服务的文档应只包含自己的路由，实际为 %v (files=%v)", paths, files)
+	}
+}
@@ -3,11 +3,14 @@ package exporter
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/YogeLiu/api-tool/pkg/console"
 	"github.com/YogeLiu/api-tool/pkg/models"
 )
 
@@ -25,8 +28,10 @@ type YAPIInterface struct {
 	ReqBodyType string                 `json:"req_body_type"`
 	ReqBodyForm []YAPIFormParam        `json:"req_body_form"`
 	ReqBodyOther string                 `json:"req_body_other"`
+	ReqBodyIsJSONSchema bool            `json:"req_body_is_json_schema"` // req_body_other 为 JSON Schema
 	ResBody     string                 `json:"res_body"`
 	ResBodyType string                 `json:"res_body_type"`
+	ResBodyIsJSONSchema bool            `json:"res_body_is_json_schema"` // res_body 为示例JSON，不是 JSON Schema
 	Desc        string                 `json:"desc"`
 	Markdown    string                 `json:"markdown"`
 	AddTime     int64                  `json:"add_time"`
@@ -80,6 +85,7 @@ type YAPIProject struct {
 	Info       YAPIProjectInfo `json:"info"`
 	Interfaces []YAPIInterface `json:"interfaces"`
 	Categories []YAPICategory  `json:"categories"`
+	Merge      string          `json:"merge,omitempty"` // 导入时的数据同步方式 (normal/good/merge)
 }
 
 // YAPIProjectInfo YAPI项目信息
@@ -106,24 +112,73 @@ type YAPIProjectInfo struct {
 	Tag []string `json:"tag"`
 }
 
+// YAPI 导入时的数据同步方式
+const (
+	YAPIMergeNormal = "normal" // 不导入已存在的接口
+	YAPIMergeGood   = "good"   // 智能合并，保留已有接口中手工补充的内容
+	YAPIMergeFull   = "merge"  // 完全覆盖已存在的接口
+)
+
+// YAPIProjectOptions 导入目标YAPI项目的配置，避免重复导入时与项目中已有的ID冲突
+type YAPIProjectOptions struct {
+	ProjectID  int    // 项目ID
+	CategoryID int    // 起始分类ID，各分类按顺序递增
+	UID        int    // 创建者的用户ID
+	Merge      string // 导入时的数据同步方式 (normal/good/merge)，为空时由导入方决定
+}
+
+// DefaultYAPIProjectOptions 默认配置：项目、分类和用户ID均从1开始
+func DefaultYAPIProjectOptions() YAPIProjectOptions {
+	return YAPIProjectOptions{
+		ProjectID:  1,
+		CategoryID: 1,
+		UID:        1,
+	}
+}
+
+// IsValidYAPIMerge 检查数据同步方式是否受支持
+func IsValidYAPIMerge(merge string) bool {
+	switch merge {
+	case "", YAPIMergeNormal, YAPIMergeGood, YAPIMergeFull:
+		return true
+	}
+	return false
+}
+
 // YAPIExporter YAPI格式导出器
 type YAPIExporter struct {
 	projectName string
 	projectID   int
+	categoryID  int
+	uid         int
+	merge       string
 	basePath    string
 	outputDir   string
+	options     Options
 }
 
-// NewYAPIExporter 创建YAPI导出器
-func NewYAPIExporter(projectName string, basePath string, outputDir string) *YAPIExporter {
+// NewYAPIExporter 创建YAPI导出器，projectOptions 省略时使用 DefaultYAPIProjectOptions
+func NewYAPIExporter(projectName string, basePath string, outputDir string, projectOptions ...YAPIProjectOptions) *YAPIExporter {
+	project := DefaultYAPIProjectOptions()
+	if len(projectOptions) > 0 {
+		project = projectOptions[0]
+	}
 	return &YAPIExporter{
 		projectName: projectName,
-		projectID:   1, // 默认项目ID
+		projectID:   project.ProjectID,
+		categoryID:  project.CategoryID,
+		uid:         project.UID,
+		merge:       project.Merge,
 		basePath:    basePath,
 		outputDir:   outputDir,
 	}
 }
 
+// SetOptions 设置导出配置（默认不解包响应封装）
+func (e *YAPIExporter) SetOptions(options Options) {
+	e.options = options
+}
+
 // Export 导出API信息为YAPI格式
 func (e *YAPIExporter) Export(apiInfo *models.APIInfo) error {
 	// 创建YAPI项目结构
@@ -135,7 +190,7 @@ func (e *YAPIExporter) Export(apiInfo *models.APIInfo) error {
 	}
 
 	// 生成JSON文件
-	jsonData, err := json.MarshalIndent(yapiProject, "", "  ")
+	jsonData, err := MarshalOutput(yapiProject, e.options.Compact)
 	if err != nil {
 		return fmt.Errorf("JSON序列化失败: %v", err)
 	}
@@ -151,8 +206,8 @@ func (e *YAPIExporter) Export(apiInfo *models.APIInfo) error {
 		return fmt.Errorf("保存文件失败: %v", err)
 	}
 
-	fmt.Printf("✅ YAPI格式导出成功: %s\n", filepath)
-	fmt.Printf("📊 导出统计: %d个接口, %d个分类\n", 
+	console.Printf("✅ YAPI格式导出成功: %s\n", filepath)
+	console.Printf("📊 导出统计: %d个接口, %d个分类\n", 
 		len(yapiProject.Interfaces), len(yapiProject.Categories))
 	
 	return nil
@@ -169,7 +224,7 @@ func (e *YAPIExporter) convertToYAPIProject(apiInfo *models.APIInfo) *YAPIProjec
 		Desc:        fmt.Sprintf("通过api-tool自动生成的API文档 (生成时间: %s)", time.Now().Format("2006-01-02 15:04:05")),
 		BasePath:    e.basePath,
 		ProjectType: "private",
-		UID:         1,
+		UID:         e.uid,
 		GroupID:     1,
 		Icon:        "code-o",
 		Color:       "cyan",
@@ -207,33 +262,38 @@ func (e *YAPIExporter) convertToYAPIProject(apiInfo *models.APIInfo) *YAPIProjec
 		Info:       projectInfo,
 		Interfaces: interfaces,
 		Categories: categories,
+		Merge:      e.merge,
 	}
 }
 
-// createCategories 根据包路径创建分类
+// createCategories 根据包路径（或按配置的路径前缀）创建分类
 func (e *YAPIExporter) createCategories(routes []models.RouteInfo) []YAPICategory {
 	categoryMap := make(map[string]bool)
 	var categories []YAPICategory
 	
 	now := time.Now().Unix()
-	catID := 1
+	catID := e.categoryID
 
-	// 收集所有包路径
+	// 收集所有分类，同名的分类只创建一次
 	for _, route := range routes {
-		if !categoryMap[route.PackagePath] {
-			categoryMap[route.PackagePath] = true
+		// 提取友好的分类名
+		categoryName := e.extractCategoryName(route)
+		if !categoryMap[categoryName] {
+			categoryMap[categoryName] = true
 			
-			// 从包路径提取友好的分类名
-			categoryName := e.extractCategoryName(route.PackagePath)
+			desc := fmt.Sprintf("包路径: %s", route.PackagePath)
+			if e.options.GroupBy == GroupByPathPrefix {
+				desc = fmt.Sprintf("示例路径: %s", route.Path)
+			}
 			
 			categories = append(categories, YAPICategory{
 				ID:       catID,
 				Name:     categoryName,
-				Desc:     fmt.Sprintf("包路径: %s", route.PackagePath),
-				UID:      1,
+				Desc:     desc,
+				UID:      e.uid,
 				AddTime:  now,
 				UpTime:   now,
-				Index:    catID - 1,
+				Index:    catID - e.categoryID,
 				Username: "api-tool",
 			})
 			catID++
@@ -243,24 +303,23 @@ func (e *YAPIExporter) createCategories(routes []models.RouteInfo) []YAPICategor
 	return categories
 }
 
-// extractCategoryName 从包路径提取分类名
-func (e *YAPIExporter) extractCategoryName(packagePath string) string {
-	parts := strings.Split(packagePath, "/")
-	if len(parts) > 0 {
-		return parts[len(parts)-1] // 使用最后一部分作为分类名
+// extractCategoryName 路由的分类名：默认从包路径提取，GroupBy 为 path-prefix 时与Swagger标签一致，从路径前缀提取
+func (e *YAPIExporter) extractCategoryName(route models.RouteInfo) string {
+	if e.options.GroupBy == GroupByPathPrefix {
+		return extractTagFromPath(route.Path)
 	}
-	return "default"
+	return e.options.packageGroup(route.PackagePath)
 }
 
-// getCategoryID 获取包路径对应的分类ID
-func (e *YAPIExporter) getCategoryID(packagePath string, categories []YAPICategory) int {
-	targetName := e.extractCategoryName(packagePath)
+// getCategoryID 获取路由所属分类的ID
+func (e *YAPIExporter) getCategoryID(route models.RouteInfo, categories []YAPICategory) int {
+	targetName := e.extractCategoryName(route)
 	for _, cat := range categories {
 		if cat.Name == targetName {
 			return cat.ID
 		}
 	}
-	return 1 // 默认分类ID
+	return e.categoryID // 默认分类ID
 }
 
 // convertInterfaces 转换接口信息
@@ -269,21 +328,23 @@ func (e *YAPIExporter) convertInterfaces(routes []models.RouteInfo, categories [
 	now := time.Now().Unix()
 
 	for i, route := range routes {
+		reqBodyOther := e.convertRequestBodyOther(route.RequestParams)
 		yapiInterface := YAPIInterface{
 			ID:          i + 1,
 			Title:       e.generateInterfaceTitle(route),
 			Path:        route.Path,
 			Method:      strings.ToUpper(route.Method),
 			ProjectID:   e.projectID,
-			CatID:       e.getCategoryID(route.PackagePath, categories),
+			CatID:       e.getCategoryID(route, categories),
 			Status:      "done",
 			ReqQuery:    e.convertQueryParams(route.RequestParams),
-			ReqHeaders:  e.getDefaultHeaders(),
+			ReqHeaders:  e.convertHeaders(route.RequestParams),
 			ReqBodyType: e.getRequestBodyType(route.RequestParams),
 			ReqBodyForm: e.convertFormParams(route.RequestParams),
-			ReqBodyOther: e.convertRequestBodyOther(route.RequestParams),
-			ResBody:     e.convertResponseBody(route.ResponseSchema),
-			ResBodyType: "json",
+			ReqBodyOther: reqBodyOther,
+			ReqBodyIsJSONSchema: reqBodyOther != "",
+			ResBody:     e.convertResponseBodyForRoute(route),
+			ResBodyType: e.getResponseBodyType(route.ResponseSchema),
 			Desc:        e.generateDescription(route),
 			Markdown:    e.generateMarkdown(route),
 			AddTime:     now,
@@ -292,7 +353,7 @@ func (e *YAPIExporter) convertInterfaces(routes []models.RouteInfo, categories [
 			APIOpened:   false,
 			Index:       i,
 			Username:    "api-tool",
-			UID:         1,
+			UID:         e.uid,
 		}
 
 		interfaces = append(interfaces, yapiInterface)
@@ -303,6 +364,9 @@ func (e *YAPIExporter) convertInterfaces(routes []models.RouteInfo, categories [
 
 // generateInterfaceTitle 生成接口标题
 func (e *YAPIExporter) generateInterfaceTitle(route models.RouteInfo) string {
+	if route.Summary != "" {
+		return route.Summary
+	}
 	return fmt.Sprintf("%s %s", strings.ToUpper(route.Method), route.Path)
 }
 
@@ -319,7 +383,7 @@ func (e *YAPIExporter) convertQueryParams(requestParams []models.RequestParamInf
 
 			queryParams = append(queryParams, YAPIQueryParam{
 				Name:     param.ParamName,
-				Value:    "",
+				Value:    defaultValue(param.ParamSchema),
 				Desc:     e.generateParamDescription(param),
 				Required: required,
 			})
@@ -341,16 +405,43 @@ func (e *YAPIExporter) getDefaultHeaders() []YAPIHeader {
 	}
 }
 
+// convertHeaders 默认请求头加上请求头参数（来自 @Param 注解）
+func (e *YAPIExporter) convertHeaders(requestParams []models.RequestParamInfo) []YAPIHeader {
+	headers := e.getDefaultHeaders()
+	for _, param := range requestParams {
+		if param.ParamType == "header" {
+			required := "0"
+			if param.IsRequired {
+				required = "1"
+			}
+			headers = append(headers, YAPIHeader{
+				Name:     param.ParamName,
+				Desc:     e.generateParamDescription(param),
+				Required: required,
+			})
+		}
+	}
+	return headers
+}
+
 // getRequestBodyType 获取请求体类型
 func (e *YAPIExporter) getRequestBodyType(requestParams []models.RequestParamInfo) string {
 	for _, param := range requestParams {
 		if param.ParamType == "body" {
+			if isFormContentType(param.ContentType) {
+				return "form"
+			}
 			return "json"
 		}
 	}
 	return "none"
 }
 
+// isFormContentType 判断请求体是否为表单格式
+func isFormContentType(contentType string) bool {
+	return contentType == "application/x-www-form-urlencoded" || contentType == "multipart/form-data"
+}
+
 // convertFormParams 转换表单参数
 func (e *YAPIExporter) convertFormParams(requestParams []models.RequestParamInfo) []YAPIFormParam {
 	var formParams []YAPIFormParam
@@ -370,6 +461,39 @@ func (e *YAPIExporter) convertFormParams(requestParams []models.RequestParamInfo
 				Value:    "",
 			})
 		}
+
+		// 表单格式的请求体，按结构体字段展开为表单参数
+		if param.ParamType == "body" && isFormContentType(param.ContentType) && param.ParamSchema != nil {
+			var keys []string
+			for key := range param.ParamSchema.Properties {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				prop, ok := e.options.redactProperty(key, param.ParamSchema.Properties[key])
+				if !ok {
+					continue
+				}
+				// 表单字段名优先使用form标签
+				name := e.options.propertyKey(key, prop)
+				if prop.FormTag != "" {
+					name = prop.FormTag
+				}
+				required := "0"
+				if param.IsRequired {
+					required = "1"
+				}
+
+				formParams = append(formParams, YAPIFormParam{
+					Name:     name,
+					Type:     e.convertSchemaTypeToYAPIType(prop),
+					Desc:     prop.Description,
+					Required: required,
+					Value:    defaultValue(prop),
+				})
+			}
+		}
 	}
 
 	return formParams
@@ -378,9 +502,10 @@ func (e *YAPIExporter) convertFormParams(requestParams []models.RequestParamInfo
 // convertRequestBodyOther 转换请求体其他格式
 func (e *YAPIExporter) convertRequestBodyOther(requestParams []models.RequestParamInfo) string {
 	for _, param := range requestParams {
-		if param.ParamType == "body" && param.ParamSchema != nil {
-			// 生成JSON Schema
-			schema := e.convertAPISchemaToJSONSchema(param.ParamSchema)
+		if param.ParamType == "body" && param.ParamSchema != nil && !isFormContentType(param.ContentType) {
+			// 生成JSON Schema，必填字段 (binding:"required") 列在 required 中
+			schema := e.convertAPISchemaToYAPIJSONSchema(param.ParamSchema)
+			schema["$schema"] = "http://json-schema.org/draft-04/schema#"
 			jsonData, _ := json.MarshalIndent(schema, "", "  ")
 			return string(jsonData)
 		}
@@ -388,20 +513,109 @@ func (e *YAPIExporter) convertRequestBodyOther(requestParams []models.RequestPar
 	return ""
 }
 
+// convertAPISchemaToYAPIJSONSchema 转换APISchema为YAPI请求体使用的 JSON Schema (draft-04)
+func (e *YAPIExporter) convertAPISchemaToYAPIJSONSchema(apiSchema *models.APISchema) map[string]interface{} {
+	schema := map[string]interface{}{}
+	if apiSchema == nil {
+		return schema
+	}
+	if apiSchema.Description != "" {
+		schema["description"] = apiSchema.Description
+	}
+	// 多个候选结构时使用第一个
+	if len(apiSchema.OneOf) > 0 {
+		return e.convertAPISchemaToYAPIJSONSchema(apiSchema.OneOf[0])
+	}
+
+	schemaType := apiSchema.Type
+	if len(apiSchema.Properties) > 0 && schemaType != "array" {
+		schemaType = "object"
+	}
+
+	switch schemaType {
+	case "object":
+		schema["type"] = "object"
+		properties := make(map[string]interface{})
+		var required []string
+		for key, prop := range apiSchema.Properties {
+			prop, ok := e.options.redactProperty(key, prop)
+			if !ok {
+				continue
+			}
+			name := e.options.propertyKey(key, prop)
+			properties[name] = e.convertAPISchemaToYAPIJSONSchema(prop)
+			if prop != nil && prop.Required {
+				required = append(required, name)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			sort.Strings(required)
+			schema["required"] = required
+		}
+	case "array":
+		schema["type"] = "array"
+		schema["items"] = e.convertAPISchemaToYAPIJSONSchema(apiSchema.Items)
+	case "string", "integer", "number", "boolean":
+		schema["type"] = schemaType
+		if apiSchema.Format != "" {
+			schema["format"] = apiSchema.Format
+		}
+		if apiSchema.Default != nil {
+			schema["default"] = apiSchema.Default
+		}
+	case "any":
+		// 任意类型不限制 type
+	default:
+		// 无法识别的类型按字符串处理，保留原类型名便于排查
+		schema["type"] = "string"
+		if apiSchema.Description == "" {
+			schema["description"] = apiSchema.Type
+		}
+	}
+	return schema
+}
+
+// convertResponseBodyForRoute 转换路由的响应体，WebSocket 接口没有JSON响应体
+func (e *YAPIExporter) convertResponseBodyForRoute(route models.RouteInfo) string {
+	if route.Kind == models.RouteKindWebSocket {
+		return ""
+	}
+	return e.convertResponseBody(route.ResponseSchema)
+}
+
+// getResponseBodyType 获取响应体类型，二进制/文件响应为 raw
+func (e *YAPIExporter) getResponseBodyType(responseSchema *models.APISchema) string {
+	if responseSchema != nil && responseSchema.Format == "binary" {
+		return "raw"
+	}
+	return "json"
+}
+
 // convertResponseBody 转换响应体
 func (e *YAPIExporter) convertResponseBody(responseSchema *models.APISchema) string {
+	if responseSchema != nil && responseSchema.Format == "binary" {
+		return fmt.Sprintf("二进制内容 (%s)", responseSchema.ContentType)
+	}
 	if responseSchema == nil {
 		// 返回默认响应格式
 		defaultResponse := map[string]interface{}{
-			"code":       0,
-			"message":    "success",
-			"data":       nil,
-			"request_id": "uuid",
+			"code":                            0,
+			"message":                         "success",
+			"data":                            nil,
+			e.options.fieldName("request_id"): "uuid",
 		}
 		jsonData, _ := json.MarshalIndent(defaultResponse, "", "  ")
 		return string(jsonData)
 	}
 
+	// 按配置的封装字段解包（保留封装字段时使用完整结构）
+	if !e.options.KeepEnvelope {
+		if _, dataField := findEnvelopeField(responseSchema, e.options.EnvelopeField); dataField != nil {
+			responseSchema = dataField
+		}
+	}
+
 	// 转换响应Schema
 	schema := e.convertAPISchemaToJSONSchema(responseSchema)
 	jsonData, _ := json.MarshalIndent(schema, "", "  ")
@@ -414,24 +628,45 @@ func (e *YAPIExporter) convertAPISchemaToJSONSchema(apiSchema *models.APISchema)
 		return nil
 	}
 
-	switch apiSchema.Type {
+	// 多个候选结构时，示例使用第一个
+	if len(apiSchema.OneOf) > 0 {
+		return e.convertAPISchemaToJSONSchema(apiSchema.OneOf[0])
+	}
+
+	// 已知示例值（如枚举常量的值）时直接使用
+	if apiSchema.Example != nil && isPrimitiveSchema(apiSchema) {
+		return apiSchema.Example
+	}
+
+	// 带有字段的自定义类型（如数组元素 CreateReq）按对象处理
+	schemaType := apiSchema.Type
+	if len(apiSchema.Properties) > 0 && schemaType != "array" {
+		schemaType = "object"
+	}
+
+	switch schemaType {
 	case "object":
 		obj := make(map[string]interface{})
 		if apiSchema.Properties != nil {
 			for key, prop := range apiSchema.Properties {
-				// 使用JSON标签作为键名，如果没有则使用字段名
-				jsonKey := key
-				if prop.JSONTag != "" && prop.JSONTag != "-" {
-					jsonKey = prop.JSONTag
+				prop, ok := e.options.redactProperty(key, prop)
+				if !ok {
+					continue
 				}
-				obj[jsonKey] = e.convertAPISchemaToJSONSchema(prop)
+				// 使用JSON标签作为键名，如果没有则使用字段名，再按配置的命名风格转换
+				obj[e.options.propertyKey(key, prop)] = e.convertAPISchemaToJSONSchema(prop)
 			}
 		}
 		return obj
 
 	case "array":
 		if apiSchema.Items != nil {
-			return []interface{}{e.convertAPISchemaToJSONSchema(apiSchema.Items)}
+			item := e.convertAPISchemaToJSONSchema(apiSchema.Items)
+			// 基本类型的数组示例给出两个元素（如 ["string","string"]、[0,0]），对象数组只给出一个元素
+			if isPrimitiveSchema(apiSchema.Items) {
+				return []interface{}{item, item}
+			}
+			return []interface{}{item}
 		}
 		return []interface{}{}
 
@@ -450,12 +685,37 @@ func (e *YAPIExporter) convertAPISchemaToJSONSchema(apiSchema *models.APISchema)
 	}
 }
 
+// isPrimitiveSchema 是否为基本类型（字符串、整数、浮点数、布尔）的结构
+func isPrimitiveSchema(schema *models.APISchema) bool {
+	if schema == nil || len(schema.Properties) > 0 || len(schema.OneOf) > 0 {
+		return false
+	}
+	switch schema.Type {
+	case "string", "integer", "number", "boolean":
+		return true
+	}
+	return false
+}
+
+// defaultValue 参数默认值（default 标签）的文本形式，没有默认值时为空
+func defaultValue(schema *models.APISchema) string {
+	if schema == nil || schema.Default == nil {
+		return ""
+	}
+	return fmt.Sprint(schema.Default)
+}
+
 // convertSchemaTypeToYAPIType 转换Schema类型为YAPI类型
 func (e *YAPIExporter) convertSchemaTypeToYAPIType(schema *models.APISchema) string {
 	if schema == nil {
 		return "text"
 	}
 
+	// 上传文件字段（*multipart.FileHeader）
+	if schema.Type == "FileHeader" || (schema.Items != nil && schema.Items.Type == "FileHeader") {
+		return "file"
+	}
+
 	switch schema.Type {
 	case "string":
 		return "text"
@@ -483,8 +743,27 @@ func (e *YAPIExporter) generateParamDescription(param models.RequestParamInfo) s
 
 // generateDescription 生成接口描述
 func (e *YAPIExporter) generateDescription(route models.RouteInfo) string {
-	return fmt.Sprintf("Handler: %s\n包路径: %s\n生成时间: %s", 
-		route.Handler, 
+	desc := ""
+	if route.Deprecated {
+		desc += "⚠️ 已弃用\n"
+	}
+	if route.Kind == models.RouteKindWebSocket {
+		desc += "WebSocket 接口\n"
+	}
+	if route.HandlerUnresolved {
+		desc += "⚠️ 无法解析处理函数，请求和响应信息缺失\n"
+	}
+	if route.MiddlewareRendered {
+		desc += "响应由中间件渲染 (处理函数通过 c.Set 存储数据)\n"
+	}
+	if route.Description != "" {
+		desc += route.Description + "\n"
+	}
+	if route.Since != "" {
+		desc += fmt.Sprintf("引入日期: %s\n", route.Since)
+	}
+	return desc + fmt.Sprintf("Handler: %s\n包路径: %s\n生成时间: %s",
+		route.Handler,
 		route.PackagePath,
 		time.Now().Format("2006-01-02 15:04:05"))
 }
@@ -492,8 +771,29 @@ func (e *YAPIExporter) generateDescription(route models.RouteInfo) string {
 // generateMarkdown 生成Markdown文档
 func (e *YAPIExporter) generateMarkdown(route models.RouteInfo) string {
 	markdown := fmt.Sprintf("# %s %s\n\n", strings.ToUpper(route.Method), route.Path)
+	if route.Summary != "" {
+		markdown += fmt.Sprintf("**%s**\n\n", route.Summary)
+	}
+	if route.Description != "" {
+		markdown += route.Description + "\n\n"
+	}
 	markdown += fmt.Sprintf("**Handler**: `%s`\n\n", route.Handler)
 	markdown += fmt.Sprintf("**包路径**: `%s`\n\n", route.PackagePath)
+	if route.Since != "" {
+		markdown += fmt.Sprintf("**引入日期**: %s\n\n", route.Since)
+	}
+	if route.Deprecated {
+		markdown += "> ⚠️ 该接口已弃用\n\n"
+	}
+	if route.Kind == models.RouteKindWebSocket {
+		markdown += "> WebSocket 接口：连接升级后通过 WebSocket 收发消息，没有JSON响应体\n\n"
+		markdown += fmt.Sprintf("**响应状态**: %d %s\n\n", http.StatusSwitchingProtocols, e.options.statusDescription(http.StatusSwitchingProtocols))
+	} else {
+		markdown += fmt.Sprintf("**响应状态**: %d %s\n\n", successStatus(route), e.options.successDescription(route))
+	}
+	if len(route.Middlewares) > 0 {
+		markdown += fmt.Sprintf("**中间件**: `%s`\n\n", strings.Join(route.Middlewares, "`, `"))
+	}
 	
 	if len(route.RequestParams) > 0 {
 		markdown += "## 请求参数\n\n"
@@ -528,7 +828,7 @@ func (e *YAPIExporter) generateMarkdown(route models.RouteInfo) string {
 // ensureOutputDir 确保输出目录存在
 func (e *YAPIExporter) ensureOutputDir() error {
 	if e.outputDir == "" {
-		e.outputDir = "./yapi_exports"
+		e.outputDir = ResolveOutputDir("", "", OutputFormatYAPI)
 	}
 	
 	return os.MkdirAll(e.outputDir, 0755)
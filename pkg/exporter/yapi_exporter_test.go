@@ -0,0 +1,187 @@
+package exporter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+)
+
+// bodyRoute 返回只有一个请求体参数的路由
+func bodyRoute(contentType string) models.RouteInfo {
+	return models.RouteInfo{
+		Method:  "POST",
+		Path:    "/login",
+		Handler: "Login",
+		RequestParams: []models.RequestParamInfo{{
+			ParamName:   "request_body",
+			ParamType:   "body",
+			ContentType: contentType,
+			ParamSchema: &models.APISchema{
+				Type: "LoginForm",
+				Properties: map[string]*models.APISchema{
+					"Username": {Type: "string", FormTag: "username"},
+				},
+			},
+		}},
+	}
+}
+
+func TestYAPIRequestBodyType(t *testing.T) {
+	e := NewYAPIExporter("fixture", "", "")
+	for contentType, want := range map[string]string{
+		"application/x-www-form-urlencoded": "form",
+		"multipart/form-data":               "form",
+		"application/json":                  "json",
+	} {
+		interfaces := e.convertInterfaces([]models.RouteInfo{bodyRoute(contentType)}, nil)
+		if got := interfaces[0].ReqBodyType; got != want {
+			t.Errorf("%s 请求体的 req_body_type 应为 %s，实际为 %s", contentType, want, got)
+		}
+		if isForm := want == "form"; isForm != (len(interfaces[0].ReqBodyForm) == 1) {
+			t.Errorf("%s 请求体的表单参数不正确: %+v", contentType, interfaces[0].ReqBodyForm)
+		}
+	}
+}
+
+func TestYAPIRequiredBodyFields(t *testing.T) {
+	route := bodyRoute("application/json")
+	route.RequestParams[0].ParamSchema = &models.APISchema{
+		Type: "CreateUserRequest",
+		Properties: map[string]*models.APISchema{
+			"Name": {Type: "string", JSONTag: "name", Required: true},
+			"Age":  {Type: "integer", JSONTag: "age"},
+			"Address": {
+				Type:     "Address",
+				JSONTag:  "address",
+				Required: true,
+				Properties: map[string]*models.APISchema{
+					"City": {Type: "string", JSONTag: "city", Required: true},
+					"Zip":  {Type: "string", JSONTag: "zip"},
+				},
+			},
+		},
+	}
+
+	e := NewYAPIExporter("fixture", "", "")
+	iface := e.convertInterfaces([]models.RouteInfo{route}, nil)[0]
+	if iface.ReqBodyType != "json" || !iface.ReqBodyIsJSONSchema {
+		t.Fatalf("JSON 请求体应以 JSON Schema 输出，实际 req_body_type=%s req_body_is_json_schema=%v", iface.ReqBodyType, iface.ReqBodyIsJSONSchema)
+	}
+
+	var schema struct {
+		Type       string   `json:"type"`
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Type     string   `json:"type"`
+			Required []string `json:"required"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(iface.ReqBodyOther), &schema); err != nil {
+		t.Fatalf("req_body_other 应为 JSON Schema: %v\n%s", err, iface.ReqBodyOther)
+	}
+	if schema.Type != "object" || len(schema.Properties) != 3 {
+		t.Errorf("请求体应为包含 3 个字段的 object，实际为 %s", iface.ReqBodyOther)
+	}
+	if want := []string{"address", "name"}; !reflect.DeepEqual(schema.Required, want) {
+		t.Errorf("required 应为 %v，实际为 %v", want, schema.Required)
+	}
+	if address := schema.Properties["address"]; address.Type != "object" || !reflect.DeepEqual(address.Required, []string{"city"}) {
+		t.Errorf("嵌套结构体的 required 应为 [city]，实际为 %+v", address)
+	}
+}
+
+func TestYAPIProjectOptions(t *testing.T) {
+	routes := []models.RouteInfo{
+		{Method: "GET", Path: "/users", Handler: "ListUsers", PackagePath: "example.com/app/user"},
+		{Method: "GET", Path: "/orders", Handler: "ListOrders", PackagePath: "example.com/app/order"},
+		{Method: "POST", Path: "/users", Handler: "CreateUser", PackagePath: "example.com/app/user"},
+	}
+	e := NewYAPIExporter("fixture", "", "", YAPIProjectOptions{ProjectID: 42, CategoryID: 100, UID: 7, Merge: YAPIMergeGood})
+	project := e.convertToYAPIProject(&models.APIInfo{Routes: routes})
+
+	if project.Info.ID != 42 || project.Info.UID != 7 || project.Merge != YAPIMergeGood {
+		t.Errorf("项目信息应使用配置的ID和同步方式，实际为 id=%d uid=%d merge=%q", project.Info.ID, project.Info.UID, project.Merge)
+	}
+
+	categoryIDs := make(map[string]int)
+	for i, category := range project.Categories {
+		if category.ID != 100+i || category.Index != i || category.UID != 7 {
+			t.Errorf("分类 %s 的ID应从 100 开始递增，实际为 id=%d index=%d uid=%d", category.Name, category.ID, category.Index, category.UID)
+		}
+		categoryIDs[category.Name] = category.ID
+	}
+	if len(project.Categories) != 2 {
+		t.Fatalf("应按包创建 2 个分类，实际为 %+v", project.Categories)
+	}
+
+	for _, iface := range project.Interfaces {
+		if iface.ProjectID != 42 || iface.UID != 7 {
+			t.Errorf("接口 %s %s 应使用配置的项目和用户ID，实际为 project_id=%d uid=%d", iface.Method, iface.Path, iface.ProjectID, iface.UID)
+		}
+		if iface.CatID < 100 || iface.CatID > 101 {
+			t.Errorf("接口 %s %s 的分类ID应为配置的分类之一，实际为 %d", iface.Method, iface.Path, iface.CatID)
+		}
+	}
+	if project.Interfaces[0].CatID != project.Interfaces[2].CatID || project.Interfaces[0].CatID == project.Interfaces[1].CatID {
+		t.Errorf("同一包的接口应在同一分类，不同包的接口在不同分类: %v", categoryIDs)
+	}
+
+	// 默认从 1 开始，不输出同步方式
+	defaults := NewYAPIExporter("fixture", "", "").convertToYAPIProject(&models.APIInfo{Routes: routes})
+	if defaults.Info.ID != 1 || defaults.Categories[0].ID != 1 || defaults.Merge != "" {
+		t.Errorf("默认项目和分类ID应为 1，实际为 %d/%d merge=%q", defaults.Info.ID, defaults.Categories[0].ID, defaults.Merge)
+	}
+}
+
+func TestYAPIDefaultValues(t *testing.T) {
+	e := NewYAPIExporter("fixture", "", "")
+	route := bodyRoute("application/x-www-form-urlencoded")
+	route.RequestParams[0].ParamSchema.Properties["Remember"] = &models.APISchema{Type: "boolean", FormTag: "remember", Default: true}
+	route.RequestParams = append(route.RequestParams, models.RequestParamInfo{
+		ParamName:   "page_size",
+		ParamType:   "query",
+		ParamSchema: &models.APISchema{Type: "integer", Default: int64(10)},
+	})
+
+	yapi := e.convertInterfaces([]models.RouteInfo{route}, nil)[0]
+	if len(yapi.ReqQuery) != 1 || yapi.ReqQuery[0].Value != "10" {
+		t.Errorf("查询参数的 value 应为默认值 10，实际为 %+v", yapi.ReqQuery)
+	}
+	for _, param := range yapi.ReqBodyForm {
+		want := ""
+		if param.Name == "remember" {
+			want = "true"
+		}
+		if param.Value != want {
+			t.Errorf("表单字段 %s 的 value 应为 %q，实际为 %q", param.Name, want, param.Value)
+		}
+	}
+}
+
+func TestYAPIArrayExamples(t *testing.T) {
+	e := NewYAPIExporter("fixture", "", "")
+	user := &models.APISchema{
+		Type: "User",
+		Properties: map[string]*models.APISchema{
+			"Name": {Type: "string", JSONTag: "name"},
+		},
+	}
+	for name, tc := range map[string]struct {
+		schema *models.APISchema
+		want   string
+	}{
+		"[]string": {&models.APISchema{Type: "array", Items: &models.APISchema{Type: "string"}}, `["string","string"]`},
+		"[]int":    {&models.APISchema{Type: "array", Items: &models.APISchema{Type: "integer"}}, `[0,0]`},
+		"[]User":   {&models.APISchema{Type: "array", Items: user}, `[{"name":"string"}]`},
+	} {
+		data, err := json.Marshal(e.convertAPISchemaToJSONSchema(tc.schema))
+		if err != nil {
+			t.Fatalf("%s 的示例无法序列化: %v", name, err)
+		}
+		if string(data) != tc.want {
+			t.Errorf("%s 的示例应为 %s，实际为 %s", name, tc.want, data)
+		}
+	}
+}
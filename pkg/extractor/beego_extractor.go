@@ -0,0 +1,271 @@
+// 文件位置: pkg/extractor/beego_extractor.go
+package extractor
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"log"
+	"strings"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+	"github.com/YogeLiu/api-tool/pkg/parser"
+	"golang.org/x/tools/go/packages"
+)
+
+// beegoPackagePaths Beego框架的包路径（v1 与 v2）
+var beegoPackagePaths = map[string]bool{
+	"github.com/astaxie/beego":                true,
+	"github.com/beego/beego/server/web":       true,
+	"github.com/beego/beego/v2/server/web":    true,
+	"github.com/beego/beego/v2/adapter":       true,
+	"github.com/beego/beego/v2/adapter/beego": true,
+}
+
+// beegoRestfulMethods 未指定映射字符串时，控制器上按方法名对应的HTTP方法
+var beegoRestfulMethods = []string{"Get", "Post", "Put", "Delete", "Patch", "Head", "Options"}
+
+// BeegoExtractor 实现了针对Beego框架的路由提取逻辑。
+// Beego通过包级函数（beego.Router / beego.NSNamespace 等）注册路由，
+// 因此路由由 FindRouteRegistrations 直接解析，而不是通过路由器对象追踪。
+type BeegoExtractor struct {
+	project *parser.Project
+}
+
+// GetFrameworkName 返回框架名称
+func (b *BeegoExtractor) GetFrameworkName() string {
+	return "beego"
+}
+
+// InitializeAnalysis 初始化分析器
+func (b *BeegoExtractor) InitializeAnalysis() error {
+	return nil
+}
+
+// FindRootRouters Beego没有需要追踪的根路由器对象
+func (b *BeegoExtractor) FindRootRouters(pkgs []*packages.Package) []types.Object {
+	return nil
+}
+
+// FindRouterGroupFunctions Beego的分组通过命名空间表达，不需要路由分组函数索引
+func (b *BeegoExtractor) FindRouterGroupFunctions(pkgs []*packages.Package) map[string]*models.RouterGroupFunction {
+	return make(map[string]*models.RouterGroupFunction)
+}
+
+// IsRouterParameter Beego没有路由器参数
+func (b *BeegoExtractor) IsRouterParameter(param *ast.Field, typeInfo *types.Info) bool {
+	return false
+}
+
+// IsRouteGroupCall Beego的分组由 FindRouteRegistrations 处理
+func (b *BeegoExtractor) IsRouteGroupCall(callExpr *ast.CallExpr, typeInfo *types.Info) (bool, string) {
+	return false, ""
+}
+
+// IsHTTPMethodCall Beego的路由注册由 FindRouteRegistrations 处理
+func (b *BeegoExtractor) IsHTTPMethodCall(callExpr *ast.CallExpr, typeInfo *types.Info) (bool, string, string) {
+	return false, "", ""
+}
+
+// FindRouteRegistrations 查找 beego.Router 注册以及 NewNamespace/NSNamespace/NSRouter 命名空间树
+func (b *BeegoExtractor) FindRouteRegistrations(pkgs []*packages.Package) []*models.RouteRegistration {
+	var registrations []*models.RouteRegistration
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(node ast.Node) bool {
+				callExpr, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				switch b.beegoFuncName(callExpr, pkg.TypesInfo) {
+				case "Router":
+					// beego.Router("/path", &Controller{}, "get:Method")
+					registrations = append(registrations, b.parseRouterCall(callExpr, "", pkg)...)
+					return false
+				case "NewNamespace":
+					// beego.NewNamespace("/v1", beego.NSRouter(...), beego.NSNamespace(...))
+					registrations = append(registrations, b.parseNamespaceCall(callExpr, "", pkg)...)
+					return false
+				}
+				return true
+			})
+		}
+	}
+
+	log.Printf("[DEBUG] BeegoExtractor.FindRouteRegistrations: 找到 %d 个路由注册\n", len(registrations))
+	return registrations
+}
+
+// parseNamespaceCall 解析命名空间调用，递归处理 NSNamespace 和 NSRouter 子节点
+func (b *BeegoExtractor) parseNamespaceCall(callExpr *ast.CallExpr, parentPath string, pkg *packages.Package) []*models.RouteRegistration {
+	var registrations []*models.RouteRegistration
+
+	if len(callExpr.Args) == 0 {
+		return registrations
+	}
+
+	prefix := joinBeegoPath(parentPath, extractPathFromExpression(callExpr.Args[0], pkg.TypesInfo))
+
+	for _, arg := range callExpr.Args[1:] {
+		childCall, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		switch b.beegoFuncName(childCall, pkg.TypesInfo) {
+		case "NSRouter":
+			registrations = append(registrations, b.parseRouterCall(childCall, prefix, pkg)...)
+		case "NSNamespace":
+			registrations = append(registrations, b.parseNamespaceCall(childCall, prefix, pkg)...)
+		}
+	}
+
+	return registrations
+}
+
+// parseRouterCall 解析 Router/NSRouter 调用，根据映射字符串生成路由
+func (b *BeegoExtractor) parseRouterCall(callExpr *ast.CallExpr, parentPath string, pkg *packages.Package) []*models.RouteRegistration {
+	var registrations []*models.RouteRegistration
+
+	if len(callExpr.Args) < 2 {
+		return registrations
+	}
+
+	fullPath := joinBeegoPath(parentPath, extractPathFromExpression(callExpr.Args[0], pkg.TypesInfo))
+
+	controller := b.controllerType(callExpr.Args[1], pkg.TypesInfo)
+	if controller == nil {
+		log.Printf("[DEBUG] BeegoExtractor: 无法解析控制器类型: %s\n", fullPath)
+		return registrations
+	}
+
+	// 解析 "get:GetMethod;post,put:SaveMethod" 形式的映射
+	methodMapping := make(map[string]string) // HTTP方法 -> 控制器方法名
+	var mappingOrder []string
+	if len(callExpr.Args) >= 3 {
+		mapping := b.stringValue(callExpr.Args[2], pkg.TypesInfo)
+		for _, item := range strings.Split(mapping, ";") {
+			parts := strings.SplitN(strings.TrimSpace(item), ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			for _, httpMethod := range strings.Split(parts[0], ",") {
+				httpMethod = strings.ToUpper(strings.TrimSpace(httpMethod))
+				if httpMethod == "*" {
+					httpMethod = "ANY"
+				}
+				if _, exists := methodMapping[httpMethod]; !exists {
+					mappingOrder = append(mappingOrder, httpMethod)
+				}
+				methodMapping[httpMethod] = strings.TrimSpace(parts[1])
+			}
+		}
+	} else {
+		// 没有映射字符串时，按RESTful约定使用控制器上定义的 Get/Post 等方法
+		for i := 0; i < controller.NumMethods(); i++ {
+			methodName := controller.Method(i).Name()
+			for _, restful := range beegoRestfulMethods {
+				if methodName == restful {
+					httpMethod := strings.ToUpper(restful)
+					mappingOrder = append(mappingOrder, httpMethod)
+					methodMapping[httpMethod] = methodName
+				}
+			}
+		}
+	}
+
+	for _, httpMethod := range mappingOrder {
+		methodName := methodMapping[httpMethod]
+		funcDecl, declPkg := b.findControllerMethod(controller, methodName)
+		if funcDecl == nil {
+			log.Printf("[DEBUG] BeegoExtractor: 未找到控制器方法 %s.%s\n", controller.Obj().Name(), methodName)
+			continue
+		}
+
+		registrations = append(registrations, &models.RouteRegistration{
+			Method:      httpMethod,
+			Path:        fullPath,
+			HandlerName: controller.Obj().Name() + "." + methodName,
+			FuncDecl:    funcDecl,
+			Package:     declPkg,
+		})
+	}
+
+	return registrations
+}
+
+// beegoFuncName 如果调用为Beego包的函数调用，返回函数名
+func (b *BeegoExtractor) beegoFuncName(callExpr *ast.CallExpr, typeInfo *types.Info) string {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if pkgName, ok := typeInfo.ObjectOf(ident).(*types.PkgName); ok {
+		if beegoPackagePaths[pkgName.Imported().Path()] {
+			return selExpr.Sel.Name
+		}
+	}
+	return ""
+}
+
+// controllerType 从 &Controller{} 参数中解析控制器的命名类型
+func (b *BeegoExtractor) controllerType(expr ast.Expr, typeInfo *types.Info) *types.Named {
+	typ := typeInfo.TypeOf(expr)
+	if typ == nil {
+		return nil
+	}
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, _ := typ.(*types.Named)
+	return named
+}
+
+// findControllerMethod 在项目中查找控制器方法的声明
+func (b *BeegoExtractor) findControllerMethod(controller *types.Named, methodName string) (*ast.FuncDecl, *packages.Package) {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(controller), true, controller.Obj().Pkg(), methodName)
+	method, ok := obj.(*types.Func)
+	if !ok || method.Pkg() == nil {
+		return nil, nil
+	}
+
+	pkg := b.project.GetPackage(method.Pkg().Path())
+	if pkg == nil {
+		return nil, nil
+	}
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv != nil {
+				if pkg.TypesInfo.ObjectOf(funcDecl.Name) == method {
+					return funcDecl, pkg
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// stringValue 获取字符串字面量或常量表达式的值
+func (b *BeegoExtractor) stringValue(expr ast.Expr, typeInfo *types.Info) string {
+	if tv, ok := typeInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value)
+	}
+	return ""
+}
+
+// joinBeegoPath 拼接命名空间前缀与路由路径
+func joinBeegoPath(prefix, segment string) string {
+	if prefix == "" {
+		return segment
+	}
+	if segment == "" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(segment, "/")
+}
@@ -4,15 +4,21 @@ package extractor
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
+	"github.com/YogeLiu/api-tool/pkg/console"
 	"github.com/YogeLiu/api-tool/pkg/parser"
 )
 
+// FrameworkAuto 根据项目的导入自动选择框架
+const FrameworkAuto = "auto"
+
 // DetectFramework 自动检测项目使用的Web框架
 func DetectFramework(project *parser.Project) (string, error) {
 	ginFound := false
 	irisFound := false
+	beegoFound := false
 
 	// 检查项目的导入
 	for _, pkg := range project.Packages {
@@ -26,12 +32,22 @@ func DetectFramework(project *parser.Project) (string, error) {
 				if strings.Contains(importPath, "github.com/kataras/iris") {
 					irisFound = true
 				}
+				if beegoPackagePaths[importPath] {
+					beegoFound = true
+				}
 			}
 		}
 	}
 
-	if ginFound && irisFound {
-		return "", fmt.Errorf("检测到多个框架，请手动指定")
+	var found []string
+	for name, f := range map[string]bool{"gin": ginFound, "iris": irisFound, "beego": beegoFound} {
+		if f {
+			found = append(found, name)
+		}
+	}
+	if len(found) > 1 {
+		sort.Strings(found)
+		return "", fmt.Errorf("检测到多个框架 (%s)，请通过 -framework 手动指定", strings.Join(found, ", "))
 	}
 
 	if ginFound {
@@ -42,12 +58,16 @@ func DetectFramework(project *parser.Project) (string, error) {
 		return "iris", nil
 	}
 
-	return "", fmt.Errorf("未检测到支持的Web框架")
+	if beegoFound {
+		return "beego", nil
+	}
+
+	return "", fmt.Errorf("未检测到支持的Web框架 (gin, iris, beego)，请检查项目路径或通过 -framework 手动指定")
 }
 
 // NewGinExtractor 创建Gin框架提取器
 func NewGinExtractor(project *parser.Project) Extractor {
-	log.Printf("[DEBUG] 🔥 NewGinExtractor 被调用，创建GinExtractor实例 🔥\n")
+	log.Printf(console.Text("[DEBUG] 🔥 NewGinExtractor 被调用，创建GinExtractor实例 🔥\n"))
 	return &GinExtractor{
 		project: project,
 	}
@@ -60,6 +80,13 @@ func NewIrisExtractor(project *parser.Project) Extractor {
 	}
 }
 
+// NewBeegoExtractor 创建Beego框架提取器
+func NewBeegoExtractor(project *parser.Project) Extractor {
+	return &BeegoExtractor{
+		project: project,
+	}
+}
+
 // CreateExtractor 根据框架名称创建对应的提取器
 func CreateExtractor(framework string, project *parser.Project) (Extractor, error) {
 	switch strings.ToLower(framework) {
@@ -67,6 +94,8 @@ func CreateExtractor(framework string, project *parser.Project) (Extractor, erro
 		return NewGinExtractor(project), nil
 	case "iris":
 		return NewIrisExtractor(project), nil
+	case "beego":
+		return NewBeegoExtractor(project), nil
 	default:
 		return nil, fmt.Errorf("不支持的框架: %s", framework)
 	}
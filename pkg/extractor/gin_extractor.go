@@ -3,10 +3,10 @@ package extractor
 
 import (
 	"go/ast"
-	"go/token"
 	"go/types"
 	"strings"
 
+	"github.com/YogeLiu/api-tool/helper"
 	"github.com/YogeLiu/api-tool/pkg/models"
 	"github.com/YogeLiu/api-tool/pkg/parser"
 	"golang.org/x/tools/go/packages"
@@ -36,10 +36,18 @@ func (g *GinExtractor) FindRootRouters(pkgs []*packages.Package) []types.Object
 		for _, file := range pkg.Syntax {
 			ast.Inspect(file, func(node ast.Node) bool {
 				if assign, ok := node.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 {
-					if lhs, ok := assign.Lhs[0].(*ast.Ident); ok {
+					// 接收者可以是变量 r := gin.New()，也可以是结构体字段 s.router = gin.New()
+					var lhs *ast.Ident
+					switch target := assign.Lhs[0].(type) {
+					case *ast.Ident:
+						lhs = target
+					case *ast.SelectorExpr:
+						lhs = target.Sel
+					}
+					if lhs != nil {
 						if callExpr, ok := assign.Rhs[0].(*ast.CallExpr); ok {
 							if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-								if ident, ok := selExpr.X.(*ast.Ident); ok && ident.Name == "gin" {
+								if ident, ok := selExpr.X.(*ast.Ident); ok && g.isGinPackageIdent(ident, pkg.TypesInfo) {
 									if selExpr.Sel.Name == "Default" || selExpr.Sel.Name == "New" {
 										if obj := pkg.TypesInfo.ObjectOf(lhs); obj != nil {
 											routers = append(routers, obj)
@@ -58,6 +66,16 @@ func (g *GinExtractor) FindRootRouters(pkgs []*packages.Package) []types.Object
 	return routers
 }
 
+// isGinPackageIdent 检查标识符是否引用gin包（支持别名导入）
+func (g *GinExtractor) isGinPackageIdent(ident *ast.Ident, typeInfo *types.Info) bool {
+	if typeInfo != nil {
+		if pkgName, ok := typeInfo.ObjectOf(ident).(*types.PkgName); ok {
+			return helper.IsGinPackagePath(pkgName.Imported().Path())
+		}
+	}
+	return ident.Name == "gin"
+}
+
 // IsGinEngine 检查类型是否为gin.Engine
 func (g *GinExtractor) IsGinEngine(typ types.Type) bool {
 	// 处理指针类型
@@ -68,7 +86,7 @@ func (g *GinExtractor) IsGinEngine(typ types.Type) bool {
 	if named, ok := typ.(*types.Named); ok {
 		obj := named.Obj()
 		if obj != nil && obj.Pkg() != nil {
-			return obj.Pkg().Path() == "github.com/gin-gonic/gin" && obj.Name() == "Engine"
+			return helper.IsGinPackagePath(obj.Pkg().Path()) && obj.Name() == "Engine"
 		}
 	}
 	return false
@@ -84,12 +102,28 @@ func (g *GinExtractor) IsGinRouterGroup(typ types.Type) bool {
 	if named, ok := typ.(*types.Named); ok {
 		obj := named.Obj()
 		if obj != nil && obj.Pkg() != nil {
-			return obj.Pkg().Path() == "github.com/gin-gonic/gin" && obj.Name() == "RouterGroup"
+			return helper.IsGinPackagePath(obj.Pkg().Path()) && obj.Name() == "RouterGroup"
+		}
+	}
+	return false
+}
+
+// IsGinRouterInterface 检查类型是否为gin的路由接口 gin.IRouter 或 gin.IRoutes
+func (g *GinExtractor) IsGinRouterInterface(typ types.Type) bool {
+	if named, ok := typ.(*types.Named); ok {
+		obj := named.Obj()
+		if obj != nil && obj.Pkg() != nil {
+			return helper.IsGinPackagePath(obj.Pkg().Path()) && (obj.Name() == "IRouter" || obj.Name() == "IRoutes")
 		}
 	}
 	return false
 }
 
+// isGinRouterType 检查类型是否可以注册路由：*gin.Engine、*gin.RouterGroup 或路由接口
+func (g *GinExtractor) isGinRouterType(typ types.Type) bool {
+	return g.IsGinEngine(typ) || g.IsGinRouterGroup(typ) || g.IsGinRouterInterface(typ)
+}
+
 // IsRouterParameter 检查函数参数是否为路由器类型
 func (g *GinExtractor) IsRouterParameter(param *ast.Field, typeInfo *types.Info) bool {
 	if param.Type == nil {
@@ -101,8 +135,8 @@ func (g *GinExtractor) IsRouterParameter(param *ast.Field, typeInfo *types.Info)
 		return false
 	}
 
-	// 检查是否为 *gin.Engine 或 *gin.RouterGroup
-	return g.IsGinEngine(typ) || g.IsGinRouterGroup(typ)
+	// 检查是否为 *gin.Engine、*gin.RouterGroup 或 gin.IRouter 等路由接口
+	return g.isGinRouterType(typ)
 }
 
 // FindRouterGroupFunctions 查找所有接受路由器参数的函数（路由分组函数）
@@ -115,14 +149,15 @@ func (g *GinExtractor) FindRouterGroupFunctions(pkgs []*packages.Package) map[st
 				if funcDecl, ok := decl.(*ast.FuncDecl); ok {
 					if funcDecl.Type.Params != nil {
 						// 检查每个参数是否为路由器类型
-						for _, param := range funcDecl.Type.Params.List {
+						for idx, param := range funcDecl.Type.Params.List {
 							if g.IsRouterParameter(param, pkg.TypesInfo) {
 								uniqueKey := pkg.PkgPath + "+" + funcDecl.Name.Name
 								routerGroupFunctions[uniqueKey] = &models.RouterGroupFunction{
-									PackagePath:  pkg.PkgPath,
-									FunctionName: funcDecl.Name.Name,
-									FuncDecl:     funcDecl,
-									Package:      pkg,
+									PackagePath:    pkg.PkgPath,
+									FunctionName:   funcDecl.Name.Name,
+									FuncDecl:       funcDecl,
+									Package:        pkg,
+									RouterParamIdx: idx,
 								}
 								break
 							}
@@ -142,13 +177,11 @@ func (g *GinExtractor) IsRouteGroupCall(callExpr *ast.CallExpr, typeInfo *types.
 		if selExpr.Sel.Name == "Group" {
 			// 检查调用者是否为gin相关类型
 			if typ := typeInfo.TypeOf(selExpr.X); typ != nil {
-				if g.IsGinEngine(typ) || g.IsGinRouterGroup(typ) {
+				if g.isGinRouterType(typ) {
 					// 提取路径参数
 					if len(callExpr.Args) > 0 {
-						if lit, ok := callExpr.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-							pathSegment = strings.Trim(lit.Value, `"`)
-							return true, pathSegment
-						}
+						pathSegment = extractPathFromExpression(callExpr.Args[0], typeInfo)
+						return true, pathSegment
 					}
 				}
 			}
@@ -165,18 +198,38 @@ func (g *GinExtractor) IsHTTPMethodCall(callExpr *ast.CallExpr, typeInfo *types.
 			if selExpr.Sel.Name == method {
 				// 检查调用者是否为gin相关类型
 				if typ := typeInfo.TypeOf(selExpr.X); typ != nil {
-					if g.IsGinEngine(typ) || g.IsGinRouterGroup(typ) {
+					if g.isGinRouterType(typ) {
 						// 提取路径参数
 						if len(callExpr.Args) > 0 {
-							if lit, ok := callExpr.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-								pathSegment = strings.Trim(lit.Value, `"`)
-								return true, method, pathSegment
-							}
+							pathSegment = extractPathFromExpression(callExpr.Args[0], typeInfo)
+							return true, method, pathSegment
 						}
 					}
 				}
 			}
 		}
+
+		// r.Handle("GET", "/path", handler)，方法为常量时按普通HTTP方法处理
+		if selExpr.Sel.Name == "Handle" && len(callExpr.Args) >= 3 && g.isGinRouterExpr(selExpr.X, typeInfo) {
+			if method := constantString(callExpr.Args[0], typeInfo); method != "" {
+				return true, strings.ToUpper(method), extractPathFromExpression(callExpr.Args[1], typeInfo)
+			}
+		}
 	}
 	return false, "", ""
 }
+
+// ExtractRouteTable 解析循环中按路由表注册的路由：r.Handle(rt.Method, rt.Path, rt.Handler)
+func (g *GinExtractor) ExtractRouteTable(callExpr *ast.CallExpr, typeInfo *types.Info) []*models.RouteTableEntry {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Handle" || len(callExpr.Args) < 3 || !g.isGinRouterExpr(selExpr.X, typeInfo) {
+		return nil
+	}
+	return expandRouteTable(g.project, callExpr.Args[0], callExpr.Args[1], callExpr.Args[len(callExpr.Args)-1], typeInfo)
+}
+
+// isGinRouterExpr 检查表达式是否为gin.Engine或gin.RouterGroup
+func (g *GinExtractor) isGinRouterExpr(expr ast.Expr, typeInfo *types.Info) bool {
+	typ := typeInfo.TypeOf(expr)
+	return typ != nil && g.isGinRouterType(typ)
+}
@@ -40,3 +40,17 @@ type Extractor interface {
 	// GetFrameworkName 返回当前提取器支持的框架名称
 	GetFrameworkName() string
 }
+
+// RouteRegistrationExtractor 是可选接口，适用于通过包级函数注册路由、
+// 无法通过路由器对象追踪的框架（如Beego）。分析器会在实现了该接口时直接收集路由注册。
+type RouteRegistrationExtractor interface {
+	// FindRouteRegistrations 查找所有直接注册的路由
+	FindRouteRegistrations(pkgs []*packages.Package) []*models.RouteRegistration
+}
+
+// RouteTableExtractor 是可选接口，用于解析在循环中按路由表注册的路由，如
+// for _, rt := range routes { r.Handle(rt.Method, rt.Path, rt.Handler) }
+type RouteTableExtractor interface {
+	// ExtractRouteTable 如果调用是以循环变量字段为参数的路由注册，返回路由表中的所有路由
+	ExtractRouteTable(callExpr *ast.CallExpr, typeInfo *types.Info) []*models.RouteTableEntry
+}
@@ -22,9 +22,9 @@ func (i *IrisExtractor) GetFrameworkName() string {
 	return "iris"
 }
 
-// InitializeAnalysis 初始化分析器（Iris提取器暂未实现预扫描）
+// InitializeAnalysis 初始化分析器
 func (i *IrisExtractor) InitializeAnalysis() error {
-	// Iris提取器暂未实现响应函数预扫描功能
+	// 响应封装函数（如 RespondOK(ctx, data) 内部调用 ctx.JSON）由响应解析引擎在预处理阶段统一识别，与gin共用
 	return nil
 }
 
@@ -179,7 +179,7 @@ func (i *IrisExtractor) IsRouteGroupCall(callExpr *ast.CallExpr, typeInfo *types
 			if typ := typeInfo.TypeOf(selExpr.X); typ != nil {
 				if i.IsIrisParty(typ) {
 					if len(callExpr.Args) > 0 {
-						path := i.extractPathFromExpression(callExpr.Args[0], typeInfo)
+						path := extractPathFromExpression(callExpr.Args[0], typeInfo)
 						return true, path
 					}
 				}
@@ -255,7 +255,7 @@ func (i *IrisExtractor) IsHTTPMethodCall(callExpr *ast.CallExpr, typeInfo *types
 				// 提取路径参数
 				var path string
 				if len(callExpr.Args) > 0 {
-					path = i.extractPathFromExpression(callExpr.Args[0], typeInfo)
+					path = extractPathFromExpression(callExpr.Args[0], typeInfo)
 				}
 
 				fmt.Printf("[DEBUG] IsHTTPMethodCall (Iris): 找到HTTP方法调用 %s %s\n", httpMethod, path)
@@ -423,137 +423,3 @@ func (i *IrisExtractor) IsRouterParameter(param *ast.Field, typeInfo *types.Info
 	}
 	return false
 }
-
-// extractPathFromExpression 从表达式中提取路径，支持多种表达式类型
-func (i *IrisExtractor) extractPathFromExpression(expr ast.Expr, typeInfo *types.Info) string {
-	switch e := expr.(type) {
-	case *ast.BasicLit:
-		// 字符串字面量: "/user"
-		return strings.Trim(e.Value, "\"")
-
-	case *ast.CallExpr:
-		// 函数调用: fmt.Sprintf("/%s", enum.AvoidInsuranceFlag)
-		return i.extractPathFromFunctionCall(e, typeInfo)
-
-	case *ast.Ident:
-		// 变量引用: pathVar
-		return i.extractPathFromIdentifier(e, typeInfo)
-
-	case *ast.SelectorExpr:
-		// 字段访问: config.BasePath
-		return i.extractPathFromSelector(e, typeInfo)
-
-	case *ast.BinaryExpr:
-		// 二元表达式: "/api" + "/v1"
-		return i.extractPathFromBinaryExpr(e, typeInfo)
-
-	default:
-		// 其他未处理的表达式类型，返回占位符
-		fmt.Printf("[DEBUG] extractPathFromExpression: 未处理的表达式类型 %T\n", expr)
-		return "/dynamic_path"
-	}
-}
-
-// extractPathFromFunctionCall 从函数调用中提取路径
-func (i *IrisExtractor) extractPathFromFunctionCall(callExpr *ast.CallExpr, typeInfo *types.Info) string {
-	// 检查是否为 fmt.Sprintf 调用
-	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			if ident.Name == "fmt" && selExpr.Sel.Name == "Sprintf" {
-				// 处理 fmt.Sprintf 调用
-				return i.extractPathFromSprintfCall(callExpr, typeInfo)
-			}
-		}
-	}
-
-	// 其他函数调用，尝试从类型信息获取
-	if typ := typeInfo.TypeOf(callExpr); typ != nil {
-		if basic, ok := typ.(*types.Basic); ok && basic.Kind() == types.String {
-			return "/dynamic_path"
-		}
-	}
-
-	return "/function_call"
-}
-
-// extractPathFromSprintfCall 从 fmt.Sprintf 调用中提取路径模式
-func (i *IrisExtractor) extractPathFromSprintfCall(callExpr *ast.CallExpr, typeInfo *types.Info) string {
-	if len(callExpr.Args) == 0 {
-		return "/sprintf_empty"
-	}
-
-	// 获取格式字符串（第一个参数）
-	if formatExpr, ok := callExpr.Args[0].(*ast.BasicLit); ok {
-		formatStr := strings.Trim(formatExpr.Value, "\"")
-
-		// 如果有更多参数，尝试进行简单的模式识别
-		if len(callExpr.Args) > 1 {
-			// 对于简单情况，我们可以尝试识别一些常见模式
-			// 例如: fmt.Sprintf("/%s", enum.Value) -> "/{param}"
-			result := formatStr
-			argCount := len(callExpr.Args) - 1 // 减去格式字符串
-
-			// 简单替换 %s, %d 等为占位符
-			result = strings.ReplaceAll(result, "%s", "{param}")
-			result = strings.ReplaceAll(result, "%d", "{id}")
-			result = strings.ReplaceAll(result, "%v", "{value}")
-
-			fmt.Printf("[DEBUG] extractPathFromSprintfCall: 格式='%s', 参数数量=%d, 结果='%s'\n",
-				formatStr, argCount, result)
-
-			return result
-		}
-
-		return formatStr
-	}
-
-	return "/sprintf_complex"
-}
-
-// extractPathFromIdentifier 从标识符中提取路径
-func (i *IrisExtractor) extractPathFromIdentifier(ident *ast.Ident, typeInfo *types.Info) string {
-	// 尝试从类型信息获取值
-	if obj := typeInfo.ObjectOf(ident); obj != nil {
-		if konst, ok := obj.(*types.Const); ok {
-			// 常量值
-			if konst.Val() != nil {
-				if val := konst.Val().String(); val != "" {
-					return strings.Trim(val, "\"")
-				}
-			}
-		}
-
-		// 变量名作为路径标识
-		return fmt.Sprintf("/{%s}", ident.Name)
-	}
-
-	return fmt.Sprintf("/{%s}", ident.Name)
-}
-
-// extractPathFromSelector 从选择器表达式中提取路径
-func (i *IrisExtractor) extractPathFromSelector(selExpr *ast.SelectorExpr, typeInfo *types.Info) string {
-	if ident, ok := selExpr.X.(*ast.Ident); ok {
-		// 例如: config.BasePath -> "{config.BasePath}"
-		return fmt.Sprintf("/{%s.%s}", ident.Name, selExpr.Sel.Name)
-	}
-
-	return "/selector_path"
-}
-
-// extractPathFromBinaryExpr 从二元表达式中提取路径
-func (i *IrisExtractor) extractPathFromBinaryExpr(binExpr *ast.BinaryExpr, typeInfo *types.Info) string {
-	if binExpr.Op.String() == "+" {
-		// 字符串连接
-		left := i.extractPathFromExpression(binExpr.X, typeInfo)
-		right := i.extractPathFromExpression(binExpr.Y, typeInfo)
-
-		// 如果两边都是简单字符串，直接连接
-		if !strings.Contains(left, "{") && !strings.Contains(right, "{") {
-			return left + right
-		}
-
-		return fmt.Sprintf("%s%s", left, right)
-	}
-
-	return "/binary_expr"
-}
@@ -0,0 +1,165 @@
+// 文件位置: pkg/extractor/path_expr.go
+package extractor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"log"
+	"strings"
+)
+
+// extractPathFromExpression 从路由注册的路径参数中提取路径，供各框架提取器共用。
+// 支持字符串字面量、常量、fmt.Sprintf、字符串拼接、变量与字段访问等表达式。
+func extractPathFromExpression(expr ast.Expr, typeInfo *types.Info) string {
+	// 编译期可求值的常量表达式（字面量、常量、常量拼接）直接使用其值
+	if typeInfo != nil {
+		if tv, ok := typeInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value)
+		}
+	}
+
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		// 字符串字面量: "/user"
+		return strings.Trim(e.Value, "\"`")
+
+	case *ast.ParenExpr:
+		return extractPathFromExpression(e.X, typeInfo)
+
+	case *ast.CallExpr:
+		// 函数调用: fmt.Sprintf("/%s", enum.AvoidInsuranceFlag)
+		return extractPathFromFunctionCall(e, typeInfo)
+
+	case *ast.Ident:
+		// 变量引用: pathVar
+		return extractPathFromIdentifier(e, typeInfo)
+
+	case *ast.SelectorExpr:
+		// 字段访问: config.BasePath
+		return extractPathFromSelector(e)
+
+	case *ast.BinaryExpr:
+		// 二元表达式: "/api" + "/v1"
+		return extractPathFromBinaryExpr(e, typeInfo)
+
+	default:
+		// 其他未处理的表达式类型，返回占位符
+		log.Printf("[DEBUG] extractPathFromExpression: 未处理的表达式类型 %T\n", expr)
+		return "/dynamic_path"
+	}
+}
+
+// extractPathFromFunctionCall 从函数调用中提取路径
+func extractPathFromFunctionCall(callExpr *ast.CallExpr, typeInfo *types.Info) string {
+	// 检查是否为 fmt.Sprintf 调用
+	if isFmtSprintfCall(callExpr, typeInfo) {
+		return extractPathFromSprintfCall(callExpr, typeInfo)
+	}
+
+	// 其他函数调用，尝试从类型信息获取
+	if typeInfo != nil {
+		if typ := typeInfo.TypeOf(callExpr); typ != nil {
+			if basic, ok := typ.Underlying().(*types.Basic); ok && basic.Kind() == types.String {
+				return "/dynamic_path"
+			}
+		}
+	}
+
+	return "/function_call"
+}
+
+// isFmtSprintfCall 检查是否为 fmt.Sprintf 调用（支持别名导入）
+func isFmtSprintfCall(callExpr *ast.CallExpr, typeInfo *types.Info) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Sprintf" {
+		return false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if typeInfo != nil {
+		if pkgName, ok := typeInfo.ObjectOf(ident).(*types.PkgName); ok {
+			return pkgName.Imported().Path() == "fmt"
+		}
+	}
+	return ident.Name == "fmt"
+}
+
+// extractPathFromSprintfCall 从 fmt.Sprintf 调用中提取路径模式
+func extractPathFromSprintfCall(callExpr *ast.CallExpr, typeInfo *types.Info) string {
+	if len(callExpr.Args) == 0 {
+		return "/sprintf_empty"
+	}
+
+	// 获取格式字符串（第一个参数，支持字面量和常量）
+	formatStr := ""
+	if typeInfo != nil {
+		if tv, ok := typeInfo.Types[callExpr.Args[0]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			formatStr = constant.StringVal(tv.Value)
+		}
+	}
+	if formatStr == "" {
+		formatExpr, ok := callExpr.Args[0].(*ast.BasicLit)
+		if !ok {
+			return "/sprintf_complex"
+		}
+		formatStr = strings.Trim(formatExpr.Value, "\"`")
+	}
+
+	// 如果有更多参数，尝试进行简单的模式识别
+	if len(callExpr.Args) > 1 {
+		// 例如: fmt.Sprintf("/%s", enum.Value) -> "/{param}"
+		result := formatStr
+		result = strings.ReplaceAll(result, "%s", "{param}")
+		result = strings.ReplaceAll(result, "%d", "{id}")
+		result = strings.ReplaceAll(result, "%v", "{value}")
+
+		log.Printf("[DEBUG] extractPathFromSprintfCall: 格式='%s', 参数数量=%d, 结果='%s'\n",
+			formatStr, len(callExpr.Args)-1, result)
+
+		return result
+	}
+
+	return formatStr
+}
+
+// extractPathFromIdentifier 从标识符中提取路径
+func extractPathFromIdentifier(ident *ast.Ident, typeInfo *types.Info) string {
+	// 尝试从类型信息获取常量值
+	if typeInfo != nil {
+		if konst, ok := typeInfo.ObjectOf(ident).(*types.Const); ok && konst.Val() != nil {
+			if konst.Val().Kind() == constant.String {
+				return constant.StringVal(konst.Val())
+			}
+		}
+	}
+
+	// 变量名作为路径标识
+	return fmt.Sprintf("/{%s}", ident.Name)
+}
+
+// extractPathFromSelector 从选择器表达式中提取路径
+func extractPathFromSelector(selExpr *ast.SelectorExpr) string {
+	if ident, ok := selExpr.X.(*ast.Ident); ok {
+		// 例如: config.BasePath -> "{config.BasePath}"
+		return fmt.Sprintf("/{%s.%s}", ident.Name, selExpr.Sel.Name)
+	}
+
+	return "/selector_path"
+}
+
+// extractPathFromBinaryExpr 从二元表达式中提取路径
+func extractPathFromBinaryExpr(binExpr *ast.BinaryExpr, typeInfo *types.Info) string {
+	if binExpr.Op == token.ADD {
+		// 字符串连接
+		left := extractPathFromExpression(binExpr.X, typeInfo)
+		right := extractPathFromExpression(binExpr.Y, typeInfo)
+		return left + right
+	}
+
+	return "/binary_expr"
+}
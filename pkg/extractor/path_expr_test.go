@@ -0,0 +1,111 @@
+package extractor
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// 路径解析输出调试日志，测试中丢弃
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// pathExprSource 路由路径表达式的示例，paths 中每个元素对应一种写法
+const pathExprSource = `package routes
+
+import (
+	"fmt"
+	f "fmt"
+)
+
+const base = "/api"
+
+const format = "/orders/%s"
+
+var pathVar = "/dynamic"
+
+var cfg struct{ BasePath string }
+
+var name string
+
+var id int
+
+var paths = []string{
+	"/user",
+	base + "/v1",
+	(base),
+	fmt.Sprintf("/%s", name),
+	fmt.Sprintf("/users/%d/%v", id, name),
+	fmt.Sprintf(format, name),
+	f.Sprintf("/alias/%s", name),
+	pathVar,
+	cfg.BasePath,
+	"/prefix" + fmt.Sprintf("/%s", name),
+}
+`
+
+// parsePathExprs 解析并类型检查示例源码，返回 paths 中的表达式和类型信息
+func parsePathExprs(t *testing.T) ([]ast.Expr, *types.Info) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes.go", pathExprSource, 0)
+	if err != nil {
+		t.Fatalf("解析示例源码失败: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("routes", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("类型检查示例源码失败: %v", err)
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			if valueSpec.Names[0].Name == "paths" {
+				return valueSpec.Values[0].(*ast.CompositeLit).Elts, info
+			}
+		}
+	}
+	t.Fatal("示例源码中没有 paths")
+	return nil, nil
+}
+
+func TestExtractPathFromExpression(t *testing.T) {
+	exprs, info := parsePathExprs(t)
+	want := []string{
+		"/user",
+		"/api/v1",
+		"/api",
+		"/{param}",
+		"/users/{id}/{value}",
+		"/orders/{param}",
+		"/alias/{param}",
+		"/{pathVar}",
+		"/{cfg.BasePath}",
+		"/prefix/{param}",
+	}
+	if len(exprs) != len(want) {
+		t.Fatalf("示例表达式数量 %d 与期望数量 %d 不一致", len(exprs), len(want))
+	}
+	for i, expr := range exprs {
+		if got := extractPathFromExpression(expr, info); got != want[i] {
+			t.Errorf("第 %d 个表达式应解析为 %q，实际为 %q", i, want[i], got)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// 文件位置: pkg/extractor/route_table.go
+package extractor
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"log"
+	"strings"
+
+	"github.com/YogeLiu/api-tool/pkg/models"
+	"github.com/YogeLiu/api-tool/pkg/parser"
+	"golang.org/x/tools/go/packages"
+)
+
+// expandRouteTable 展开声明式路由表，供各框架提取器共用。
+// methodExpr、pathExpr、handlerExpr 必须是同一个 range 循环变量的字段（如 rt.Method、rt.Path、rt.Handler），
+// 循环对象为包级切片变量或切片字面量，元素为包含对应字段的结构体字面量。
+func expandRouteTable(project *parser.Project, methodExpr, pathExpr, handlerExpr ast.Expr, typeInfo *types.Info) []*models.RouteTableEntry {
+	methodField, rangeVar := rangeVarField(methodExpr, typeInfo)
+	pathField, pathVar := rangeVarField(pathExpr, typeInfo)
+	handlerField, handlerVar := rangeVarField(handlerExpr, typeInfo)
+	if rangeVar == nil || pathVar != rangeVar || handlerVar != rangeVar {
+		return nil
+	}
+
+	rangeExpr, rangePkg := findRangeExpr(project, rangeVar)
+	if rangeExpr == nil {
+		log.Printf("[DEBUG] expandRouteTable: 未找到循环变量 %s 对应的 range 语句\n", rangeVar.Name())
+		return nil
+	}
+
+	table, tablePkg := findSliceLiteral(project, rangeExpr, rangePkg)
+	if table == nil {
+		log.Printf("[DEBUG] expandRouteTable: 循环对象不是切片字面量\n")
+		return nil
+	}
+
+	var entries []*models.RouteTableEntry
+	for _, elt := range table.Elts {
+		fields := structLiteralFields(elt, tablePkg.TypesInfo)
+		if fields == nil {
+			continue
+		}
+
+		method := constantString(fields[methodField], tablePkg.TypesInfo)
+		handler := fields[handlerField]
+		if method == "" || handler == nil || fields[pathField] == nil {
+			continue
+		}
+
+		entries = append(entries, &models.RouteTableEntry{
+			Method:   strings.ToUpper(method),
+			Path:     extractPathFromExpression(fields[pathField], tablePkg.TypesInfo),
+			Handler:  handler,
+			TypeInfo: tablePkg.TypesInfo,
+		})
+	}
+
+	log.Printf("[DEBUG] expandRouteTable: 从路由表中展开 %d 条路由\n", len(entries))
+	return entries
+}
+
+// rangeVarField 如果表达式为 变量.字段，返回字段名和变量对象
+func rangeVarField(expr ast.Expr, typeInfo *types.Info) (string, *types.Var) {
+	selExpr, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", nil
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok {
+		return "", nil
+	}
+	v, ok := typeInfo.ObjectOf(ident).(*types.Var)
+	if !ok {
+		return "", nil
+	}
+	return selExpr.Sel.Name, v
+}
+
+// findRangeExpr 查找以 rangeVar 为值变量的 range 语句，返回被遍历的表达式及其所在包
+func findRangeExpr(project *parser.Project, rangeVar *types.Var) (ast.Expr, *packages.Package) {
+	for _, pkg := range project.Packages {
+		if pkg.Types != rangeVar.Pkg() {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			if file.Pos() > rangeVar.Pos() || rangeVar.Pos() > file.End() {
+				continue
+			}
+			var rangeExpr ast.Expr
+			ast.Inspect(file, func(node ast.Node) bool {
+				if rangeStmt, ok := node.(*ast.RangeStmt); ok {
+					if ident, ok := rangeStmt.Value.(*ast.Ident); ok && pkg.TypesInfo.Defs[ident] == rangeVar {
+						rangeExpr = rangeStmt.X
+					}
+				}
+				return rangeExpr == nil
+			})
+			if rangeExpr != nil {
+				return rangeExpr, pkg
+			}
+		}
+	}
+	return nil, nil
+}
+
+// findSliceLiteral 解析被遍历的表达式：切片字面量直接返回，变量则查找其包级声明中的初始值
+func findSliceLiteral(project *parser.Project, expr ast.Expr, pkg *packages.Package) (*ast.CompositeLit, *packages.Package) {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return e, pkg
+	case *ast.ParenExpr:
+		return findSliceLiteral(project, e.X, pkg)
+	case *ast.Ident:
+		return findVarInitializer(project, pkg.TypesInfo.ObjectOf(e))
+	case *ast.SelectorExpr:
+		// 其他包中的路由表: router.Routes
+		return findVarInitializer(project, pkg.TypesInfo.ObjectOf(e.Sel))
+	}
+	return nil, nil
+}
+
+// findVarInitializer 查找包级变量声明中的切片字面量初始值
+func findVarInitializer(project *parser.Project, obj types.Object) (*ast.CompositeLit, *packages.Package) {
+	v, ok := obj.(*types.Var)
+	if !ok || v.Pkg() == nil {
+		return nil, nil
+	}
+
+	for _, pkg := range project.Packages {
+		if pkg.Types != v.Pkg() {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					for i, name := range valueSpec.Names {
+						if pkg.TypesInfo.Defs[name] != v || i >= len(valueSpec.Values) {
+							continue
+						}
+						if compLit, ok := valueSpec.Values[i].(*ast.CompositeLit); ok {
+							return compLit, pkg
+						}
+						return nil, nil
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// structLiteralFields 返回结构体字面量中 字段名 -> 值表达式 的映射，支持键值形式和按位置赋值
+func structLiteralFields(expr ast.Expr, typeInfo *types.Info) map[string]ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	compLit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	var structType *types.Struct
+	if typ := typeInfo.TypeOf(compLit); typ != nil {
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		structType, _ = typ.Underlying().(*types.Struct)
+	}
+
+	fields := make(map[string]ast.Expr)
+	for i, elt := range compLit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				fields[key.Name] = kv.Value
+			}
+		} else if structType != nil && i < structType.NumFields() {
+			fields[structType.Field(i).Name()] = elt
+		}
+	}
+	return fields
+}
+
+// constantString 返回表达式的字符串常量值（如 "GET" 或 http.MethodGet）
+func constantString(expr ast.Expr, typeInfo *types.Info) string {
+	if expr == nil {
+		return ""
+	}
+	if tv, ok := typeInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value)
+	}
+	return ""
+}
@@ -3,30 +3,77 @@ package models
 
 import (
 	"go/ast"
+	"go/types"
+	"time"
 
 	"golang.org/x/tools/go/packages"
 )
 
 // APIInfo 代表整个API的结构化信息
 type APIInfo struct {
-	Routes []RouteInfo `json:"routes"`
+	Routes   []RouteInfo `json:"routes"`
+	Warnings []string    `json:"warnings,omitempty"` // 分析过程中的警告，如无法解析处理函数的路由
+	Page     *PageInfo   `json:"page,omitempty"`     // 分页输出时当前文件的分页信息
+}
+
+// PageInfo 路由较多时JSON输出按页拆分为多个文件，每个文件记录所在页及总数
+type PageInfo struct {
+	Page        int `json:"page"`         // 当前页，从1开始
+	TotalPages  int `json:"total_pages"`  // 总页数
+	TotalRoutes int `json:"total_routes"` // 所有页的路由总数
+}
+
+// PhaseTiming 分析阶段的耗时统计
+type PhaseTiming struct {
+	Phase    string        `json:"phase"`    // 阶段名称，如 preprocess、index、analyze
+	Duration time.Duration `json:"duration"` // 耗时
+	Count    int           `json:"count"`    // 阶段处理的对象数量（包、路由分组函数、路由等）
 }
 
 // RouteInfo 代表单个API路由的信息
 type RouteInfo struct {
-	PackageName      string `json:"package_name"`       // 包名
-	PackagePath      string `json:"package_path"`       // 包路径
-	Method           string `json:"method"`             // HTTP方法 (GET, POST, PUT, DELETE等)
-	Path             string `json:"path"`               // 路由路径
-	Handler          string `json:"handler"`            // 处理函数名称
-	HandlerStartLine int    `json:"handler_start_line"` // 处理函数开始行号
-	HandlerEndLine   int    `json:"handler_end_line"`   // 处理函数结束行号
+	PackageName       string `json:"package_name"`                 // 包名
+	PackagePath       string `json:"package_path"`                 // 包路径
+	Method            string `json:"method"`                       // HTTP方法 (GET, POST, PUT, DELETE等)
+	Path              string `json:"path"`                         // 路由路径
+	Handler           string `json:"handler"`                      // 处理函数名称
+	HandlerStartLine  int    `json:"handler_start_line"`           // 处理函数开始行号
+	HandlerEndLine    int    `json:"handler_end_line"`             // 处理函数结束行号
+	HandlerFile       string `json:"handler_file,omitempty"`       // 处理函数所在的源文件（相对项目根目录），开启源码位置输出时设置
+	HandlerAdapter    string `json:"handler_adapter,omitempty"`    // 处理函数适配器，如 gin.WrapF（标准库处理函数）
+	HandlerUnresolved bool   `json:"handler_unresolved,omitempty"` // 无法解析处理函数，Handler 为注册时的处理函数表达式，没有请求和响应信息
+	Deprecated        bool   `json:"deprecated,omitempty"`         // 处理函数注释中标记了 Deprecated:
+	Kind              string `json:"kind,omitempty"`               // 路由类型，为空表示普通HTTP接口，websocket 表示 WebSocket 升级接口，redirect 表示只重定向的接口
+	Server            string `json:"server,omitempty"`             // 注册路由的根路由器名称，仅项目中存在多个根路由器（多个服务）时设置
+	Since             string `json:"since,omitempty"`              // 接口的引入日期 (YYYY-MM-DD)，来自处理函数的git提交历史，开启 -git-since 时设置
+
+	Summary     string `json:"summary,omitempty"`     // 接口摘要，来自处理函数文档注释的第一句，@Summary 注解优先
+	Description string `json:"description,omitempty"` // 接口描述，来自处理函数文档注释第一句之后的部分，@Description 注解优先
+
+	SuccessStatus       int    `json:"success_status,omitempty"`       // 成功响应的状态码（如 201），无法静态确定时为0
+	ResponseDescription string `json:"response_description,omitempty"` // 成功响应的描述，来自处理函数注释中的 "Response:" 说明
+
+	Middlewares []string `json:"middlewares,omitempty"` // 路由经过的中间件（按注册顺序），如外层分组 r.Group("/admin", AuthMW) 注册的 AuthMW
 
 	// 集成func_body解析结果
 	RequestParams  []RequestParamInfo `json:"request_params,omitempty"`  // 详细请求参数信息（来自func_body解析）
 	ResponseSchema *APISchema         `json:"response_schema,omitempty"` // 详细响应结构信息（来自func_body解析）
+
+	// MiddlewareRendered 处理函数没有直接渲染响应，只通过 c.Set 存储数据，由中间件渲染；
+	// ResponseSchema 为 c.Set 存储的值的结构（能解析时）
+	MiddlewareRendered bool `json:"middleware_rendered,omitempty"`
+
+	// Responses 按状态码（如 "200"、"400"）记录的响应结构，状态码无法静态确定的响应（如响应封装函数）记为 "default"；
+	// ResponseSchema 保留为成功响应的结构
+	Responses map[string]*APISchema `json:"responses,omitempty"`
 }
 
+// RouteKindWebSocket WebSocket 升级接口，没有JSON响应体
+const RouteKindWebSocket = "websocket"
+
+// RouteKindRedirect 只重定向到其他地址的接口，没有响应体
+const RouteKindRedirect = "redirect"
+
 // RequestInfo 代表API请求的信息
 type RequestInfo struct {
 	Params []FieldInfo `json:"params,omitempty"` // 路径参数
@@ -125,6 +172,24 @@ type RouterGroupFunction struct {
 	UniqueKey      string            `json:"unique_key"`       // 唯一标识 (packagePath+functionName)
 }
 
+// RouteRegistration 代表由提取器直接解析出的路由注册（如Beego的 beego.Router）
+type RouteRegistration struct {
+	Method      string            `json:"method"`       // HTTP方法
+	Path        string            `json:"path"`         // 完整路由路径
+	HandlerName string            `json:"handler_name"` // 处理函数名称 (如 UserController.Get)
+	FuncDecl    *ast.FuncDecl     `json:"-"`            // 处理函数声明（不序列化）
+	Package     *packages.Package `json:"-"`            // 处理函数所在包（不序列化）
+}
+
+// RouteTableEntry 代表声明式路由表中的一条路由
+// 如 var routes = []Route{{Method: "GET", Path: "/users", Handler: ListUsers}}
+type RouteTableEntry struct {
+	Method   string      `json:"method"` // HTTP方法
+	Path     string      `json:"path"`   // 路由表中声明的路径段
+	Handler  ast.Expr    `json:"-"`      // 处理函数表达式（不序列化）
+	TypeInfo *types.Info `json:"-"`      // 路由表所在包的类型信息（不序列化）
+}
+
 // ResponseFunction 代表响应封装函数的信息
 type ResponseFunction struct {
 	PackagePath     string            `json:"package_path"`      // 包路径
@@ -152,11 +217,12 @@ type ResponseFunctionAnalysis struct {
 
 // RequestParamInfo 请求参数信息（来自func_body解析）
 type RequestParamInfo struct {
-	ParamType   string     `json:"param_type"`   // "query", "body", "path"
-	ParamName   string     `json:"param_name"`   // 参数名称
-	ParamSchema *APISchema `json:"param_schema"` // 参数结构
-	IsRequired  bool       `json:"is_required"`  // 是否必需
-	Source      string     `json:"source"`       // 来源方法: "c.Query", "c.ShouldBindJSON", etc.
+	ParamType   string     `json:"param_type"`             // "query", "body", "path"
+	ParamName   string     `json:"param_name"`             // 参数名称
+	ParamSchema *APISchema `json:"param_schema"`           // 参数结构
+	IsRequired  bool       `json:"is_required"`            // 是否必需
+	Source      string     `json:"source"`                 // 来源方法: "c.Query", "c.ShouldBindJSON", etc.
+	ContentType string     `json:"content_type,omitempty"` // 请求体格式，如 "application/json"、"multipart/form-data"
 }
 
 // APISchema API结构定义（来自func_body解析）
@@ -166,4 +232,16 @@ type APISchema struct {
 	Items       *APISchema            `json:"items,omitempty"`
 	Description string                `json:"description,omitempty"`
 	JSONTag     string                `json:"json_tag,omitempty"`
+	FormTag     string                `json:"form_tag,omitempty"`     // form 标签，表单绑定时的字段名
+	OneOf       []*APISchema          `json:"one_of,omitempty"`       // 多个候选结构（如接口有多个实现）
+	Package     string                `json:"package,omitempty"`      // 命名类型所在的包路径，用于区分不同包中的同名类型
+	Format      string                `json:"format,omitempty"`       // 格式，如 binary（文件/二进制响应）
+	ContentType string                `json:"content_type,omitempty"` // 非JSON响应体的格式，如 image/png
+	Nullable    bool                  `json:"nullable,omitempty"`     // 值可以为 null（指针类型的字段、切片元素、map值）
+	Required    bool                  `json:"required,omitempty"`     // 字段为必填项（binding:"required" 或 validate:"required"）
+	ReadOnly    bool                  `json:"read_only,omitempty"`    // 字段只出现在响应中（readonly:"true"）
+	WriteOnly   bool                  `json:"write_only,omitempty"`   // 字段只出现在请求中（writeonly:"true"）
+	Default     interface{}           `json:"default,omitempty"`      // 字段的默认值（default:"10"），按字段类型解析为对应的JSON类型
+	Example     interface{}           `json:"example,omitempty"`      // 字段的示例值，如响应 map 中枚举常量的值
+	Map         bool                  `json:"map,omitempty"`          // 键值对类型的 map（含以map为底层类型的命名类型），Properties 中为 <key>/<value>
 }
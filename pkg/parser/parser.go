@@ -6,14 +6,28 @@ import (
 	"go/ast"
 	"go/token"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/YogeLiu/api-tool/pkg/models"
 
 	"golang.org/x/tools/go/packages"
 )
 
+// Options 项目解析配置
+type Options struct {
+	IncludeTests bool     // 同时加载 _test.go 文件（如在测试中注册的路由）
+	BuildFlags   []string // 传给 go 命令的构建参数，如 -tags=prod，用于加载带构建约束的路由文件
+	Env          []string // 额外的环境变量，如 GOOS=linux、GOARCH=arm64，覆盖当前环境中的同名变量
+}
+
 // ParseProject 解析指定路径的Go项目
 func ParseProject(projectPath string) (*Project, error) {
+	return ParseProjectWithOptions(projectPath, Options{})
+}
+
+// ParseProjectWithOptions 按配置解析指定路径的Go项目
+func ParseProjectWithOptions(projectPath string, options Options) (*Project, error) {
 	// 配置包加载选项
 	cfg := &packages.Config{
 		Mode: packages.NeedName |
@@ -22,9 +36,11 @@ func ParseProject(projectPath string) (*Project, error) {
 			packages.NeedTypes |
 			packages.NeedTypesInfo |
 			packages.NeedDeps,
-		Tests: false,
-		Dir:   projectPath,
-		Env:   append(os.Environ(), "GOFLAGS=-mod=vendor"),
+		Tests:      options.IncludeTests,
+		Dir:        projectPath,
+		BuildFlags: options.BuildFlags,
+		// 同名环境变量以最后出现的为准，调用方指定的变量放在最后
+		Env: append(append(os.Environ(), "GOFLAGS=-mod=vendor"), options.Env...),
 	}
 
 	// 加载项目中的所有包
@@ -61,6 +77,10 @@ func ParseProject(projectPath string) (*Project, error) {
 		}
 	}
 
+	if options.IncludeTests {
+		validPkgs = selectTestVariants(validPkgs)
+	}
+
 	if len(validPkgs) == 0 {
 		return nil, &models.ParseError{
 			Path:   projectPath,
@@ -73,6 +93,56 @@ func ParseProject(projectPath string) (*Project, error) {
 	return project, nil
 }
 
+// selectTestVariants 加载测试文件时，同一个包会同时出现普通版本和包含 _test.go 的测试版本（ID 如 "p [p.test]"），
+// 保留文件更全的测试版本，并去掉生成的测试主包（p.test），避免重复分析同一份代码
+func selectTestVariants(pkgs []*packages.Package) []*packages.Package {
+	selected := make(map[string]*packages.Package)
+	var order []string
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		existing, ok := selected[pkg.PkgPath]
+		if !ok {
+			order = append(order, pkg.PkgPath)
+		}
+		if !ok || (existing.ID == existing.PkgPath && pkg.ID != pkg.PkgPath) {
+			selected[pkg.PkgPath] = pkg
+		}
+	}
+
+	result := make([]*packages.Package, 0, len(order))
+	for _, pkgPath := range order {
+		result = append(result, selected[pkgPath])
+	}
+	return result
+}
+
+// ModulePath 读取项目的模块路径：从 projectPath 向上查找最近的 go.mod，返回其中 module 指令声明的路径
+func ModulePath(projectPath string) (string, error) {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", err
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) >= 2 && fields[0] == "module" {
+					return strings.Trim(fields[1], `"`), nil
+				}
+			}
+			return "", fmt.Errorf("%s 中没有 module 指令", filepath.Join(dir, "go.mod"))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("未找到 go.mod: %s", projectPath)
+		}
+		dir = parent
+	}
+}
+
 // GetFilePosition 获取AST节点在源文件中的位置信息
 func GetFilePosition(pkg *packages.Package, pos token.Pos) (string, int, error) {
 	if !pos.IsValid() {